@@ -24,6 +24,7 @@ import (
 	gsrpc "github.com/centrifuge/go-substrate-rpc-client/v4"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/config"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/tx"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 )
 
@@ -264,6 +265,47 @@ func Example_makeASimpleTransfer() {
 	// Output: Balance transferred from Alice to Bob: 100000000000000
 }
 
+func Example_makeASimpleTransferWithBuilder() {
+	// This sample shows how to create a transfer with a transaction builder, which resolves the nonce, the era, the
+	// runtime versions and the genesis hash automatically.
+
+	api, err := gsrpc.NewSubstrateAPI(config.Default().RPCURL)
+	if err != nil {
+		panic(err)
+	}
+
+	meta, err := api.RPC.State.GetMetadataLatest()
+	if err != nil {
+		panic(err)
+	}
+
+	bob, err := types.NewMultiAddressFromHexAccountID("0x8eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48")
+	if err != nil {
+		panic(err)
+	}
+
+	c, err := types.NewCall(meta, "Balances.transfer", bob, types.NewUCompactFromUInt(12345))
+	if err != nil {
+		panic(err)
+	}
+
+	// The extrinsic is mortal by default, valid for 64 blocks after the latest finalized block
+	ext, err := tx.NewBuilder(api.RPC, c, signature.TestKeyringPairAlice).
+		WithMetadata(meta).
+		WithTip(types.NewUCompactFromUInt(100)).
+		Build()
+	if err != nil {
+		panic(err)
+	}
+
+	hash, err := api.RPC.Author.SubmitExtrinsic(ext)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("Transfer submitted with hash %v\n", hash.Hex())
+}
+
 func Example_displaySystemEvents() {
 	// Query the system events and extract information from them. This example runs until exited via Ctrl-C
 
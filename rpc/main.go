@@ -24,6 +24,7 @@ import (
 	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/contract"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/mmr"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/offchain"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/payment"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/system"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
@@ -35,6 +36,7 @@ type RPC struct {
 	Chain    chain.Chain
 	MMR      mmr.MMR
 	Offchain offchain.Offchain
+	Payment  payment.Payment
 	State    state.State
 	System   system.System
 	client   client.Client
@@ -57,6 +59,7 @@ func NewRPC(cl client.Client) (*RPC, error) {
 		Chain:    chain.NewChain(cl),
 		MMR:      mmr.NewMMR(cl),
 		Offchain: offchain.NewOffchain(cl),
+		Payment:  payment.NewPayment(cl),
 		State:    st,
 		System:   system.NewSystem(cl),
 		client:   cl,
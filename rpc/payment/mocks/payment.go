@@ -0,0 +1,197 @@
+// Code generated by mockery v2.13.0-beta.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+
+	types "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// Payment is an autogenerated mock type for the Payment type
+type Payment struct {
+	mock.Mock
+}
+
+// QueryFeeDetails provides a mock function with given fields: ext, blockHash
+func (_m *Payment) QueryFeeDetails(ext types.Extrinsic, blockHash types.Hash) (types.FeeDetails, error) {
+	ret := _m.Called(ext, blockHash)
+
+	var r0 types.FeeDetails
+	if rf, ok := ret.Get(0).(func(types.Extrinsic, types.Hash) types.FeeDetails); ok {
+		r0 = rf(ext, blockHash)
+	} else {
+		r0 = ret.Get(0).(types.FeeDetails)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(types.Extrinsic, types.Hash) error); ok {
+		r1 = rf(ext, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// QueryFeeDetailsLatest provides a mock function with given fields: ext
+func (_m *Payment) QueryFeeDetailsLatest(ext types.Extrinsic) (types.FeeDetails, error) {
+	ret := _m.Called(ext)
+
+	var r0 types.FeeDetails
+	if rf, ok := ret.Get(0).(func(types.Extrinsic) types.FeeDetails); ok {
+		r0 = rf(ext)
+	} else {
+		r0 = ret.Get(0).(types.FeeDetails)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(types.Extrinsic) error); ok {
+		r1 = rf(ext)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// QueryFeeDetailsRuntimeAPI provides a mock function with given fields: ext, blockHash
+func (_m *Payment) QueryFeeDetailsRuntimeAPI(ext types.Extrinsic, blockHash types.Hash) (types.FeeDetails, error) {
+	ret := _m.Called(ext, blockHash)
+
+	var r0 types.FeeDetails
+	if rf, ok := ret.Get(0).(func(types.Extrinsic, types.Hash) types.FeeDetails); ok {
+		r0 = rf(ext, blockHash)
+	} else {
+		r0 = ret.Get(0).(types.FeeDetails)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(types.Extrinsic, types.Hash) error); ok {
+		r1 = rf(ext, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// QueryFeeDetailsRuntimeAPILatest provides a mock function with given fields: ext
+func (_m *Payment) QueryFeeDetailsRuntimeAPILatest(ext types.Extrinsic) (types.FeeDetails, error) {
+	ret := _m.Called(ext)
+
+	var r0 types.FeeDetails
+	if rf, ok := ret.Get(0).(func(types.Extrinsic) types.FeeDetails); ok {
+		r0 = rf(ext)
+	} else {
+		r0 = ret.Get(0).(types.FeeDetails)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(types.Extrinsic) error); ok {
+		r1 = rf(ext)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// QueryInfo provides a mock function with given fields: ext, blockHash
+func (_m *Payment) QueryInfo(ext types.Extrinsic, blockHash types.Hash) (types.RuntimeDispatchInfo, error) {
+	ret := _m.Called(ext, blockHash)
+
+	var r0 types.RuntimeDispatchInfo
+	if rf, ok := ret.Get(0).(func(types.Extrinsic, types.Hash) types.RuntimeDispatchInfo); ok {
+		r0 = rf(ext, blockHash)
+	} else {
+		r0 = ret.Get(0).(types.RuntimeDispatchInfo)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(types.Extrinsic, types.Hash) error); ok {
+		r1 = rf(ext, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// QueryInfoLatest provides a mock function with given fields: ext
+func (_m *Payment) QueryInfoLatest(ext types.Extrinsic) (types.RuntimeDispatchInfo, error) {
+	ret := _m.Called(ext)
+
+	var r0 types.RuntimeDispatchInfo
+	if rf, ok := ret.Get(0).(func(types.Extrinsic) types.RuntimeDispatchInfo); ok {
+		r0 = rf(ext)
+	} else {
+		r0 = ret.Get(0).(types.RuntimeDispatchInfo)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(types.Extrinsic) error); ok {
+		r1 = rf(ext)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// QueryInfoRuntimeAPI provides a mock function with given fields: ext, blockHash
+func (_m *Payment) QueryInfoRuntimeAPI(ext types.Extrinsic, blockHash types.Hash) (types.RuntimeDispatchInfo, error) {
+	ret := _m.Called(ext, blockHash)
+
+	var r0 types.RuntimeDispatchInfo
+	if rf, ok := ret.Get(0).(func(types.Extrinsic, types.Hash) types.RuntimeDispatchInfo); ok {
+		r0 = rf(ext, blockHash)
+	} else {
+		r0 = ret.Get(0).(types.RuntimeDispatchInfo)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(types.Extrinsic, types.Hash) error); ok {
+		r1 = rf(ext, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// QueryInfoRuntimeAPILatest provides a mock function with given fields: ext
+func (_m *Payment) QueryInfoRuntimeAPILatest(ext types.Extrinsic) (types.RuntimeDispatchInfo, error) {
+	ret := _m.Called(ext)
+
+	var r0 types.RuntimeDispatchInfo
+	if rf, ok := ret.Get(0).(func(types.Extrinsic) types.RuntimeDispatchInfo); ok {
+		r0 = rf(ext)
+	} else {
+		r0 = ret.Get(0).(types.RuntimeDispatchInfo)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(types.Extrinsic) error); ok {
+		r1 = rf(ext)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type NewPaymentT interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewPayment creates a new instance of Payment. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewPayment(t NewPaymentT) *Payment {
+	mock := &Payment{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
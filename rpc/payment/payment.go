@@ -0,0 +1,48 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:generate mockery --name Payment --filename payment.go
+
+package payment
+
+import (
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// Payment exposes methods for the estimation of transaction fees. The RuntimeAPI methods call the
+// TransactionPaymentApi of the runtime via state_call, they can be used as a fallback on nodes that do not expose the
+// payment RPC methods.
+type Payment interface {
+	QueryInfo(ext types.Extrinsic, blockHash types.Hash) (types.RuntimeDispatchInfo, error)
+	QueryInfoLatest(ext types.Extrinsic) (types.RuntimeDispatchInfo, error)
+	QueryFeeDetails(ext types.Extrinsic, blockHash types.Hash) (types.FeeDetails, error)
+	QueryFeeDetailsLatest(ext types.Extrinsic) (types.FeeDetails, error)
+	QueryInfoRuntimeAPI(ext types.Extrinsic, blockHash types.Hash) (types.RuntimeDispatchInfo, error)
+	QueryInfoRuntimeAPILatest(ext types.Extrinsic) (types.RuntimeDispatchInfo, error)
+	QueryFeeDetailsRuntimeAPI(ext types.Extrinsic, blockHash types.Hash) (types.FeeDetails, error)
+	QueryFeeDetailsRuntimeAPILatest(ext types.Extrinsic) (types.FeeDetails, error)
+}
+
+// payment exposes methods for the estimation of transaction fees
+type payment struct {
+	client client.Client
+}
+
+// NewPayment creates a new payment struct
+func NewPayment(cl client.Client) Payment {
+	return &payment{cl}
+}
@@ -0,0 +1,237 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package payment
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var testPayment Payment
+
+func TestMain(m *testing.M) {
+	s := rpcmocksrv.New()
+	err := s.RegisterName("payment", &mockSrv)
+	if err != nil {
+		panic(err)
+	}
+
+	err = s.RegisterName("state", &mockStateSrv)
+	if err != nil {
+		panic(err)
+	}
+
+	cl, err := client.Connect(s.URL)
+	if err != nil {
+		panic(err)
+	}
+	testPayment = NewPayment(cl)
+
+	os.Exit(m.Run())
+}
+
+var (
+	testExtrinsic = types.NewExtrinsic(types.Call{CallIndex: types.CallIndex{SectionIndex: 6, MethodIndex: 0},
+		Args: types.Args{0x01, 0x02}})
+	testBlockHash   = types.NewHash(types.MustHexDecodeString("0xabcdef0000000000000000000000000000000000000000000000000000000001"))
+	testV1BlockHash = types.NewHash(types.MustHexDecodeString("0xabcdef0000000000000000000000000000000000000000000000000000000002"))
+	// at this block the encoded info with weights v2 has the same length as one with a one-dimensional weight
+	testShortV2BlockHash = types.NewHash(types.MustHexDecodeString("0xabcdef0000000000000000000000000000000000000000000000000000000003"))
+	testNoAPIBlockHash   = types.NewHash(types.MustHexDecodeString("0xabcdef0000000000000000000000000000000000000000000000000000000004"))
+)
+
+// MockSrv holds data and methods exposed by the RPC Mock Server used in integration tests
+type MockSrv struct {
+	info       string
+	feeDetails string
+}
+
+func (s *MockSrv) QueryInfo(ext string, at *string) (json.RawMessage, error) {
+	if err := mockSrv.checkExtrinsic(ext); err != nil {
+		return nil, err
+	}
+	return json.RawMessage(mockSrv.info), nil
+}
+
+func (s *MockSrv) QueryFeeDetails(ext string, at *string) (json.RawMessage, error) {
+	if err := mockSrv.checkExtrinsic(ext); err != nil {
+		return nil, err
+	}
+	return json.RawMessage(mockSrv.feeDetails), nil
+}
+
+func (s *MockSrv) checkExtrinsic(ext string) error {
+	enc, err := types.EncodeToHex(testExtrinsic)
+	if err != nil {
+		return err
+	}
+	if ext != enc {
+		return fmt.Errorf("unexpected extrinsic %v", ext)
+	}
+	return nil
+}
+
+// MockStateSrv serves the TransactionPaymentApi via state_call
+type MockStateSrv struct{}
+
+func (s *MockStateSrv) GetRuntimeVersion(at *string) (types.RuntimeVersion, error) {
+	version := types.RuntimeVersion{SpecName: "node", APIs: []types.RuntimeVersionAPI{
+		{APIID: "0xdf6acb689907609b", Version: 4},
+		{APIID: "0x37c8bb1350a9a2a8", Version: 4},
+	}}
+
+	switch {
+	case at == nil:
+	case *at == testV1BlockHash.Hex():
+		version.APIs[1].Version = 1
+	case *at == testNoAPIBlockHash.Hex():
+		version.APIs = version.APIs[:1]
+	}
+
+	return version, nil
+}
+
+func (s *MockStateSrv) Call(method string, data string, at *string) (string, error) {
+	enc, err := types.Encode(testExtrinsic)
+	if err != nil {
+		return "", err
+	}
+
+	expected, err := types.Hex(append(enc, byte(len(enc)), 0, 0, 0))
+	if err != nil {
+		return "", err
+	}
+
+	if data != expected {
+		return "", fmt.Errorf("unexpected data %v", data)
+	}
+
+	switch method {
+	case "TransactionPaymentApi_query_info":
+		if at != nil && *at == testV1BlockHash.Hex() {
+			return types.EncodeToHex(types.RuntimeDispatchInfoV1{
+				Weight:     195000000,
+				Class:      types.DispatchClass{IsNormal: true},
+				PartialFee: expectedInfo.PartialFee,
+			})
+		}
+		if at != nil && *at == testShortV2BlockHash.Hex() {
+			return types.EncodeToHex(expectedShortInfo)
+		}
+		return types.EncodeToHex(expectedInfo)
+	case "TransactionPaymentApi_query_fee_details":
+		details := expectedFeeDetails
+		details.Tip = types.NewU128(*big.NewInt(10))
+		return types.EncodeToHex(details)
+	default:
+		return "", fmt.Errorf("unknown method %v", method)
+	}
+}
+
+var expectedInfo = types.RuntimeDispatchInfo{
+	Weight:     types.NewWeightV2(195000000, 3593),
+	Class:      types.DispatchClass{IsNormal: true},
+	PartialFee: types.NewU128(*big.NewInt(15600000001)),
+}
+
+var expectedShortInfo = types.RuntimeDispatchInfo{
+	Weight:     types.NewWeightV2(300000000, 20000),
+	Class:      types.DispatchClass{IsNormal: true},
+	PartialFee: types.NewU128(*big.NewInt(15600000001)),
+}
+
+var expectedFeeDetails = types.FeeDetails{
+	HasInclusionFee: true,
+	InclusionFee: types.InclusionFee{
+		BaseFee:           types.NewU128(*big.NewInt(125000000)),
+		LenFee:            types.NewU128(*big.NewInt(1430000000)),
+		AdjustedWeightFee: types.NewU128(*big.NewInt(0)),
+	},
+	Tip: types.NewU128(*big.NewInt(0)),
+}
+
+// mockSrv sets default data used in tests. This data might become stale when substrate is updated – just run the tests
+// against real servers and update the values stored here. To do that, replace s.URL with
+// config.Default().RPCURL
+var mockSrv = MockSrv{
+	info:       `{"weight":{"ref_time":195000000,"proof_size":3593},"class":"normal","partialFee":"15600000001"}`,
+	feeDetails: `{"inclusionFee":{"baseFee":"0x7735940","lenFee":"0x553c1180","adjustedWeightFee":"0x0"}}`,
+}
+
+var mockStateSrv = MockStateSrv{}
+
+func TestPayment_QueryInfo(t *testing.T) {
+	info, err := testPayment.QueryInfoLatest(testExtrinsic)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedInfo, info)
+
+	info, err = testPayment.QueryInfo(testExtrinsic, testBlockHash)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedInfo, info)
+}
+
+func TestPayment_QueryFeeDetails(t *testing.T) {
+	details, err := testPayment.QueryFeeDetailsLatest(testExtrinsic)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedFeeDetails, details)
+
+	details, err = testPayment.QueryFeeDetails(testExtrinsic, testBlockHash)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedFeeDetails, details)
+}
+
+func TestPayment_QueryInfoRuntimeAPI(t *testing.T) {
+	info, err := testPayment.QueryInfoRuntimeAPILatest(testExtrinsic)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedInfo, info)
+
+	info, err = testPayment.QueryInfoRuntimeAPI(testExtrinsic, testV1BlockHash)
+	assert.NoError(t, err)
+	assert.Equal(t, types.RuntimeDispatchInfo{
+		Weight:     types.NewWeightV2(195000000, 0),
+		Class:      types.DispatchClass{IsNormal: true},
+		PartialFee: expectedInfo.PartialFee,
+	}, info)
+
+	enc, err := types.Encode(expectedShortInfo)
+	assert.NoError(t, err)
+	assert.Len(t, enc, 25)
+
+	info, err = testPayment.QueryInfoRuntimeAPI(testExtrinsic, testShortV2BlockHash)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedShortInfo, info)
+
+	_, err = testPayment.QueryInfoRuntimeAPI(testExtrinsic, testNoAPIBlockHash)
+	assert.EqualError(t, err, "runtime node does not implement the TransactionPaymentApi")
+}
+
+func TestPayment_QueryFeeDetailsRuntimeAPI(t *testing.T) {
+	details, err := testPayment.QueryFeeDetailsRuntimeAPI(testExtrinsic, testBlockHash)
+	assert.NoError(t, err)
+
+	expected := expectedFeeDetails
+	expected.Tip = types.NewU128(*big.NewInt(10))
+	assert.Equal(t, expected, details)
+}
@@ -0,0 +1,47 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package payment
+
+import (
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// QueryFeeDetails retrieves the components of the inclusion fee of an extrinsic at the given block
+func (p *payment) QueryFeeDetails(ext types.Extrinsic, blockHash types.Hash) (types.FeeDetails, error) {
+	return p.queryFeeDetails(ext, &blockHash)
+}
+
+// QueryFeeDetailsLatest retrieves the components of the inclusion fee of an extrinsic at the latest block
+func (p *payment) QueryFeeDetailsLatest(ext types.Extrinsic) (types.FeeDetails, error) {
+	return p.queryFeeDetails(ext, nil)
+}
+
+func (p *payment) queryFeeDetails(ext types.Extrinsic, blockHash *types.Hash) (types.FeeDetails, error) {
+	enc, err := types.EncodeToHex(ext)
+	if err != nil {
+		return types.FeeDetails{}, err
+	}
+
+	var details types.FeeDetails
+	err = client.CallWithBlockHash(p.client, &details, "payment_queryFeeDetails", blockHash, enc)
+	if err != nil {
+		return types.FeeDetails{}, err
+	}
+
+	return details, nil
+}
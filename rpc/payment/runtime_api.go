@@ -0,0 +1,156 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package payment
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// transactionPaymentAPIID is the ID of the TransactionPaymentApi in the APIs of the runtime version, the blake2b-64
+// hash of its name
+const transactionPaymentAPIID = "0x37c8bb1350a9a2a8"
+
+// weightV2APIVersion is the first version of the TransactionPaymentApi that returns weights v2, version 1 returns a
+// one-dimensional weight
+const weightV2APIVersion = 2
+
+// QueryInfoRuntimeAPI retrieves the weight, the class and the partial fee of an extrinsic at the given block by
+// calling TransactionPaymentApi_query_info
+func (p *payment) QueryInfoRuntimeAPI(ext types.Extrinsic, blockHash types.Hash) (types.RuntimeDispatchInfo, error) {
+	return p.queryInfoRuntimeAPI(ext, &blockHash)
+}
+
+// QueryInfoRuntimeAPILatest retrieves the weight, the class and the partial fee of an extrinsic at the latest block by
+// calling TransactionPaymentApi_query_info
+func (p *payment) QueryInfoRuntimeAPILatest(ext types.Extrinsic) (types.RuntimeDispatchInfo, error) {
+	return p.queryInfoRuntimeAPI(ext, nil)
+}
+
+func (p *payment) queryInfoRuntimeAPI(ext types.Extrinsic, blockHash *types.Hash) (types.RuntimeDispatchInfo,
+	error) {
+	version, err := p.transactionPaymentAPIVersion(blockHash)
+	if err != nil {
+		return types.RuntimeDispatchInfo{}, err
+	}
+
+	res, err := p.callRuntimeAPI("TransactionPaymentApi_query_info", ext, blockHash)
+	if err != nil {
+		return types.RuntimeDispatchInfo{}, err
+	}
+
+	if version < weightV2APIVersion {
+		var v1 types.RuntimeDispatchInfoV1
+		err = types.Decode(res, &v1)
+		if err != nil {
+			return types.RuntimeDispatchInfo{}, err
+		}
+
+		return types.RuntimeDispatchInfo{
+			Weight:     types.NewWeightV2(uint64(v1.Weight), 0),
+			Class:      v1.Class,
+			PartialFee: v1.PartialFee,
+		}, nil
+	}
+
+	var info types.RuntimeDispatchInfo
+	err = types.Decode(res, &info)
+	if err != nil {
+		return types.RuntimeDispatchInfo{}, err
+	}
+
+	return info, nil
+}
+
+// QueryFeeDetailsRuntimeAPI retrieves the components of the fee of an extrinsic at the given block by calling
+// TransactionPaymentApi_query_fee_details
+func (p *payment) QueryFeeDetailsRuntimeAPI(ext types.Extrinsic, blockHash types.Hash) (types.FeeDetails, error) {
+	return p.queryFeeDetailsRuntimeAPI(ext, &blockHash)
+}
+
+// QueryFeeDetailsRuntimeAPILatest retrieves the components of the fee of an extrinsic at the latest block by calling
+// TransactionPaymentApi_query_fee_details
+func (p *payment) QueryFeeDetailsRuntimeAPILatest(ext types.Extrinsic) (types.FeeDetails, error) {
+	return p.queryFeeDetailsRuntimeAPI(ext, nil)
+}
+
+func (p *payment) queryFeeDetailsRuntimeAPI(ext types.Extrinsic, blockHash *types.Hash) (types.FeeDetails, error) {
+	res, err := p.callRuntimeAPI("TransactionPaymentApi_query_fee_details", ext, blockHash)
+	if err != nil {
+		return types.FeeDetails{}, err
+	}
+
+	var details types.FeeDetails
+	err = types.Decode(res, &details)
+	if err != nil {
+		return types.FeeDetails{}, err
+	}
+
+	return details, nil
+}
+
+// transactionPaymentAPIVersion returns the version of the TransactionPaymentApi of the runtime at the given block
+func (p *payment) transactionPaymentAPIVersion(blockHash *types.Hash) (uint32, error) {
+	var runtimeVersion types.RuntimeVersion
+	err := client.CallWithBlockHash(p.client, &runtimeVersion, "state_getRuntimeVersion", blockHash)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, api := range runtimeVersion.APIs {
+		if strings.EqualFold(api.APIID, transactionPaymentAPIID) {
+			return uint32(api.Version), nil
+		}
+	}
+
+	return 0, fmt.Errorf("runtime %v does not implement the TransactionPaymentApi", runtimeVersion.SpecName)
+}
+
+// callRuntimeAPI calls a method of the TransactionPaymentApi via state_call, the arguments are the extrinsic and its
+// encoded length
+func (p *payment) callRuntimeAPI(method string, ext types.Extrinsic, blockHash *types.Hash) ([]byte, error) {
+	enc, err := types.Encode(ext)
+	if err != nil {
+		return nil, err
+	}
+
+	args, err := types.Encode(types.NewU32(uint32(len(enc))))
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := types.Hex(append(enc, args...))
+	if err != nil {
+		return nil, err
+	}
+
+	var res string
+	err = client.CallWithBlockHash(p.client, &res, "state_call", blockHash, method, data)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := types.HexDecodeString(res)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode result of %v: %w", method, err)
+	}
+
+	return b, nil
+}
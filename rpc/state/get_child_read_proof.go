@@ -0,0 +1,54 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// GetChildReadProof retrieves a proof of the values of the given keys in the child storage at the given block. The
+// child storage key is the prefixed key of the child trie, e.g. ":child_storage:default:" followed by the ID of the
+// child trie. The proof contains the nodes of the child trie and the nodes that prove its root against the state root.
+func (s *state) GetChildReadProof(childStorageKey types.StorageKey, keys []types.StorageKey, blockHash types.Hash) (
+	types.ReadProof, error) {
+	return s.getChildReadProof(childStorageKey, keys, &blockHash)
+}
+
+// GetChildReadProofLatest retrieves a proof of the values of the given keys in the child storage for the latest block
+// height
+func (s *state) GetChildReadProofLatest(childStorageKey types.StorageKey, keys []types.StorageKey) (types.ReadProof,
+	error) {
+	return s.getChildReadProof(childStorageKey, keys, nil)
+}
+
+func (s *state) getChildReadProof(childStorageKey types.StorageKey, keys []types.StorageKey, blockHash *types.Hash) (
+	types.ReadProof, error) {
+	hexKeys := make([]string, len(keys))
+	for i, key := range keys {
+		hexKeys[i] = key.Hex()
+	}
+
+	var res types.ReadProof
+	err := client.CallWithBlockHash(s.client, &res, "state_getChildReadProof", blockHash, childStorageKey.Hex(),
+		hexKeys)
+	if err != nil {
+		return types.ReadProof{}, err
+	}
+
+	return res, nil
+}
@@ -0,0 +1,41 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestState_GetChildReadProofLatest(t *testing.T) {
+	childKey := types.NewStorageKey(types.MustHexDecodeString(mockSrv.childStorageKeyHex))
+	key := types.NewStorageKey(types.MustHexDecodeString(mockSrv.childStorageTrieKeyHex))
+	proof, err := testState.GetChildReadProofLatest(childKey, []types.StorageKey{key})
+	assert.NoError(t, err)
+	assert.Equal(t, mockSrv.blockHashLatest, proof.At)
+	assert.Equal(t, [][]byte{{0x42, 0xaa, 0x04, 0xbb}}, proof.Proof)
+}
+
+func TestState_GetChildReadProof(t *testing.T) {
+	childKey := types.NewStorageKey(types.MustHexDecodeString(mockSrv.childStorageKeyHex))
+	key := types.NewStorageKey(types.MustHexDecodeString(mockSrv.childStorageTrieKeyHex))
+	proof, err := testState.GetChildReadProof(childKey, []types.StorageKey{key}, mockSrv.blockHashLatest)
+	assert.NoError(t, err)
+	assert.Equal(t, mockSrv.blockHashLatest, proof.At)
+}
@@ -0,0 +1,60 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// GetKeysPaged retrieves up to count keys with the given prefix, starting after startKey. The keys are ordered, the
+// last key of a page is the startKey of the next page. A nil startKey returns the first page.
+func (s *state) GetKeysPaged(prefix types.StorageKey, count uint32, startKey *types.StorageKey, blockHash types.Hash) (
+	[]types.StorageKey, error) {
+	return s.getKeysPaged(prefix, count, startKey, &blockHash)
+}
+
+// GetKeysPagedLatest retrieves up to count keys with the given prefix, starting after startKey, for the latest block
+// height
+func (s *state) GetKeysPagedLatest(prefix types.StorageKey, count uint32, startKey *types.StorageKey) (
+	[]types.StorageKey, error) {
+	return s.getKeysPaged(prefix, count, startKey, nil)
+}
+
+func (s *state) getKeysPaged(prefix types.StorageKey, count uint32, startKey *types.StorageKey,
+	blockHash *types.Hash) ([]types.StorageKey, error) {
+	var start *string
+	if startKey != nil {
+		hex := startKey.Hex()
+		start = &hex
+	}
+
+	var res []string
+	err := client.CallWithBlockHash(s.client, &res, "state_getKeysPaged", blockHash, prefix.Hex(), count, start)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]types.StorageKey, len(res))
+	for i, r := range res {
+		err = types.DecodeFromHex(r, &keys[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return keys, err
+}
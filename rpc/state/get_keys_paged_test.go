@@ -0,0 +1,42 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestState_GetKeysPagedLatest(t *testing.T) {
+	key := types.NewStorageKey(types.MustHexDecodeString(mockSrv.storageKeyHex))
+	keys, err := testState.GetKeysPagedLatest(key[:8], 10, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []types.StorageKey{key}, keys)
+
+	keys, err = testState.GetKeysPagedLatest(key[:8], 10, &key)
+	assert.NoError(t, err)
+	assert.Empty(t, keys)
+}
+
+func TestState_GetKeysPaged(t *testing.T) {
+	key := types.NewStorageKey(types.MustHexDecodeString(mockSrv.storageKeyHex))
+	keys, err := testState.GetKeysPaged(key[:8], 10, nil, mockSrv.blockHashLatest)
+	assert.NoError(t, err)
+	assert.Equal(t, []types.StorageKey{key}, keys)
+}
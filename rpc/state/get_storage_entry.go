@@ -0,0 +1,76 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"errors"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// ErrStorageNotFound is returned by GetStorageEntry if the value of an entry without a default (OptionQuery) is absent
+var ErrStorageNotFound = errors.New("storage value not found")
+
+// GetStorageEntry retrieves the value of the storage entry prefix.method with the given map arguments and decodes it
+// into the target. Unlike GetStorage, the metadata defaults are applied: if the value is absent, the default of the
+// entry is decoded into the target for entries with the Default modifier (ValueQuery), e.g. a zeroed AccountInfo, and
+// ErrStorageNotFound is returned for entries with the Optional modifier (OptionQuery). Requires metadata v14.
+func GetStorageEntry(s State, meta *types.Metadata, prefix, method string, target interface{}, blockHash types.Hash,
+	args ...[]byte) error {
+	return getStorageEntry(s, meta, prefix, method, target, &blockHash, args...)
+}
+
+// GetStorageEntryLatest retrieves the value of the storage entry prefix.method with the given map arguments for the
+// latest block height and decodes it into the target, applying the metadata defaults, see GetStorageEntry.
+func GetStorageEntryLatest(s State, meta *types.Metadata, prefix, method string, target interface{},
+	args ...[]byte) error {
+	return getStorageEntry(s, meta, prefix, method, target, nil, args...)
+}
+
+func getStorageEntry(s State, meta *types.Metadata, prefix, method string, target interface{}, blockHash *types.Hash,
+	args ...[]byte) error {
+	defaultValue, hasDefault, err := meta.FindStorageEntryDefault(prefix, method)
+	if err != nil {
+		return err
+	}
+
+	key, err := types.CreateStorageKey(meta, prefix, method, args...)
+	if err != nil {
+		return err
+	}
+
+	var raw *types.StorageDataRaw
+	if blockHash != nil {
+		raw, err = s.GetStorageRaw(key, *blockHash)
+	} else {
+		raw, err = s.GetStorageRawLatest(key)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if len(*raw) != 0 {
+		return types.Decode(*raw, target)
+	}
+
+	if !hasDefault {
+		return ErrStorageNotFound
+	}
+
+	return types.Decode(defaultValue, target)
+}
@@ -0,0 +1,81 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetStorageEntry(t *testing.T) {
+	meta := decodeTestMetadata(t)
+	alice, bob := testAccountID(1), testAccountID(2)
+
+	stored := make([]byte, 80)
+	stored[0] = 5 // nonce
+
+	s := newPagedState(t, meta, "System", "Account", map[string][][]byte{string(stored): {alice[:]}})
+	s.blockHash = &types.Hash{1}
+
+	var info types.AccountInfo
+	err := GetStorageEntry(s, meta, "System", "Account", &info, *s.blockHash, alice[:])
+	assert.NoError(t, err)
+	assert.Equal(t, types.U32(5), info.Nonce)
+
+	// the default of ValueQuery entries is decoded for absent values
+	info = types.AccountInfo{Nonce: 7}
+	info.Data.Free = types.NewU128(*big.NewInt(100))
+	err = GetStorageEntry(s, meta, "System", "Account", &info, *s.blockHash, bob[:])
+	assert.NoError(t, err)
+	assert.Equal(t, types.U32(0), info.Nonce)
+	assert.Equal(t, int64(0), info.Data.Free.Int64())
+}
+
+func TestGetStorageEntryLatest_OptionQuery(t *testing.T) {
+	meta := decodeTestMetadata(t)
+	alice, bob := testAccountID(1), testAccountID(2)
+
+	s := newPagedState(t, meta, "Staking", "Bonded", map[string][][]byte{string(bob[:]): {alice[:]}})
+
+	var controller types.AccountID
+	err := GetStorageEntryLatest(s, meta, "Staking", "Bonded", &controller, alice[:])
+	assert.NoError(t, err)
+	assert.Equal(t, bob, controller)
+
+	err = GetStorageEntryLatest(s, meta, "Staking", "Bonded", &controller, bob[:])
+	assert.True(t, errors.Is(err, ErrStorageNotFound))
+}
+
+func TestGetStorageEntry_Errors(t *testing.T) {
+	meta := decodeTestMetadata(t)
+	alice := testAccountID(1)
+
+	var info types.AccountInfo
+	err := GetStorageEntryLatest(&pagedState{}, meta, "System", "Accountz", &info, alice[:])
+	assert.EqualError(t, err, "storage Accountz not found within module System")
+
+	err = GetStorageEntryLatest(&pagedState{}, meta, "System", "Account", &info)
+	assert.Error(t, err)
+
+	err = GetStorageEntryLatest(&pagedState{err: errors.New("connection lost")}, meta, "System", "Account", &info,
+		alice[:])
+	assert.EqualError(t, err, "connection lost")
+}
@@ -59,6 +59,48 @@ func (_m *State) GetChildKeysLatest(childStorageKey types.StorageKey, prefix typ
 	return r0, r1
 }
 
+// GetChildReadProof provides a mock function with given fields: childStorageKey, keys, blockHash
+func (_m *State) GetChildReadProof(childStorageKey types.StorageKey, keys []types.StorageKey, blockHash types.Hash) (types.ReadProof, error) {
+	ret := _m.Called(childStorageKey, keys, blockHash)
+
+	var r0 types.ReadProof
+	if rf, ok := ret.Get(0).(func(types.StorageKey, []types.StorageKey, types.Hash) types.ReadProof); ok {
+		r0 = rf(childStorageKey, keys, blockHash)
+	} else {
+		r0 = ret.Get(0).(types.ReadProof)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(types.StorageKey, []types.StorageKey, types.Hash) error); ok {
+		r1 = rf(childStorageKey, keys, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetChildReadProofLatest provides a mock function with given fields: childStorageKey, keys
+func (_m *State) GetChildReadProofLatest(childStorageKey types.StorageKey, keys []types.StorageKey) (types.ReadProof, error) {
+	ret := _m.Called(childStorageKey, keys)
+
+	var r0 types.ReadProof
+	if rf, ok := ret.Get(0).(func(types.StorageKey, []types.StorageKey) types.ReadProof); ok {
+		r0 = rf(childStorageKey, keys)
+	} else {
+		r0 = ret.Get(0).(types.ReadProof)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(types.StorageKey, []types.StorageKey) error); ok {
+		r1 = rf(childStorageKey, keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetChildStorage provides a mock function with given fields: childStorageKey, key, target, blockHash
 func (_m *State) GetChildStorage(childStorageKey types.StorageKey, key types.StorageKey, target interface{}, blockHash types.Hash) (bool, error) {
 	ret := _m.Called(childStorageKey, key, target, blockHash)
@@ -281,6 +323,52 @@ func (_m *State) GetKeysLatest(prefix types.StorageKey) ([]types.StorageKey, err
 	return r0, r1
 }
 
+// GetKeysPaged provides a mock function with given fields: prefix, count, startKey, blockHash
+func (_m *State) GetKeysPaged(prefix types.StorageKey, count uint32, startKey *types.StorageKey, blockHash types.Hash) ([]types.StorageKey, error) {
+	ret := _m.Called(prefix, count, startKey, blockHash)
+
+	var r0 []types.StorageKey
+	if rf, ok := ret.Get(0).(func(types.StorageKey, uint32, *types.StorageKey, types.Hash) []types.StorageKey); ok {
+		r0 = rf(prefix, count, startKey, blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.StorageKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(types.StorageKey, uint32, *types.StorageKey, types.Hash) error); ok {
+		r1 = rf(prefix, count, startKey, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetKeysPagedLatest provides a mock function with given fields: prefix, count, startKey
+func (_m *State) GetKeysPagedLatest(prefix types.StorageKey, count uint32, startKey *types.StorageKey) ([]types.StorageKey, error) {
+	ret := _m.Called(prefix, count, startKey)
+
+	var r0 []types.StorageKey
+	if rf, ok := ret.Get(0).(func(types.StorageKey, uint32, *types.StorageKey) []types.StorageKey); ok {
+		r0 = rf(prefix, count, startKey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.StorageKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(types.StorageKey, uint32, *types.StorageKey) error); ok {
+		r1 = rf(prefix, count, startKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetMetadata provides a mock function with given fields: blockHash
 func (_m *State) GetMetadata(blockHash types.Hash) (*types.Metadata, error) {
 	ret := _m.Called(blockHash)
@@ -327,6 +415,48 @@ func (_m *State) GetMetadataLatest() (*types.Metadata, error) {
 	return r0, r1
 }
 
+// GetReadProof provides a mock function with given fields: keys, blockHash
+func (_m *State) GetReadProof(keys []types.StorageKey, blockHash types.Hash) (types.ReadProof, error) {
+	ret := _m.Called(keys, blockHash)
+
+	var r0 types.ReadProof
+	if rf, ok := ret.Get(0).(func([]types.StorageKey, types.Hash) types.ReadProof); ok {
+		r0 = rf(keys, blockHash)
+	} else {
+		r0 = ret.Get(0).(types.ReadProof)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]types.StorageKey, types.Hash) error); ok {
+		r1 = rf(keys, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReadProofLatest provides a mock function with given fields: keys
+func (_m *State) GetReadProofLatest(keys []types.StorageKey) (types.ReadProof, error) {
+	ret := _m.Called(keys)
+
+	var r0 types.ReadProof
+	if rf, ok := ret.Get(0).(func([]types.StorageKey) types.ReadProof); ok {
+		r0 = rf(keys)
+	} else {
+		r0 = ret.Get(0).(types.ReadProof)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]types.StorageKey) error); ok {
+		r1 = rf(keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetRuntimeVersion provides a mock function with given fields: blockHash
 func (_m *State) GetRuntimeVersion(blockHash types.Hash) (*types.RuntimeVersion, error) {
 	ret := _m.Called(blockHash)
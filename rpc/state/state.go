@@ -61,11 +61,22 @@ type State interface {
 	GetKeys(prefix types.StorageKey, blockHash types.Hash) ([]types.StorageKey, error)
 	GetKeysLatest(prefix types.StorageKey) ([]types.StorageKey, error)
 
+	GetKeysPaged(prefix types.StorageKey, count uint32, startKey *types.StorageKey, blockHash types.Hash) (
+		[]types.StorageKey, error)
+	GetKeysPagedLatest(prefix types.StorageKey, count uint32, startKey *types.StorageKey) ([]types.StorageKey, error)
+
 	GetStorageSize(key types.StorageKey, blockHash types.Hash) (types.U64, error)
 	GetStorageSizeLatest(key types.StorageKey) (types.U64, error)
 
 	GetChildStorageHash(childStorageKey, key types.StorageKey, blockHash types.Hash) (types.Hash, error)
 	GetChildStorageHashLatest(childStorageKey, key types.StorageKey) (types.Hash, error)
+
+	GetReadProof(keys []types.StorageKey, blockHash types.Hash) (types.ReadProof, error)
+	GetReadProofLatest(keys []types.StorageKey) (types.ReadProof, error)
+
+	GetChildReadProof(childStorageKey types.StorageKey, keys []types.StorageKey, blockHash types.Hash) (
+		types.ReadProof, error)
+	GetChildReadProofLatest(childStorageKey types.StorageKey, keys []types.StorageKey) (types.ReadProof, error)
 }
 
 // state exposes methods for querying state
@@ -81,6 +81,33 @@ func (s *MockSrv) GetKeys(key string, hash *string) []string {
 	return []string{mockSrv.storageKeyHex}
 }
 
+func (s *MockSrv) GetKeysPaged(key string, count uint32, startKey *string, hash *string) []string {
+	if !strings.HasPrefix(mockSrv.storageKeyHex, key) {
+		panic("key not found")
+	}
+	if count == 0 || (startKey != nil && *startKey >= mockSrv.storageKeyHex) {
+		return []string{}
+	}
+	return []string{mockSrv.storageKeyHex}
+}
+
+func (s *MockSrv) GetReadProof(keys []string, hash *string) types.ReadProof {
+	if len(keys) != 1 || keys[0] != mockSrv.storageKeyHex {
+		panic("key not found")
+	}
+	return types.ReadProof{At: mockSrv.blockHashLatest, Proof: [][]byte{{0x42, 0xaa, 0x04, 0xbb}}}
+}
+
+func (s *MockSrv) GetChildReadProof(childStorageKey string, keys []string, hash *string) types.ReadProof {
+	if childStorageKey != mockSrv.childStorageKeyHex {
+		panic("childStorageKey not found")
+	}
+	if len(keys) != 1 || keys[0] != mockSrv.childStorageTrieKeyHex {
+		panic("key not found")
+	}
+	return types.ReadProof{At: mockSrv.blockHashLatest, Proof: [][]byte{{0x42, 0xaa, 0x04, 0xbb}}}
+}
+
 func (s *MockSrv) GetStorage(key string, hash *string) string {
 	if key != s.storageKeyHex {
 		return ""
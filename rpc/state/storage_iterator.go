@@ -0,0 +1,213 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// DefaultStoragePageSize is the number of keys and values that are fetched at once by a StorageIterator. Nodes limit
+// the page size of state_getKeysPaged to 1000.
+const DefaultStoragePageSize = 100
+
+// StorageIterator iterates over the entries of a storage map, a double map or an n-map. Keys are fetched page by page
+// with state_getKeysPaged and the values of each page are fetched at once with state_queryStorageAt, so that large
+// maps like System.Account can be iterated without fetching all keys at once.
+//
+//	it, err := state.NewStorageIterator(api.RPC.State, meta, "System", "Account", blockHash)
+//	for it.Next() {
+//		var who types.AccountID
+//		var info types.AccountInfo
+//		err = it.DecodeKey(&who)
+//		ok, err = it.DecodeValue(&info)
+//	}
+//	err = it.Err()
+type StorageIterator struct {
+	state          State
+	meta           *types.Metadata
+	prefix, method string
+	keyPrefix      types.StorageKey
+	blockHash      *types.Hash
+	pageSize       uint32
+
+	keys     []types.StorageKey
+	values   map[string]types.KeyValueOption
+	pos      int
+	startKey *types.StorageKey
+	done     bool
+	err      error
+}
+
+// NewStorageIterator returns an iterator over the entries of the map prefix.method at the given block. The optional
+// args are the leading arguments of the map, e.g. to iterate over the entries of a double map with a given first
+// argument, see types.CreateStorageKeyPrefix.
+func NewStorageIterator(s State, meta *types.Metadata, prefix, method string, blockHash types.Hash,
+	args ...[]byte) (*StorageIterator, error) {
+	return newStorageIterator(s, meta, prefix, method, &blockHash, args...)
+}
+
+// NewStorageIteratorLatest returns an iterator over the entries of the map prefix.method at the latest block height.
+// Every page is fetched at the latest block height at the time it is fetched, use NewStorageIterator to get a
+// consistent view of a map that changes during the iteration.
+func NewStorageIteratorLatest(s State, meta *types.Metadata, prefix, method string, args ...[]byte) (
+	*StorageIterator, error) {
+	return newStorageIterator(s, meta, prefix, method, nil, args...)
+}
+
+func newStorageIterator(s State, meta *types.Metadata, prefix, method string, blockHash *types.Hash,
+	args ...[]byte) (*StorageIterator, error) {
+	keyPrefix, err := types.CreateStorageKeyPrefix(meta, prefix, method, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StorageIterator{
+		state:     s,
+		meta:      meta,
+		prefix:    prefix,
+		method:    method,
+		keyPrefix: keyPrefix,
+		blockHash: blockHash,
+		pageSize:  DefaultStoragePageSize,
+		pos:       -1,
+	}, nil
+}
+
+// WithPageSize sets the number of keys and values that are fetched at once, it has to be called before the first call
+// to Next
+func (it *StorageIterator) WithPageSize(pageSize uint32) *StorageIterator {
+	it.pageSize = pageSize
+	return it
+}
+
+// Next advances the iterator to the next entry, fetching the next page if needed. It returns false when there are no
+// more entries or an error occurred, see Err.
+func (it *StorageIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	if it.pos+1 < len(it.keys) {
+		it.pos++
+		return true
+	}
+
+	if it.done {
+		return false
+	}
+
+	it.err = it.fetchPage()
+	if it.err != nil || len(it.keys) == 0 {
+		return false
+	}
+
+	it.pos = 0
+
+	return true
+}
+
+// fetchPage fetches the next page of keys and their values
+func (it *StorageIterator) fetchPage() error {
+	if it.pageSize == 0 {
+		return fmt.Errorf("invalid page size 0")
+	}
+
+	var (
+		keys []types.StorageKey
+		err  error
+	)
+
+	if it.blockHash != nil {
+		keys, err = it.state.GetKeysPaged(it.keyPrefix, it.pageSize, it.startKey, *it.blockHash)
+	} else {
+		keys, err = it.state.GetKeysPagedLatest(it.keyPrefix, it.pageSize, it.startKey)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	it.keys = keys
+	it.pos = -1
+	it.done = uint32(len(keys)) < it.pageSize
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	it.startKey = &keys[len(keys)-1]
+
+	var changeSets []types.StorageChangeSet
+	if it.blockHash != nil {
+		changeSets, err = it.state.QueryStorageAt(keys, *it.blockHash)
+	} else {
+		changeSets, err = it.state.QueryStorageAtLatest(keys)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	it.values = make(map[string]types.KeyValueOption, len(keys))
+	for _, changeSet := range changeSets {
+		for _, change := range changeSet.Changes {
+			it.values[change.StorageKey.Hex()] = change
+		}
+	}
+
+	return nil
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *StorageIterator) Err() error {
+	return it.err
+}
+
+// Key returns the raw storage key of the current entry
+func (it *StorageIterator) Key() types.StorageKey {
+	return it.keys[it.pos]
+}
+
+// DecodeKey decodes the arguments of the key of the current entry into the given targets, see
+// types.DecodeStorageKeyArgs. Arguments can only be decoded if they are hashed with Blake2_128Concat, Twox64Concat or
+// Identity.
+func (it *StorageIterator) DecodeKey(targets ...interface{}) error {
+	return types.DecodeStorageKeyArgs(it.meta, it.prefix, it.method, it.Key(), targets...)
+}
+
+// Value returns the raw storage data of the current entry, ok is false if the entry has no value, e.g. because it was
+// removed after the keys were fetched
+func (it *StorageIterator) Value() (data types.StorageDataRaw, ok bool) {
+	change, ok := it.values[it.Key().Hex()]
+	if !ok || !change.HasStorageData {
+		return nil, false
+	}
+
+	return change.StorageData, true
+}
+
+// DecodeValue decodes the value of the current entry into the target, ok is false if the entry has no value
+func (it *StorageIterator) DecodeValue(target interface{}) (ok bool, err error) {
+	data, ok := it.Value()
+	if !ok {
+		return false, nil
+	}
+
+	return true, types.Decode(data, target)
+}
@@ -0,0 +1,212 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// pagedState serves keys and values from memory, other methods of State are not implemented
+type pagedState struct {
+	State
+	blockHash *types.Hash
+	storage   map[string][]byte
+	pages     int
+	err       error
+}
+
+func newPagedState(t *testing.T, meta *types.Metadata, prefix, method string, entries map[string][][]byte) *pagedState {
+	s := &pagedState{storage: map[string][]byte{}}
+	for value, args := range entries {
+		key, err := types.CreateStorageKey(meta, prefix, method, args...)
+		assert.NoError(t, err)
+		s.storage[string(key)] = []byte(value)
+	}
+	return s
+}
+
+func (s *pagedState) GetKeysPaged(prefix types.StorageKey, count uint32, startKey *types.StorageKey,
+	blockHash types.Hash) ([]types.StorageKey, error) {
+	if s.blockHash == nil || *s.blockHash != blockHash {
+		return nil, errors.New("unexpected block hash")
+	}
+	return s.GetKeysPagedLatest(prefix, count, startKey)
+}
+
+func (s *pagedState) GetKeysPagedLatest(prefix types.StorageKey, count uint32, startKey *types.StorageKey) (
+	[]types.StorageKey, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	s.pages++
+
+	var keys []string
+	for key := range s.storage {
+		if bytes.HasPrefix([]byte(key), prefix) && (startKey == nil || key > string(*startKey)) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	res := []types.StorageKey{}
+	for i := 0; i < len(keys) && i < int(count); i++ {
+		res = append(res, types.StorageKey(keys[i]))
+	}
+	return res, nil
+}
+
+func (s *pagedState) QueryStorageAt(keys []types.StorageKey, blockHash types.Hash) ([]types.StorageChangeSet, error) {
+	if s.blockHash == nil || *s.blockHash != blockHash {
+		return nil, errors.New("unexpected block hash")
+	}
+	return s.QueryStorageAtLatest(keys)
+}
+
+func (s *pagedState) QueryStorageAtLatest(keys []types.StorageKey) ([]types.StorageChangeSet, error) {
+	changeSet := types.StorageChangeSet{}
+	for _, key := range keys {
+		value, ok := s.storage[string(key)]
+		changeSet.Changes = append(changeSet.Changes, types.KeyValueOption{
+			StorageKey:     key,
+			HasStorageData: ok,
+			StorageData:    value,
+		})
+	}
+	return []types.StorageChangeSet{changeSet}, nil
+}
+
+func (s *pagedState) GetStorageRaw(key types.StorageKey, blockHash types.Hash) (*types.StorageDataRaw, error) {
+	if s.blockHash == nil || *s.blockHash != blockHash {
+		return nil, errors.New("unexpected block hash")
+	}
+	return s.GetStorageRawLatest(key)
+}
+
+func (s *pagedState) GetStorageRawLatest(key types.StorageKey) (*types.StorageDataRaw, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	data := types.NewStorageDataRaw(s.storage[string(key)])
+	return &data, nil
+}
+
+func decodeTestMetadata(t *testing.T) *types.Metadata {
+	var meta types.Metadata
+	err := types.DecodeFromHex(types.MetadataV14Data, &meta)
+	assert.NoError(t, err)
+	return &meta
+}
+
+func testAccountID(b byte) types.AccountID {
+	return types.NewAccountID(bytes.Repeat([]byte{b}, 32))
+}
+
+func TestStorageIterator_Map(t *testing.T) {
+	meta := decodeTestMetadata(t)
+
+	for _, n := range []int{0, 1, 4, 5} {
+		entries := map[string][][]byte{}
+		for i := 0; i < n; i++ {
+			who := testAccountID(byte(i))
+			entries[string([]byte{byte(i)})] = [][]byte{who[:]}
+		}
+
+		s := newPagedState(t, meta, "System", "Account", entries)
+		s.blockHash = &types.Hash{1}
+
+		it, err := NewStorageIterator(s, meta, "System", "Account", *s.blockHash)
+		assert.NoError(t, err)
+		it.WithPageSize(2)
+
+		seen := map[types.AccountID]bool{}
+		for it.Next() {
+			var who types.AccountID
+			assert.NoError(t, it.DecodeKey(&who))
+
+			var value types.U8
+			ok, err := it.DecodeValue(&value)
+			assert.NoError(t, err)
+			assert.True(t, ok)
+			assert.Equal(t, testAccountID(byte(value)), who)
+
+			seen[who] = true
+		}
+
+		assert.NoError(t, it.Err())
+		assert.Len(t, seen, n)
+		assert.Equal(t, n/2+1, s.pages, "%d entries", n)
+	}
+}
+
+func TestStorageIterator_DoubleMapPrefix(t *testing.T) {
+	meta := decodeTestMetadata(t)
+	alice, bob := testAccountID(1), testAccountID(2)
+
+	s := newPagedState(t, meta, "Staking", "ErasStakers", map[string][][]byte{
+		"a": {{7, 0, 0, 0}, alice[:]},
+		"b": {{7, 0, 0, 0}, bob[:]},
+		"c": {{8, 0, 0, 0}, alice[:]},
+	})
+
+	it, err := NewStorageIteratorLatest(s, meta, "Staking", "ErasStakers", []byte{7, 0, 0, 0})
+	assert.NoError(t, err)
+
+	var stakers []types.AccountID
+	for it.Next() {
+		var era types.U32
+		var who types.AccountID
+		assert.NoError(t, it.DecodeKey(&era, &who))
+		assert.Equal(t, types.U32(7), era)
+
+		value, ok := it.Value()
+		assert.True(t, ok)
+		assert.Contains(t, []types.StorageDataRaw{types.StorageDataRaw("a"), types.StorageDataRaw("b")}, value)
+
+		stakers = append(stakers, who)
+	}
+
+	assert.NoError(t, it.Err())
+	assert.ElementsMatch(t, []types.AccountID{alice, bob}, stakers)
+}
+
+func TestStorageIterator_Errors(t *testing.T) {
+	meta := decodeTestMetadata(t)
+
+	_, err := NewStorageIteratorLatest(&pagedState{}, meta, "Timestamp", "Now")
+	assert.EqualError(t, err, "Timestamp:Now is not a map")
+
+	s := &pagedState{err: errors.New("connection lost")}
+	it, err := NewStorageIteratorLatest(s, meta, "System", "Account")
+	assert.NoError(t, err)
+	assert.False(t, it.Next())
+	assert.EqualError(t, it.Err(), "connection lost")
+	assert.False(t, it.Next())
+
+	it, err = NewStorageIteratorLatest(&pagedState{}, meta, "System", "Account")
+	assert.NoError(t, err)
+	it.WithPageSize(0)
+	assert.False(t, it.Next())
+	assert.EqualError(t, it.Err(), "invalid page size 0")
+}
@@ -0,0 +1,29 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// AccountNextIndex retrieves the next nonce of an account, taking the transactions in the pool into account. The
+// address is the SS58 address of the account.
+func (c *system) AccountNextIndex(address string) (types.U64, error) {
+	var n types.U64
+	err := c.client.Call(&n, "system_accountNextIndex", address)
+	return n, err
+}
@@ -0,0 +1,54 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// DryRun applies the extrinsic on top of the given block without submitting it and returns the result, which tells
+// whether the extrinsic is valid and whether its dispatch succeeds. The node has to expose the unsafe RPC methods.
+func (c *system) DryRun(ext types.Extrinsic, blockHash types.Hash) (types.ApplyExtrinsicResult, error) {
+	return c.dryRun(ext, &blockHash)
+}
+
+// DryRunLatest applies the extrinsic on top of the latest block without submitting it and returns the result
+func (c *system) DryRunLatest(ext types.Extrinsic) (types.ApplyExtrinsicResult, error) {
+	return c.dryRun(ext, nil)
+}
+
+func (c *system) dryRun(ext types.Extrinsic, blockHash *types.Hash) (types.ApplyExtrinsicResult, error) {
+	enc, err := types.EncodeToHex(ext)
+	if err != nil {
+		return types.ApplyExtrinsicResult{}, err
+	}
+
+	var res string
+	err = client.CallWithBlockHash(c.client, &res, "system_dryRun", blockHash, enc)
+	if err != nil {
+		return types.ApplyExtrinsicResult{}, err
+	}
+
+	var result types.ApplyExtrinsicResult
+	err = types.DecodeFromHex(res, &result)
+	if err != nil {
+		return types.ApplyExtrinsicResult{}, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,46 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSystem_DryRun(t *testing.T) {
+	res, err := testSystem.DryRun(mockSrv.dryRunExtrinsic, mockSrv.dryRunBlockHash)
+	assert.NoError(t, err)
+	assert.Equal(t, mockSrv.dryRunAtBlock, res)
+	assert.True(t, res.IsErr)
+	assert.True(t, res.AsErr.AsInvalid.IsStale)
+}
+
+func TestSystem_DryRunLatest(t *testing.T) {
+	res, err := testSystem.DryRunLatest(mockSrv.dryRunExtrinsic)
+	assert.NoError(t, err)
+	assert.Equal(t, mockSrv.dryRunLatest, res)
+	assert.False(t, res.IsSuccess())
+	assert.True(t, res.AsOk.AsErr.IsBadOrigin)
+}
+
+func TestSystem_DryRun_UnexpectedExtrinsic(t *testing.T) {
+	ext := types.NewExtrinsic(types.Call{CallIndex: types.CallIndex{SectionIndex: 1, MethodIndex: 1}})
+	_, err := testSystem.DryRunLatest(ext)
+	assert.Error(t, err)
+}
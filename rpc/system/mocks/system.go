@@ -13,6 +13,27 @@ type System struct {
 	mock.Mock
 }
 
+// AccountNextIndex provides a mock function with given fields: address
+func (_m *System) AccountNextIndex(address string) (types.U64, error) {
+	ret := _m.Called(address)
+
+	var r0 types.U64
+	if rf, ok := ret.Get(0).(func(string) types.U64); ok {
+		r0 = rf(address)
+	} else {
+		r0 = ret.Get(0).(types.U64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Chain provides a mock function with given fields:
 func (_m *System) Chain() (types.Text, error) {
 	ret := _m.Called()
@@ -34,6 +55,48 @@ func (_m *System) Chain() (types.Text, error) {
 	return r0, r1
 }
 
+// DryRun provides a mock function with given fields: ext, blockHash
+func (_m *System) DryRun(ext types.Extrinsic, blockHash types.Hash) (types.ApplyExtrinsicResult, error) {
+	ret := _m.Called(ext, blockHash)
+
+	var r0 types.ApplyExtrinsicResult
+	if rf, ok := ret.Get(0).(func(types.Extrinsic, types.Hash) types.ApplyExtrinsicResult); ok {
+		r0 = rf(ext, blockHash)
+	} else {
+		r0 = ret.Get(0).(types.ApplyExtrinsicResult)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(types.Extrinsic, types.Hash) error); ok {
+		r1 = rf(ext, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DryRunLatest provides a mock function with given fields: ext
+func (_m *System) DryRunLatest(ext types.Extrinsic) (types.ApplyExtrinsicResult, error) {
+	ret := _m.Called(ext)
+
+	var r0 types.ApplyExtrinsicResult
+	if rf, ok := ret.Get(0).(func(types.Extrinsic) types.ApplyExtrinsicResult); ok {
+		r0 = rf(ext)
+	} else {
+		r0 = ret.Get(0).(types.ApplyExtrinsicResult)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(types.Extrinsic) error); ok {
+		r1 = rf(ext)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Health provides a mock function with given fields:
 func (_m *System) Health() (types.Health, error) {
 	ret := _m.Called()
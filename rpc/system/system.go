@@ -31,6 +31,9 @@ type System interface {
 	Chain() (types.Text, error)
 	Version() (types.Text, error)
 	NetworkState() (types.NetworkState, error)
+	AccountNextIndex(address string) (types.U64, error)
+	DryRun(ext types.Extrinsic, blockHash types.Hash) (types.ApplyExtrinsicResult, error)
+	DryRunLatest(ext types.Extrinsic) (types.ApplyExtrinsicResult, error)
 }
 
 // system exposes methods for retrieval of system data
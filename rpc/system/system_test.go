@@ -17,6 +17,7 @@
 package system
 
 import (
+	"fmt"
 	"os"
 	"testing"
 
@@ -46,19 +47,46 @@ func TestMain(m *testing.M) {
 
 // MockSrv holds data and methods exposed by the RPC Mock Server used in integration tests
 type MockSrv struct {
-	chain        types.Text
-	health       types.Health
-	name         types.Text
-	networkState types.NetworkState
-	peers        []types.PeerInfo
-	properties   types.ChainProperties
-	version      types.Text
+	accountAddress   string
+	accountNextIndex types.U64
+	chain            types.Text
+	dryRunExtrinsic  types.Extrinsic
+	dryRunBlockHash  types.Hash
+	dryRunAtBlock    types.ApplyExtrinsicResult
+	dryRunLatest     types.ApplyExtrinsicResult
+	health           types.Health
+	name             types.Text
+	networkState     types.NetworkState
+	peers            []types.PeerInfo
+	properties       types.ChainProperties
+	version          types.Text
+}
+
+func (s *MockSrv) AccountNextIndex(address string) (types.U64, error) {
+	if address != mockSrv.accountAddress {
+		return 0, fmt.Errorf("unknown account %v", address)
+	}
+	return mockSrv.accountNextIndex, nil
 }
 
 func (s *MockSrv) Chain() types.Text {
 	return mockSrv.chain
 }
 
+func (s *MockSrv) DryRun(ext string, at *string) (string, error) {
+	enc, err := types.EncodeToHex(mockSrv.dryRunExtrinsic)
+	if err != nil {
+		return "", err
+	}
+	if ext != enc {
+		return "", fmt.Errorf("unexpected extrinsic %v", ext)
+	}
+	if at != nil && *at == mockSrv.dryRunBlockHash.Hex() {
+		return types.EncodeToHex(mockSrv.dryRunAtBlock)
+	}
+	return types.EncodeToHex(mockSrv.dryRunLatest)
+}
+
 func (s *MockSrv) Health() types.Health {
 	return mockSrv.health
 }
@@ -87,7 +115,16 @@ func (s *MockSrv) Version() types.Text {
 // against real servers and update the values stored here. To do that, replace s.URL with
 // config.Default().RPCURL
 var mockSrv = MockSrv{
-	chain:        "test-chain",
+	accountAddress:   "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY",
+	accountNextIndex: 42,
+	chain:            "test-chain",
+	dryRunExtrinsic: types.NewExtrinsic(types.Call{CallIndex: types.CallIndex{SectionIndex: 6, MethodIndex: 0},
+		Args: types.Args{0x01, 0x02}}),
+	dryRunBlockHash: types.NewHash(types.MustHexDecodeString("0xabcdef")),
+	dryRunAtBlock: types.ApplyExtrinsicResult{IsErr: true, AsErr: types.TransactionValidityError{IsInvalid: true,
+		AsInvalid: types.InvalidTransaction{IsStale: true}}},
+	dryRunLatest: types.ApplyExtrinsicResult{IsOk: true, AsOk: types.DispatchOutcome{IsErr: true,
+		AsErr: types.DispatchError{IsBadOrigin: true}}},
 	health:       types.Health{Peers: 2, IsSyncing: false, ShouldHavePeers: true},
 	name:         "test-node",
 	networkState: types.NetworkState{PeerID: "my-peer-id"},
@@ -0,0 +1,175 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/vedhavyas/go-subkey/v2"
+)
+
+// DeriveJunction is a single step of a derivation path. Hard junctions (//code) derive keys that can't be linked to
+// the parent public key, soft junctions (/code) derive keys whose public key can be derived from the parent public
+// key. Soft junctions are only supported by sr25519.
+type DeriveJunction struct {
+	// Code is the code of the junction, numeric codes are derived as 64 bit integers
+	Code   string
+	IsHard bool
+}
+
+// NewHardJunction returns a hard junction with the given code
+func NewHardJunction(code string) DeriveJunction {
+	return DeriveJunction{Code: code, IsHard: true}
+}
+
+// NewSoftJunction returns a soft junction with the given code
+func NewSoftJunction(code string) DeriveJunction {
+	return DeriveJunction{Code: code}
+}
+
+func (j DeriveJunction) String() string {
+	if j.IsHard {
+		return "//" + j.Code
+	}
+
+	return "/" + j.Code
+}
+
+// DerivationPath is a sequence of junctions, e.g. //polkadot//0/1
+type DerivationPath []DeriveJunction
+
+// ParseDerivationPath parses a derivation path like //polkadot//0/1, an empty string is the empty path
+func ParseDerivationPath(path string) (DerivationPath, error) {
+	var res DerivationPath
+
+	for path != "" {
+		if !strings.HasPrefix(path, "/") {
+			return nil, fmt.Errorf("invalid derivation path, expected / at %q", path)
+		}
+
+		path = path[1:]
+
+		var j DeriveJunction
+		if strings.HasPrefix(path, "/") {
+			j.IsHard = true
+			path = path[1:]
+		}
+
+		end := strings.Index(path, "/")
+		if end == -1 {
+			end = len(path)
+		}
+
+		j.Code = path[:end]
+		if j.Code == "" {
+			return nil, errors.New("invalid derivation path, junctions must not be empty")
+		}
+
+		res = append(res, j)
+		path = path[end:]
+	}
+
+	return res, nil
+}
+
+func (p DerivationPath) String() string {
+	var sb strings.Builder
+	for _, j := range p {
+		sb.WriteString(j.String())
+	}
+
+	return sb.String()
+}
+
+// SecretURI is a secret URI as used by subkey, which consists of a phrase, a derivation path and a password:
+//
+//	<phrase><path>///<password>
+//
+// The phrase is either a BIP39 mnemonic or a hex encoded seed, an empty phrase is the well known development phrase.
+// The password is used together with the mnemonic to create the seed of the key pair, it is ignored for hex encoded
+// seeds.
+type SecretURI struct {
+	Phrase   string
+	Path     DerivationPath
+	Password string
+}
+
+// ParseSecretURI parses a secret URI like "<mnemonic>//polkadot//0///password" or "//Alice"
+func ParseSecretURI(uri string) (SecretURI, error) {
+	var res SecretURI
+
+	if i := strings.Index(uri, "///"); i != -1 {
+		res.Password = uri[i+3:]
+		uri = uri[:i]
+	}
+
+	i := strings.Index(uri, "/")
+	if i == -1 {
+		i = len(uri)
+	}
+
+	res.Phrase = strings.TrimSpace(uri[:i])
+
+	path, err := ParseDerivationPath(uri[i:])
+	if err != nil {
+		return SecretURI{}, err
+	}
+
+	res.Path = path
+
+	return res, nil
+}
+
+func (u SecretURI) String() string {
+	res := u.Phrase + u.Path.String()
+	if u.Password != "" {
+		res += "///" + u.Password
+	}
+
+	return res
+}
+
+// Derive returns the URI of the child key that is derived from the key of the URI with the given junctions
+func (u SecretURI) Derive(junctions ...DeriveJunction) SecretURI {
+	path := make(DerivationPath, 0, len(u.Path)+len(junctions))
+	path = append(path, u.Path...)
+	path = append(path, junctions...)
+
+	return SecretURI{Phrase: u.Phrase, Path: path, Password: u.Password}
+}
+
+// Derive returns the keyring pair of the child key that is derived from the key pair with the given junctions. The
+// address of the child key pair uses the network of the address of the key pair, or the generic substrate network
+// if the key pair has no address.
+func (k KeyringPair) Derive(junctions ...DeriveJunction) (KeyringPair, error) {
+	uri, err := ParseSecretURI(k.URI)
+	if err != nil {
+		return KeyringPair{}, err
+	}
+
+	network := uint16(42)
+	if k.Address != "" {
+		network, _, err = subkey.SS58Decode(k.Address)
+		if err != nil {
+			return KeyringPair{}, err
+		}
+	}
+
+	return KeyringPairFromSecretWithScheme(k.Type, uri.Derive(junctions...).String(), network)
+}
@@ -0,0 +1,182 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature_test
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateMnemonic(t *testing.T) {
+	for _, words := range []int{12, 15, 18, 21, 24} {
+		phrase, err := GenerateMnemonic(words)
+		assert.NoError(t, err)
+		assert.Len(t, strings.Fields(phrase), words)
+		assert.NoError(t, ValidateMnemonic(phrase))
+
+		_, err = KeyringPairFromSecret(phrase+"//0", 42)
+		assert.NoError(t, err)
+	}
+
+	_, err := GenerateMnemonic(13)
+	assert.EqualError(t, err, "invalid number of words 13, expected 12, 15, 18, 21 or 24")
+}
+
+func TestValidateMnemonic(t *testing.T) {
+	assert.NoError(t, ValidateMnemonic("bottom drive obey lake curtain smoke basket hold race lonely fit walk"))
+	assert.EqualError(t, ValidateMnemonic("bottom drive obey lake curtain smoke basket hold race lonely fit walks"),
+		"invalid mnemonic")
+}
+
+func TestParseDerivationPath(t *testing.T) {
+	path, err := ParseDerivationPath("//polkadot//0/1/stash")
+	assert.NoError(t, err)
+	assert.Equal(t, DerivationPath{
+		NewHardJunction("polkadot"),
+		NewHardJunction("0"),
+		NewSoftJunction("1"),
+		NewSoftJunction("stash"),
+	}, path)
+	assert.Equal(t, "//polkadot//0/1/stash", path.String())
+
+	path, err = ParseDerivationPath("")
+	assert.NoError(t, err)
+	assert.Empty(t, path)
+
+	_, err = ParseDerivationPath("polkadot")
+	assert.EqualError(t, err, `invalid derivation path, expected / at "polkadot"`)
+
+	_, err = ParseDerivationPath("//polkadot//")
+	assert.EqualError(t, err, "invalid derivation path, junctions must not be empty")
+}
+
+func TestParseSecretURI(t *testing.T) {
+	for _, test := range []struct {
+		uri      string
+		expected SecretURI
+	}{
+		{"//Alice", SecretURI{Path: DerivationPath{NewHardJunction("Alice")}}},
+		{
+			"bottom drive obey lake curtain smoke basket hold race lonely fit walk//Alice/0///secret",
+			SecretURI{
+				Phrase:   "bottom drive obey lake curtain smoke basket hold race lonely fit walk",
+				Path:     DerivationPath{NewHardJunction("Alice"), NewSoftJunction("0")},
+				Password: "secret",
+			},
+		},
+		{"0xabcd///secret", SecretURI{Phrase: "0xabcd", Password: "secret"}},
+	} {
+		uri, err := ParseSecretURI(test.uri)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, uri)
+		assert.Equal(t, test.uri, uri.String())
+	}
+
+	_, err := ParseSecretURI("//Alice//")
+	assert.Error(t, err)
+}
+
+func TestSecretURI_Derive(t *testing.T) {
+	uri := SecretURI{Phrase: "phrase", Path: DerivationPath{NewHardJunction("Alice")}, Password: "secret"}
+
+	child := uri.Derive(NewHardJunction("stash"), NewSoftJunction("1"))
+	assert.Equal(t, "phrase//Alice//stash/1///secret", child.String())
+
+	// the parent is not modified
+	assert.Equal(t, "phrase//Alice///secret", uri.String())
+}
+
+func TestKeyringPair_Derive(t *testing.T) {
+	alice, err := KeyringPairFromSecret("//Alice", 42)
+	assert.NoError(t, err)
+	assert.Equal(t, TestKeyringPairAlice.Address, alice.Address)
+
+	// subkey inspect //Alice//stash
+	stash, err := alice.Derive(NewHardJunction("stash"))
+	assert.NoError(t, err)
+	assert.Equal(t, "//Alice//stash", stash.URI)
+	assert.Equal(t, "5GNJqTPyNqANBkUVMN1LPPrxXnFouWXoe2wNSmmEoLctxiZY", stash.Address)
+
+	// soft junctions of sr25519 keys, subkey inspect "crowd swamp sniff machine grid pretty client emotion banana
+	// cricket flush soap/foo" and ".../foo//bar"
+	crowd, err := KeyringPairFromSecret("crowd swamp sniff machine grid pretty client emotion banana cricket flush soap",
+		42)
+	assert.NoError(t, err)
+
+	soft, err := crowd.Derive(NewSoftJunction("foo"))
+	assert.NoError(t, err)
+	assert.Equal(t, "287061f5973551d070ccc62fb4563a0be2e6324ce183c456850e342aa021f94d", hex.EncodeToString(soft.PublicKey))
+	assert.Equal(t, "5CyjA4yQrQtJBs7jC4D6S672y3Ez4Shd3se6VXB4JBkdGwUZ", soft.Address)
+
+	mixed, err := soft.Derive(NewHardJunction("bar"))
+	assert.NoError(t, err)
+	assert.Equal(t, "5HE5Y6MDZvy9QJsmgjrnJHiSqsYRTrfBLrzLvHQC3f9PM6TR", mixed.Address)
+
+	// subkey inspect "crowd swamp sniff machine grid pretty client emotion banana cricket flush soap//foo/bar"
+	hardSoft, err := crowd.Derive(NewHardJunction("foo"), NewSoftJunction("bar"))
+	assert.NoError(t, err)
+	assert.Equal(t, "0c6febc87c461f8ddceb295d90c3ba999b1e93c2bdd13145b265512d06729449",
+		hex.EncodeToString(hardSoft.PublicKey))
+	assert.Equal(t, "5CM1gMJkyRoE7txkdHv31y6H4yPMKCALSDpaeaE8BpDVwrht", hardSoft.Address)
+
+	// subkey inspect --scheme ed25519 //Alice
+	aliceEd25519, err := KeyringPairFromSecretWithScheme(SchemeEd25519, "", 42)
+	assert.NoError(t, err)
+
+	aliceEd25519, err = aliceEd25519.Derive(NewHardJunction("Alice"))
+	assert.NoError(t, err)
+	assert.Equal(t, SchemeEd25519, aliceEd25519.Type)
+	assert.Equal(t, "5FA9nQDVg267DEd8m1ZypXLBnvN7SFxYwV7ndqSYGiN9TTpu", aliceEd25519.Address)
+
+	_, err = aliceEd25519.Derive(NewSoftJunction("1"))
+	assert.EqualError(t, err, "soft derivation is not supported")
+
+	// the network of the address is kept
+	polkadot, err := KeyringPairFromSecret("//Bob", 0)
+	assert.NoError(t, err)
+
+	polkadotStash, err := polkadot.Derive(NewHardJunction("stash"))
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(polkadotStash.Address, "1"))
+}
+
+func TestKeyringPairFromSecret_Password(t *testing.T) {
+	phrase := "crowd swamp sniff machine grid pretty client emotion banana cricket flush soap"
+
+	// subkey inspect "crowd swamp sniff machine grid pretty client emotion banana cricket flush soap///password"
+	withPassword, err := KeyringPairFromSecret(SecretURI{Phrase: phrase, Password: "password"}.String(), 42)
+	assert.NoError(t, err)
+	assert.Equal(t, "5c2d57c4cfa7df7a9d0e9546bb575045f5ec14e9771de8bc907910c84cd5de2a",
+		hex.EncodeToString(withPassword.PublicKey))
+	assert.Equal(t, "5E9ZjRM9VdqES5JhbABVpvgCstaE7J5x3cE7sTKMGG5TF8tZ", withPassword.Address)
+
+	// subkey inspect "crowd swamp sniff machine grid pretty client emotion banana cricket flush soap//foo/bar//42/69
+	// ///password"
+	uri := SecretURI{Phrase: phrase, Password: "password"}.Derive(NewHardJunction("foo"), NewSoftJunction("bar"),
+		NewHardJunction("42"), NewSoftJunction("69"))
+	assert.Equal(t, phrase+"//foo/bar//42/69///password", uri.String())
+
+	derived, err := KeyringPairFromSecret(uri.String(), 42)
+	assert.NoError(t, err)
+	assert.Equal(t, "4055514cd4ddcc7b23024839b68190f3f71bc262eb038145262bfe087bbb5429",
+		hex.EncodeToString(derived.PublicKey))
+	assert.Equal(t, "5DX4GQQm9rSHVcqaG9CgxdZLsj8buBxcRWEYYcHrRXe4epZg", derived.Address)
+}
@@ -0,0 +1,212 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/cosmos/go-bip39"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// DefaultEthereumDerivationPath is the BIP44 derivation path of the first account of Ethereum wallets like MetaMask
+const DefaultEthereumDerivationPath = "m/44'/60'/0'/0/0"
+
+const bip32HardenedOffset = 0x80000000
+
+// EthereumSigner is a Signer for Ethereum compatible chains that are based on Frontier, like Moonbeam. Their accounts
+// are 20 byte Ethereum addresses and their signatures are 65 byte recoverable secp256k1 signatures over the keccak-256
+// hash of the payload.
+type EthereumSigner struct {
+	key *ecdsa.PrivateKey
+}
+
+// NewEthereumSigner returns a signer for the 32 byte secp256k1 private key
+func NewEthereumSigner(privateKey []byte) (*EthereumSigner, error) {
+	key, err := crypto.ToECDSA(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EthereumSigner{key: key}, nil
+}
+
+// NewEthereumSignerFromMnemonic returns a signer for the key that is derived from the BIP39 mnemonic and password with
+// the BIP44 derivation path, e.g. DefaultEthereumDerivationPath
+func NewEthereumSignerFromMnemonic(phrase, password, path string) (*EthereumSigner, error) {
+	if err := ValidateMnemonic(phrase); err != nil {
+		return nil, err
+	}
+
+	indexes, err := parseBIP32Path(path)
+	if err != nil {
+		return nil, err
+	}
+
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(bip39.NewSeed(phrase, password))
+	i := mac.Sum(nil)
+
+	key, chainCode := i[:32], i[32:]
+
+	for _, index := range indexes {
+		key, chainCode, err = deriveBIP32Child(key, chainCode, index)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return NewEthereumSigner(key)
+}
+
+// Public returns the 33 byte compressed public key of the signer
+func (s *EthereumSigner) Public() []byte {
+	return crypto.CompressPubkey(&s.key.PublicKey)
+}
+
+// Scheme returns SchemeEthereum
+func (s *EthereumSigner) Scheme() Scheme {
+	return SchemeEthereum
+}
+
+// Sign signs the keccak-256 hash of the payload, payloads longer than 256 bytes are hashed with blake2b-256 first, see
+// PreparePayload. The signature is 65 bytes long, the last byte is the recovery ID.
+func (s *EthereumSigner) Sign(payload []byte) ([]byte, error) {
+	return crypto.Sign(crypto.Keccak256(PreparePayload(payload)), s.key)
+}
+
+// SignUnhashed signs the keccak-256 hash of the data without hashing long data with blake2b-256 first, see RawSigner
+func (s *EthereumSigner) SignUnhashed(data []byte) ([]byte, error) {
+	return crypto.Sign(crypto.Keccak256(data), s.key)
+}
+
+// Address returns the 20 byte Ethereum address of the signer, which is its account ID
+func (s *EthereumSigner) Address() []byte {
+	return crypto.PubkeyToAddress(s.key.PublicKey).Bytes()
+}
+
+// ethereumAddress returns the Ethereum address of a compressed or uncompressed secp256k1 public key
+func ethereumAddress(publicKey []byte) []byte {
+	var (
+		pub *ecdsa.PublicKey
+		err error
+	)
+
+	if len(publicKey) == 33 {
+		pub, err = crypto.DecompressPubkey(publicKey)
+	} else {
+		pub, err = crypto.UnmarshalPubkey(publicKey)
+	}
+
+	if err != nil {
+		return nil
+	}
+
+	return crypto.PubkeyToAddress(*pub).Bytes()
+}
+
+// verifyEthereum verifies the signature of the keccak-256 hash of data by recovering the signer from it
+func verifyEthereum(data, sig, publicKey []byte) (bool, error) {
+	address := ethereumAddress(publicKey)
+	if address == nil {
+		return false, errors.New("invalid ethereum public key")
+	}
+
+	recovered, err := crypto.SigToPub(crypto.Keccak256(data), sig)
+	if err != nil {
+		// a signature that the signer can not be recovered from is invalid
+		return false, nil
+	}
+
+	return bytes.Equal(address, crypto.PubkeyToAddress(*recovered).Bytes()), nil
+}
+
+// parseBIP32Path parses a derivation path like m/44'/60'/0'/0/0 into the indexes of the children
+func parseBIP32Path(path string) ([]uint32, error) {
+	parts := strings.Split(path, "/")
+	if len(parts) == 0 || parts[0] != "m" {
+		return nil, fmt.Errorf("invalid derivation path %v, expected m/...", path)
+	}
+
+	indexes := make([]uint32, 0, len(parts)-1)
+
+	for _, part := range parts[1:] {
+		hardened := strings.HasSuffix(part, "'")
+
+		index, err := strconv.ParseUint(strings.TrimSuffix(part, "'"), 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("invalid index %v of derivation path %v", part, path)
+		}
+
+		if hardened {
+			index += bip32HardenedOffset
+		}
+
+		indexes = append(indexes, uint32(index))
+	}
+
+	return indexes, nil
+}
+
+// deriveBIP32Child derives the private key and the chain code of the child with the given index
+func deriveBIP32Child(key, chainCode []byte, index uint32) ([]byte, []byte, error) {
+	var data []byte
+
+	if index >= bip32HardenedOffset {
+		data = append([]byte{0}, key...)
+	} else {
+		k, err := crypto.ToECDSA(key)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		data = crypto.CompressPubkey(&k.PublicKey)
+	}
+
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], index)
+	data = append(data, buf[:]...)
+
+	mac := hmac.New(sha512.New, chainCode)
+	mac.Write(data)
+	i := mac.Sum(nil)
+
+	n := crypto.S256().Params().N
+
+	il := new(big.Int).SetBytes(i[:32])
+	if il.Cmp(n) >= 0 {
+		return nil, nil, errors.New("invalid derived key, use the next index")
+	}
+
+	child := il.Add(il, new(big.Int).SetBytes(key))
+	child.Mod(child, n)
+
+	if child.Sign() == 0 {
+		return nil, nil, errors.New("invalid derived key, use the next index")
+	}
+
+	return child.FillBytes(make([]byte, 32)), i[32:], nil
+}
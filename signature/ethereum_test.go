@@ -0,0 +1,89 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature_test
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/blake2b"
+)
+
+const devPhrase = "bottom drive obey lake curtain smoke basket hold race lonely fit walk"
+
+func TestNewEthereumSignerFromMnemonic(t *testing.T) {
+	for _, test := range []struct {
+		path    string
+		address string
+	}{
+		// the development accounts of Moonbeam, Alith and Baltathar
+		{DefaultEthereumDerivationPath, "0xf24ff3a9cf04c71dbc94d0b566f7a27b94566cac"},
+		{"m/44'/60'/0'/0/1", "0x3cd0a705a2dc65e5b1e1205896baa2be8a07c6e0"},
+	} {
+		t.Run(test.path, func(t *testing.T) {
+			s, err := NewEthereumSignerFromMnemonic(devPhrase, "", test.path)
+			assert.NoError(t, err)
+			assert.Equal(t, test.address, hexutil.Encode(s.Address()))
+			assert.Equal(t, s.Address(), SchemeEthereum.AccountID(s.Public()))
+			assert.Equal(t, SchemeEthereum, s.Scheme())
+			assert.Len(t, s.Public(), 33)
+		})
+	}
+}
+
+func TestNewEthereumSignerFromMnemonic_Errors(t *testing.T) {
+	for _, path := range []string{"", "44'/60'/0'/0/0", "m/44'/60'/x", "m/4294967296"} {
+		_, err := NewEthereumSignerFromMnemonic(devPhrase, "", path)
+		assert.Error(t, err, path)
+	}
+
+	_, err := NewEthereumSignerFromMnemonic(devPhrase+"s", "", DefaultEthereumDerivationPath)
+	assert.Error(t, err)
+}
+
+func TestEthereumSigner_Sign(t *testing.T) {
+	s, err := NewEthereumSigner(hexutil.MustDecode(
+		"0x5fb92d6e98884f76de468fa3f6278f8807c48bebc13595d45af5bdc4da702133"))
+	assert.NoError(t, err)
+	assert.Equal(t, "0xf24ff3a9cf04c71dbc94d0b566f7a27b94566cac", hexutil.Encode(s.Address()))
+
+	short := []byte("short payload")
+	long := bytes.Repeat([]byte{1}, 257)
+	longHash := blake2b.Sum256(long)
+
+	for payload, signed := range map[string][]byte{
+		string(short): short,
+		string(long):  longHash[:],
+	} {
+		sig, err := s.Sign([]byte(payload))
+		assert.NoError(t, err)
+		assert.Len(t, sig, SchemeEthereum.SignatureLength())
+
+		pub, err := crypto.SigToPub(crypto.Keccak256(signed), sig)
+		assert.NoError(t, err)
+		assert.Equal(t, s.Address(), crypto.PubkeyToAddress(*pub).Bytes())
+	}
+}
+
+func TestNewEthereumSigner_Invalid(t *testing.T) {
+	_, err := NewEthereumSigner([]byte{1, 2, 3})
+	assert.Error(t, err)
+}
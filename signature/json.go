@@ -0,0 +1,403 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/vedhavyas/go-subkey/v2"
+	"github.com/vedhavyas/go-subkey/v2/ed25519"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+)
+
+// EncryptedJSONVersion is the version of the encrypted JSON format of polkadot-js that is created by ExportJSON
+const EncryptedJSONVersion = "3"
+
+const (
+	jsonContentPkcs8       = "pkcs8"
+	jsonEncryptionScrypt   = "scrypt"
+	jsonEncryptionXSalsa20 = "xsalsa20-poly1305"
+	jsonEncryptionNone     = "none"
+
+	jsonSaltLength  = 32
+	jsonNonceLength = 24
+	jsonKeyLength   = 32
+
+	// the default scrypt parameters of polkadot-js, larger parameters are rejected when decrypting
+	jsonScryptN = 1 << 15
+	jsonScryptP = 1
+	jsonScryptR = 8
+)
+
+var (
+	pkcs8Header  = []byte{48, 83, 2, 1, 1, 48, 5, 6, 3, 43, 101, 112, 4, 34, 4, 32}
+	pkcs8Divider = []byte{161, 35, 3, 33, 0}
+)
+
+// EncryptedJSON is an account that is exported as encrypted JSON by polkadot-js and compatible wallets like Talisman.
+// The secret key is encoded as PKCS8, encrypted with xsalsa20-poly1305 and a key that is derived from the password
+// with scrypt.
+type EncryptedJSON struct {
+	// Encoded is the base64 encoded scrypt parameters, nonce and encrypted PKCS8 data
+	Encoded  string                `json:"encoded"`
+	Encoding EncryptedJSONEncoding `json:"encoding"`
+	Address  string                `json:"address"`
+	// Meta holds the metadata of the account, e.g. its name
+	Meta map[string]interface{} `json:"meta"`
+}
+
+// EncryptedJSONEncoding describes the content and the encryption of an EncryptedJSON
+type EncryptedJSONEncoding struct {
+	// Content is pkcs8 followed by the crypto scheme of the key
+	Content []string `json:"content"`
+	// Type is the list of the used encryption algorithms, none for unencrypted data
+	Type    []string `json:"type"`
+	Version string   `json:"version"`
+}
+
+// Scheme returns the crypto scheme of the key of the account
+func (e EncryptedJSON) Scheme() (Scheme, error) {
+	if len(e.Encoding.Content) != 2 || e.Encoding.Content[0] != jsonContentPkcs8 {
+		return 0, fmt.Errorf("unsupported content %v", e.Encoding.Content)
+	}
+
+	for _, s := range []Scheme{SchemeSr25519, SchemeEd25519, SchemeEcdsa} {
+		if s.String() == e.Encoding.Content[1] {
+			return s, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unsupported key type %v", e.Encoding.Content[1])
+}
+
+// ParseEncryptedJSON parses an account that is exported as encrypted JSON
+func ParseEncryptedJSON(data []byte) (EncryptedJSON, error) {
+	var e EncryptedJSON
+	if err := json.Unmarshal(data, &e); err != nil {
+		return EncryptedJSON{}, err
+	}
+
+	if _, err := e.Scheme(); err != nil {
+		return EncryptedJSON{}, err
+	}
+
+	if _, _, err := subkey.SS58Decode(e.Address); err != nil {
+		return EncryptedJSON{}, fmt.Errorf("invalid address %v: %w", e.Address, err)
+	}
+
+	return e, nil
+}
+
+// KeyPairSignerFromJSON decrypts an account that is exported as encrypted JSON by polkadot-js with the password and
+// returns a signer for it
+func KeyPairSignerFromJSON(data []byte, password string) (*KeyPairSigner, error) {
+	e, err := ParseEncryptedJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.Decrypt(password)
+}
+
+// Decrypt decrypts the secret key of the account with the password and returns a signer for it. The public key and
+// the address of the account have to match the secret key.
+func (e EncryptedJSON) Decrypt(password string) (*KeyPairSigner, error) {
+	scheme, err := e.Scheme()
+	if err != nil {
+		return nil, err
+	}
+
+	encoded, err := base64.StdEncoding.DecodeString(e.Encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	decrypted, err := decryptJSONData(encoded, password, e.Encoding.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, public, err := decodePkcs8(decrypted)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := scheme.subkeyScheme()
+	if err != nil {
+		return nil, err
+	}
+
+	seed, err := seedFromJSONSecret(scheme, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	kp, err := s.FromSeed(seed)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(kp.Public(), public) {
+		return nil, errors.New("the public key does not match the secret key")
+	}
+
+	_, accountID, err := subkey.SS58Decode(e.Address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address %v: %w", e.Address, err)
+	}
+
+	if !bytes.Equal(kp.AccountID(), accountID) {
+		return nil, fmt.Errorf("the address %v does not match the secret key", e.Address)
+	}
+
+	return &KeyPairSigner{kp: kp, scheme: scheme}, nil
+}
+
+// ExportJSON exports the key pair of the signer as encrypted JSON that can be imported into polkadot-js. The address
+// is encoded for the given network. An empty password exports the key pair unencrypted.
+func (s *KeyPairSigner) ExportJSON(password string, network uint16, meta map[string]interface{}) ([]byte, error) {
+	secret, err := jsonSecretFromSeed(s.scheme, s.kp.Seed())
+	if err != nil {
+		return nil, err
+	}
+
+	plain := make([]byte, 0, len(pkcs8Header)+len(secret)+len(pkcs8Divider)+len(s.Public()))
+	plain = append(plain, pkcs8Header...)
+	plain = append(plain, secret...)
+	plain = append(plain, pkcs8Divider...)
+	plain = append(plain, s.Public()...)
+
+	encoded, encryption, err := encryptJSONData(plain, password)
+	if err != nil {
+		return nil, err
+	}
+
+	if meta == nil {
+		meta = map[string]interface{}{}
+	}
+
+	return json.Marshal(EncryptedJSON{
+		Encoded: base64.StdEncoding.EncodeToString(encoded),
+		Encoding: EncryptedJSONEncoding{
+			Content: []string{jsonContentPkcs8, s.scheme.String()},
+			Type:    encryption,
+			Version: EncryptedJSONVersion,
+		},
+		Address: s.SS58Address(network),
+		Meta:    meta,
+	})
+}
+
+func encryptJSONData(plain []byte, password string) (encoded []byte, encryption []string, err error) {
+	if password == "" {
+		return plain, []string{jsonEncryptionNone}, nil
+	}
+
+	salt := make([]byte, jsonSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, nil, err
+	}
+
+	key, err := scrypt.Key([]byte(password), salt, jsonScryptN, jsonScryptR, jsonScryptP, 64)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var nonce [jsonNonceLength]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return nil, nil, err
+	}
+
+	var secretKey [jsonKeyLength]byte
+	copy(secretKey[:], key)
+
+	params := make([]byte, 12)
+	binary.LittleEndian.PutUint32(params, jsonScryptN)
+	binary.LittleEndian.PutUint32(params[4:], jsonScryptP)
+	binary.LittleEndian.PutUint32(params[8:], jsonScryptR)
+
+	encoded = append(encoded, salt...)
+	encoded = append(encoded, params...)
+	encoded = append(encoded, nonce[:]...)
+	encoded = secretbox.Seal(encoded, plain, &nonce, &secretKey)
+
+	return encoded, []string{jsonEncryptionScrypt, jsonEncryptionXSalsa20}, nil
+}
+
+func decryptJSONData(encoded []byte, password string, encryption []string) ([]byte, error) {
+	if !containsString(encryption, jsonEncryptionXSalsa20) {
+		return encoded, nil
+	}
+
+	if password == "" {
+		return nil, errors.New("password required to decrypt the encrypted JSON")
+	}
+
+	// accounts exported by old versions of polkadot-js use the zero padded password as key
+	key := []byte(password)
+
+	if containsString(encryption, jsonEncryptionScrypt) {
+		if len(encoded) < jsonSaltLength+12 {
+			return nil, errors.New("invalid encrypted JSON, expected scrypt parameters")
+		}
+
+		salt := encoded[:jsonSaltLength]
+		n := binary.LittleEndian.Uint32(encoded[jsonSaltLength:])
+		p := binary.LittleEndian.Uint32(encoded[jsonSaltLength+4:])
+		r := binary.LittleEndian.Uint32(encoded[jsonSaltLength+8:])
+
+		if n > jsonScryptN || p > jsonScryptP || r > jsonScryptR {
+			return nil, fmt.Errorf("unsupported scrypt parameters N=%d p=%d r=%d", n, p, r)
+		}
+
+		var err error
+
+		key, err = scrypt.Key([]byte(password), salt, int(n), int(r), int(p), 64)
+		if err != nil {
+			return nil, err
+		}
+
+		encoded = encoded[jsonSaltLength+12:]
+	}
+
+	if len(encoded) < jsonNonceLength {
+		return nil, errors.New("invalid encrypted JSON, expected nonce")
+	}
+
+	var nonce [jsonNonceLength]byte
+	copy(nonce[:], encoded)
+
+	var secretKey [jsonKeyLength]byte
+	copy(secretKey[:], key)
+
+	decrypted, ok := secretbox.Open(nil, encoded[jsonNonceLength:], &nonce, &secretKey)
+	if !ok {
+		return nil, errors.New("unable to decrypt the encrypted JSON, invalid password")
+	}
+
+	return decrypted, nil
+}
+
+// decodePkcs8 returns the secret and the public key of the PKCS8 data, the secret key is 64 or 32 bytes long
+func decodePkcs8(data []byte) (secret []byte, public []byte, err error) {
+	if !bytes.HasPrefix(data, pkcs8Header) {
+		return nil, nil, errors.New("invalid PKCS8 header")
+	}
+
+	data = data[len(pkcs8Header):]
+
+	for _, l := range []int{64, 32} {
+		if len(data) > l && bytes.HasPrefix(data[l:], pkcs8Divider) {
+			return data[:l], data[l+len(pkcs8Divider):], nil
+		}
+	}
+
+	return nil, nil, errors.New("invalid PKCS8 divider")
+}
+
+// seedFromJSONSecret converts a secret key of polkadot-js into a seed of subkey. sr25519 secret keys are stored in
+// their ed25519 compatible form, which is the scalar multiplied by the cofactor followed by the nonce. ed25519 secret
+// keys are the seed followed by the public key.
+func seedFromJSONSecret(scheme Scheme, secret []byte) ([]byte, error) {
+	switch {
+	case scheme == SchemeSr25519 && len(secret) == 64:
+		seed := append([]byte{}, secret...)
+		divideScalarByCofactor(seed[:32])
+
+		return seed, nil
+	case scheme == SchemeEd25519 && len(secret) == 64:
+		return secret[:32], nil
+	case scheme == SchemeEcdsa && len(secret) == 32:
+		return secret, nil
+	default:
+		return nil, fmt.Errorf("invalid %v secret key length %d", scheme, len(secret))
+	}
+}
+
+// jsonSecretFromSeed is the inverse of seedFromJSONSecret
+func jsonSecretFromSeed(scheme Scheme, seed []byte) ([]byte, error) {
+	if seed == nil {
+		return nil, errors.New("the key pair has no secret that can be exported, e.g. because of a soft derivation")
+	}
+
+	switch {
+	case scheme == SchemeSr25519 && len(seed) == 32:
+		// the mini secret key is expanded like ed25519 seeds, the clamped scalar is the ed25519 compatible form
+		secret := sha512.Sum512(seed)
+		secret[0] &= 248
+		secret[31] &= 63
+		secret[31] |= 64
+
+		return secret[:], nil
+	case scheme == SchemeSr25519 && len(seed) == 64:
+		secret := append([]byte{}, seed...)
+		multiplyScalarByCofactor(secret[:32])
+
+		return secret, nil
+	case scheme == SchemeEd25519 && len(seed) == 32:
+		kp, err := ed25519.Scheme{}.FromSeed(seed)
+		if err != nil {
+			return nil, err
+		}
+
+		return append(append([]byte{}, seed...), kp.Public()...), nil
+	case scheme == SchemeEcdsa && len(seed) == 32:
+		return seed, nil
+	default:
+		return nil, fmt.Errorf("invalid %v seed length %d", scheme, len(seed))
+	}
+}
+
+// divideScalarByCofactor divides the little endian scalar by 8
+func divideScalarByCofactor(scalar []byte) {
+	var low byte
+	for i := len(scalar) - 1; i >= 0; i-- {
+		r := scalar[i] & 0x07
+		scalar[i] >>= 3
+		scalar[i] += low
+		low = r << 5
+	}
+}
+
+// multiplyScalarByCofactor multiplies the little endian scalar by 8
+func multiplyScalarByCofactor(scalar []byte) {
+	var high byte
+	for i := range scalar {
+		r := scalar[i] & 0xe0
+		scalar[i] <<= 3
+		scalar[i] += high
+		high = r >> 5
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
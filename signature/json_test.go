@@ -0,0 +1,150 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature_test
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyPairSigner_ExportJSON(t *testing.T) {
+	alice, err := NewKeyPairSigner(SchemeSr25519, "//Alice")
+	assert.NoError(t, err)
+
+	data, err := alice.ExportJSON("", 42, map[string]interface{}{"name": "Alice"})
+	assert.NoError(t, err)
+
+	var e EncryptedJSON
+	assert.NoError(t, json.Unmarshal(data, &e))
+	assert.Equal(t, []string{"pkcs8", "sr25519"}, e.Encoding.Content)
+	assert.Equal(t, []string{"none"}, e.Encoding.Type)
+	assert.Equal(t, "3", e.Encoding.Version)
+	assert.Equal(t, TestKeyringPairAlice.Address, e.Address)
+	assert.Equal(t, map[string]interface{}{"name": "Alice"}, e.Meta)
+
+	// the secret key of //Alice as created by polkadot-js, followed by the public key
+	encoded, err := base64.StdEncoding.DecodeString(e.Encoded)
+	assert.NoError(t, err)
+	assert.Equal(t, "3053020101300506032b657004220420"+
+		"98319d4ff8a9508c4bb0cf0b5a78d760a0b2082c02775e6e82370816fedfff48"+
+		"925a225d97aa00682d6a59b95b18780c10d7032336e88f3442b42361f4a66011"+
+		"a123032100"+
+		"d43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d", hex.EncodeToString(encoded))
+
+	imported, err := KeyPairSignerFromJSON(data, "")
+	assert.NoError(t, err)
+	assert.Equal(t, alice.Public(), imported.Public())
+}
+
+func TestKeyPairSignerFromJSON(t *testing.T) {
+	for _, scheme := range []Scheme{SchemeSr25519, SchemeEd25519, SchemeEcdsa} {
+		signer, err := NewKeyPairSigner(scheme, testSecretPhrase+"//0")
+		assert.NoError(t, err)
+
+		data, err := signer.ExportJSON("secret", 0, nil)
+		assert.NoError(t, err)
+
+		e, err := ParseEncryptedJSON(data)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"pkcs8", scheme.String()}, e.Encoding.Content)
+		assert.Equal(t, []string{"scrypt", "xsalsa20-poly1305"}, e.Encoding.Type)
+		assert.Equal(t, signer.SS58Address(0), e.Address)
+		assert.Equal(t, map[string]interface{}{}, e.Meta)
+
+		_, err = KeyPairSignerFromJSON(data, "wrong")
+		assert.EqualError(t, err, "unable to decrypt the encrypted JSON, invalid password")
+
+		_, err = KeyPairSignerFromJSON(data, "")
+		assert.EqualError(t, err, "password required to decrypt the encrypted JSON")
+
+		imported, err := KeyPairSignerFromJSON(data, "secret")
+		assert.NoError(t, err)
+		assert.Equal(t, signer.Public(), imported.Public())
+		assert.Equal(t, scheme, imported.Scheme())
+
+		msg := []byte("message")
+		sig, err := imported.Sign(msg)
+		assert.NoError(t, err)
+
+		ok, err := VerifyWithScheme(scheme, msg, sig, testSecretPhrase+"//0")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+	}
+}
+
+func TestEncryptedJSON_Decrypt_Mismatch(t *testing.T) {
+	alice, err := NewKeyPairSigner(SchemeSr25519, "//Alice")
+	assert.NoError(t, err)
+
+	data, err := alice.ExportJSON("", 42, nil)
+	assert.NoError(t, err)
+
+	e, err := ParseEncryptedJSON(data)
+	assert.NoError(t, err)
+
+	encoded, err := base64.StdEncoding.DecodeString(e.Encoded)
+	assert.NoError(t, err)
+
+	// the public key of Bob after the secret key of Alice
+	tampered := e
+	tampered.Encoded = base64.StdEncoding.EncodeToString(
+		append(encoded[:len(encoded)-32:len(encoded)-32], TestKeyringPairBob.PublicKey...))
+	_, err = tampered.Decrypt("")
+	assert.EqualError(t, err, "the public key does not match the secret key")
+
+	// a truncated public key
+	tampered.Encoded = base64.StdEncoding.EncodeToString(encoded[:len(encoded)-1])
+	_, err = tampered.Decrypt("")
+	assert.EqualError(t, err, "the public key does not match the secret key")
+
+	// the address of Bob with the key of Alice
+	tampered = e
+	tampered.Address = TestKeyringPairBob.Address
+	_, err = tampered.Decrypt("")
+	assert.EqualError(t, err, "the address "+TestKeyringPairBob.Address+" does not match the secret key")
+
+	signer, err := e.Decrypt("")
+	assert.NoError(t, err)
+	assert.Equal(t, alice.Public(), signer.Public())
+}
+
+func TestKeyPairSigner_ExportJSON_SoftDerivation(t *testing.T) {
+	signer, err := NewKeyPairSigner(SchemeSr25519, "//Alice/0")
+	assert.NoError(t, err)
+
+	_, err = signer.ExportJSON("secret", 42, nil)
+	assert.EqualError(t, err, "the key pair has no secret that can be exported, e.g. because of a soft derivation")
+}
+
+func TestParseEncryptedJSON_Errors(t *testing.T) {
+	_, err := ParseEncryptedJSON([]byte(`{"encoding":{"content":["pkcs8","ethereum"]}}`))
+	assert.EqualError(t, err, "unsupported key type ethereum")
+
+	_, err = ParseEncryptedJSON([]byte(`{"encoding":{"content":["raw","sr25519"]}}`))
+	assert.EqualError(t, err, "unsupported content [raw sr25519]")
+
+	_, err = ParseEncryptedJSON([]byte(`{"encoding":{"content":["pkcs8","sr25519"]},"address":"invalid"}`))
+	assert.Error(t, err)
+
+	_, err = ParseEncryptedJSON([]byte(`{`))
+	assert.Error(t, err)
+}
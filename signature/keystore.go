@@ -0,0 +1,153 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/vedhavyas/go-subkey/v2"
+)
+
+const keystoreFileExtension = ".json"
+
+// Keystore stores accounts as encrypted JSON files in a directory, one file per account named after the address of
+// the account. The files can be imported into polkadot-js and compatible wallets.
+type Keystore struct {
+	dir string
+}
+
+// NewKeystore creates a keystore that stores the accounts in the given directory, the directory is created if it
+// does not exist
+func NewKeystore(dir string) (*Keystore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	return &Keystore{dir: dir}, nil
+}
+
+// Accounts returns the accounts of the keystore, sorted by address
+func (k *Keystore) Accounts() ([]EncryptedJSON, error) {
+	entries, err := os.ReadDir(k.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var accounts []EncryptedJSON
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), keystoreFileExtension) {
+			continue
+		}
+
+		account, err := k.Account(strings.TrimSuffix(entry.Name(), keystoreFileExtension))
+		if err != nil {
+			return nil, fmt.Errorf("unable to read account file %v: %w", entry.Name(), err)
+		}
+
+		accounts = append(accounts, account)
+	}
+
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].Address < accounts[j].Address
+	})
+
+	return accounts, nil
+}
+
+// Account returns the account with the given address
+func (k *Keystore) Account(address string) (EncryptedJSON, error) {
+	path, err := k.path(address)
+	if err != nil {
+		return EncryptedJSON{}, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return EncryptedJSON{}, err
+	}
+
+	return ParseEncryptedJSON(data)
+}
+
+// Add exports the key pair of the signer as encrypted JSON into the keystore, see KeyPairSigner.ExportJSON. The
+// address of the added account is returned.
+func (k *Keystore) Add(signer *KeyPairSigner, password string, network uint16, meta map[string]interface{}) (string,
+	error) {
+	data, err := signer.ExportJSON(password, network, meta)
+	if err != nil {
+		return "", err
+	}
+
+	return k.Import(data)
+}
+
+// Import adds an account that is exported as encrypted JSON to the keystore, an account with the same address is
+// replaced. The address of the imported account is returned.
+func (k *Keystore) Import(data []byte) (string, error) {
+	account, err := ParseEncryptedJSON(data)
+	if err != nil {
+		return "", err
+	}
+
+	path, err := k.path(account.Address)
+	if err != nil {
+		return "", err
+	}
+
+	return account.Address, os.WriteFile(path, data, 0600)
+}
+
+// Signer decrypts the account with the given address and returns a signer for it
+func (k *Keystore) Signer(address string, password string) (*KeyPairSigner, error) {
+	account, err := k.Account(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return account.Decrypt(password)
+}
+
+// Remove removes the account with the given address from the keystore
+func (k *Keystore) Remove(address string) error {
+	path, err := k.path(address)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("account %v not found in keystore", address)
+	}
+
+	return err
+}
+
+// path returns the path of the file of the account with the given address, addresses have to be valid SS58
+// addresses so that they can't escape the directory of the keystore
+func (k *Keystore) path(address string) (string, error) {
+	if _, _, err := subkey.SS58Decode(address); err != nil {
+		return "", fmt.Errorf("invalid address %v: %w", address, err)
+	}
+
+	return filepath.Join(k.dir, address+keystoreFileExtension), nil
+}
@@ -0,0 +1,97 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeystore(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "keystore")
+
+	ks, err := NewKeystore(dir)
+	assert.NoError(t, err)
+
+	accounts, err := ks.Accounts()
+	assert.NoError(t, err)
+	assert.Empty(t, accounts)
+
+	alice, err := NewKeyPairSigner(SchemeSr25519, "//Alice")
+	assert.NoError(t, err)
+
+	address, err := ks.Add(alice, "secret", 42, map[string]interface{}{"name": "Alice"})
+	assert.NoError(t, err)
+	assert.Equal(t, TestKeyringPairAlice.Address, address)
+
+	bob, err := NewKeyPairSigner(SchemeEd25519, "//Bob")
+	assert.NoError(t, err)
+
+	data, err := bob.ExportJSON("other", 42, map[string]interface{}{"name": "Bob"})
+	assert.NoError(t, err)
+
+	bobAddress, err := ks.Import(data)
+	assert.NoError(t, err)
+	assert.Equal(t, bob.SS58Address(42), bobAddress)
+
+	// other files are ignored
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "README"), []byte("readme"), 0600))
+
+	accounts, err = ks.Accounts()
+	assert.NoError(t, err)
+	assert.Len(t, accounts, 2)
+
+	names := map[string]interface{}{}
+	for _, account := range accounts {
+		names[account.Address] = account.Meta["name"]
+	}
+
+	assert.Equal(t, map[string]interface{}{address: "Alice", bobAddress: "Bob"}, names)
+
+	signer, err := ks.Signer(address, "secret")
+	assert.NoError(t, err)
+	assert.Equal(t, alice.Public(), signer.Public())
+
+	_, err = ks.Signer(bobAddress, "secret")
+	assert.Error(t, err)
+
+	signer, err = ks.Signer(bobAddress, "other")
+	assert.NoError(t, err)
+	assert.Equal(t, SchemeEd25519, signer.Scheme())
+
+	assert.NoError(t, ks.Remove(address))
+	assert.EqualError(t, ks.Remove(address), "account "+address+" not found in keystore")
+
+	accounts, err = ks.Accounts()
+	assert.NoError(t, err)
+	assert.Len(t, accounts, 1)
+	assert.Equal(t, bobAddress, accounts[0].Address)
+}
+
+func TestKeystore_InvalidAddress(t *testing.T) {
+	ks, err := NewKeystore(t.TempDir())
+	assert.NoError(t, err)
+
+	_, err = ks.Signer("../../etc/passwd", "secret")
+	assert.Error(t, err)
+
+	assert.Error(t, ks.Remove("../keystore"))
+}
@@ -0,0 +1,58 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cosmos/go-bip39"
+)
+
+// mnemonicEntropyBits maps the supported number of words of a mnemonic to the bits of entropy it encodes
+var mnemonicEntropyBits = map[int]int{
+	12: 128,
+	15: 160,
+	18: 192,
+	21: 224,
+	24: 256,
+}
+
+// GenerateMnemonic generates a new random BIP39 mnemonic with the given number of words, which has to be 12, 15, 18,
+// 21 or 24. The mnemonic can be used as the phrase of a SecretURI.
+func GenerateMnemonic(words int) (string, error) {
+	bits, ok := mnemonicEntropyBits[words]
+	if !ok {
+		return "", fmt.Errorf("invalid number of words %d, expected 12, 15, 18, 21 or 24", words)
+	}
+
+	entropy, err := bip39.NewEntropy(bits)
+	if err != nil {
+		return "", err
+	}
+
+	return bip39.NewMnemonic(entropy)
+}
+
+// ValidateMnemonic returns an error if the phrase is not a valid BIP39 mnemonic of the english word list
+func ValidateMnemonic(phrase string) error {
+	if !bip39.IsMnemonicValid(phrase) {
+		return errors.New("invalid mnemonic")
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature
+
+import (
+	"bytes"
+	"fmt"
+)
+
+var (
+	bytesPrefix = []byte("<Bytes>")
+	bytesSuffix = []byte("</Bytes>")
+)
+
+// WrapBytes wraps a message in <Bytes>...</Bytes> like browser wallets such as the polkadot-js extension do before
+// signing raw messages, which makes sure that a signed message can never be a valid transaction payload. Messages that
+// are already wrapped are returned as is.
+func WrapBytes(message []byte) []byte {
+	if IsWrappedBytes(message) {
+		return message
+	}
+
+	wrapped := make([]byte, 0, len(bytesPrefix)+len(message)+len(bytesSuffix))
+	wrapped = append(wrapped, bytesPrefix...)
+	wrapped = append(wrapped, message...)
+
+	return append(wrapped, bytesSuffix...)
+}
+
+// UnwrapBytes removes the <Bytes>...</Bytes> wrapping from a message, messages that are not wrapped are returned as is
+func UnwrapBytes(message []byte) []byte {
+	if !IsWrappedBytes(message) {
+		return message
+	}
+
+	return message[len(bytesPrefix) : len(message)-len(bytesSuffix)]
+}
+
+// IsWrappedBytes returns true if the message is wrapped in <Bytes>...</Bytes>
+func IsWrappedBytes(message []byte) bool {
+	return len(message) >= len(bytesPrefix)+len(bytesSuffix) &&
+		bytes.HasPrefix(message, bytesPrefix) && bytes.HasSuffix(message, bytesSuffix)
+}
+
+// RawSigner is a Signer that can also sign data as is. Browser wallets sign wrapped raw messages of any length without
+// hashing them first, unlike transaction payloads, see PreparePayload.
+type RawSigner interface {
+	Signer
+	// SignUnhashed signs the data as is, even if it is longer than 256 bytes
+	SignUnhashed(data []byte) ([]byte, error)
+}
+
+// SignRaw signs an arbitrary message, e.g. a login challenge, after wrapping it in <Bytes>...</Bytes>, see WrapBytes.
+// Like the signRaw counterpart of polkadot-js, the wrapped message is signed as is, so signers have to implement
+// RawSigner to sign wrapped messages that are longer than 256 bytes. The signature can be verified with VerifyRaw.
+func SignRaw(signer Signer, message []byte) ([]byte, error) {
+	wrapped := WrapBytes(message)
+
+	if rs, ok := signer.(RawSigner); ok {
+		return rs.SignUnhashed(wrapped)
+	}
+
+	if len(wrapped) > 256 {
+		return nil, fmt.Errorf("signer can not sign raw messages longer than 256 bytes, got %d bytes", len(wrapped))
+	}
+
+	// short payloads are signed as is by all signers
+	return signer.Sign(wrapped)
+}
+
+// VerifyRaw verifies the signature of a message that was signed with SignRaw or by a browser wallet, e.g. with signRaw
+// of the polkadot-js extension. The message is wrapped in <Bytes>...</Bytes> before verification and is never hashed,
+// signatures over the plain message or over the hash of a long wrapped message are rejected.
+func VerifyRaw(scheme Scheme, message []byte, sig []byte, publicKey []byte) (bool, error) {
+	return verifyWithPublicKey(scheme, WrapBytes(message), sig, publicKey)
+}
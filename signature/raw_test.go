@@ -0,0 +1,149 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/stretchr/testify/assert"
+	"github.com/vedhavyas/go-subkey/v2"
+	"github.com/vedhavyas/go-subkey/v2/sr25519"
+)
+
+func TestWrapBytes(t *testing.T) {
+	assert.Equal(t, []byte("<Bytes>hello</Bytes>"), WrapBytes([]byte("hello")))
+	assert.Equal(t, []byte("<Bytes>hello</Bytes>"), WrapBytes([]byte("<Bytes>hello</Bytes>")))
+	assert.Equal(t, []byte("<Bytes></Bytes>"), WrapBytes(nil))
+
+	assert.Equal(t, []byte("hello"), UnwrapBytes([]byte("<Bytes>hello</Bytes>")))
+	assert.Equal(t, []byte("hello"), UnwrapBytes([]byte("hello")))
+
+	assert.True(t, IsWrappedBytes([]byte("<Bytes></Bytes>")))
+	assert.False(t, IsWrappedBytes([]byte("<Bytes>")))
+	assert.False(t, IsWrappedBytes([]byte("<Bytes></Bytes")))
+}
+
+func TestSignRawAndVerifyRaw(t *testing.T) {
+	for _, scheme := range []Scheme{SchemeSr25519, SchemeEd25519, SchemeEcdsa} {
+		s, err := NewKeyPairSigner(scheme, "//Alice")
+		assert.NoError(t, err)
+
+		for _, message := range [][]byte{[]byte("login challenge"), bytes.Repeat([]byte{1}, 300)} {
+			sig, err := SignRaw(s, message)
+			assert.NoError(t, err)
+
+			ok, err := VerifyRaw(scheme, message, sig, s.Public())
+			assert.NoError(t, err)
+			assert.True(t, ok, "%v signature over %d bytes", scheme, len(message))
+
+			// the wrapped message verifies as well
+			ok, err = VerifyRaw(scheme, WrapBytes(message), sig, s.Public())
+			assert.NoError(t, err)
+			assert.True(t, ok)
+		}
+
+		// signatures over the plain message are rejected
+		sig, err := s.Sign([]byte("login challenge"))
+		assert.NoError(t, err)
+
+		ok, err := VerifyRaw(scheme, []byte("login challenge"), sig, s.Public())
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	}
+}
+
+func TestVerifyRaw_UnhashedLongMessage(t *testing.T) {
+	// browser wallets sign long wrapped messages without hashing them first
+	kp, err := subkey.DeriveKeyPair(sr25519.Scheme{}, "//Alice")
+	assert.NoError(t, err)
+
+	message := bytes.Repeat([]byte{1}, 300)
+
+	sig, err := kp.Sign(WrapBytes(message))
+	assert.NoError(t, err)
+
+	ok, err := VerifyRaw(SchemeSr25519, message, sig, kp.Public())
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestSignRaw_LongMessage(t *testing.T) {
+	// ed25519 signatures are deterministic, this is the signature of //Alice over the unhashed wrapped message that
+	// signRaw of polkadot-js creates
+	message := bytes.Repeat([]byte("polkadot"), 40)
+	expected := "51f4507b7f4c8a0ad2436fe2afb07188157fa116e94f746afcd0e42a339aaf2c" +
+		"21ccd78ec3226a8e447635989b01df206c6f2b0d0e94d232d2858029e573b50b"
+
+	s, err := NewKeyPairSigner(SchemeEd25519, "//Alice")
+	assert.NoError(t, err)
+	assert.Equal(t, "88dc3417d5058ec4b4503e0c12ea1a0a89be200fe98922423d4334014fa6b0ee", hex.EncodeToString(s.Public()))
+
+	sig, err := SignRaw(s, message)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, hex.EncodeToString(sig))
+
+	kp, err := KeyringPairFromSecretWithScheme(SchemeEd25519, "//Alice", 42)
+	assert.NoError(t, err)
+
+	sig, err = SignRaw(kp, message)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, hex.EncodeToString(sig))
+
+	ok, err := VerifyRaw(SchemeEd25519, message, sig, s.Public())
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	// the signature over the hash of the wrapped message, as created by Sign, is rejected
+	hashed, err := s.Sign(WrapBytes(message))
+	assert.NoError(t, err)
+	assert.NotEqual(t, expected, hex.EncodeToString(hashed))
+
+	ok, err = VerifyRaw(SchemeEd25519, message, hashed, s.Public())
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	// signers that always hash long payloads can only sign short messages
+	_, err = SignRaw(hashingSigner{s}, message)
+	assert.EqualError(t, err, "signer can not sign raw messages longer than 256 bytes, got 335 bytes")
+
+	sig, err = SignRaw(hashingSigner{s}, []byte("login challenge"))
+	assert.NoError(t, err)
+
+	ok, err = VerifyRaw(SchemeEd25519, []byte("login challenge"), sig, s.Public())
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
+
+// hashingSigner only implements Signer
+type hashingSigner struct {
+	s Signer
+}
+
+func (h hashingSigner) Public() []byte {
+	return h.s.Public()
+}
+
+func (h hashingSigner) Scheme() Scheme {
+	return h.s.Scheme()
+}
+
+func (h hashingSigner) Sign(payload []byte) ([]byte, error) {
+	return h.s.Sign(payload)
+}
@@ -23,8 +23,6 @@ import (
 	"strconv"
 
 	"github.com/vedhavyas/go-subkey/v2"
-	"github.com/vedhavyas/go-subkey/v2/sr25519"
-	"golang.org/x/crypto/blake2b"
 )
 
 type KeyringPair struct {
@@ -34,13 +32,24 @@ type KeyringPair struct {
 	Address string
 	// PublicKey
 	PublicKey []byte
+	// Type is the crypto scheme of the key pair, sr25519 if not set
+	Type Scheme
 }
 
 // KeyringPairFromSecret creates KeyPair based on seed/phrase and network
 // Leave network empty for default behavior
 func KeyringPairFromSecret(seedOrPhrase string, network uint16) (KeyringPair, error) {
-	scheme := sr25519.Scheme{}
-	kyr, err := subkey.DeriveKeyPair(scheme, seedOrPhrase)
+	return KeyringPairFromSecretWithScheme(SchemeSr25519, seedOrPhrase, network)
+}
+
+// KeyringPairFromSecretWithScheme creates KeyPair of the given crypto scheme based on seed/phrase and network
+func KeyringPairFromSecretWithScheme(scheme Scheme, seedOrPhrase string, network uint16) (KeyringPair, error) {
+	s, err := scheme.subkeyScheme()
+	if err != nil {
+		return KeyringPair{}, err
+	}
+
+	kyr, err := subkey.DeriveKeyPair(s, seedOrPhrase)
 	if err != nil {
 		return KeyringPair{}, err
 	}
@@ -53,6 +62,7 @@ func KeyringPairFromSecret(seedOrPhrase string, network uint16) (KeyringPair, er
 		URI:       seedOrPhrase,
 		Address:   ss58Address,
 		PublicKey: pk,
+		Type:      scheme,
 	}, nil
 }
 
@@ -74,17 +84,27 @@ var TestKeyringPairCharlie = KeyringPair{
 	Address:   "5FLSigC9HGRKVhB9FiEo4Y3koPsNmBmLJbpXg2mp1hXcS59Y",
 }
 
-// Sign signs data with the private key under the given derivation path, returning the signature. Requires the subkey
-// command to be in path
+// Sign signs data with the sr25519 private key under the given derivation path, returning the signature
 func Sign(data []byte, privateKeyURI string) ([]byte, error) {
+	return SignWithScheme(SchemeSr25519, data, privateKeyURI)
+}
+
+// SignWithScheme signs data with the private key of the given crypto scheme under the given derivation path,
+// returning the signature. ECDSA signatures are created over the blake2b-256 hash of the data and use the 65 byte
+// recoverable form.
+func SignWithScheme(scheme Scheme, data []byte, privateKeyURI string) ([]byte, error) {
 	// if data is longer than 256 bytes, hash it first
-	if len(data) > 256 {
-		h := blake2b.Sum256(data)
-		data = h[:]
+	return signWithScheme(scheme, PreparePayload(data), privateKeyURI)
+}
+
+// signWithScheme signs data as is, without hashing long data first
+func signWithScheme(scheme Scheme, data []byte, privateKeyURI string) ([]byte, error) {
+	s, err := scheme.subkeyScheme()
+	if err != nil {
+		return nil, err
 	}
 
-	scheme := sr25519.Scheme{}
-	kyr, err := subkey.DeriveKeyPair(scheme, privateKeyURI)
+	kyr, err := subkey.DeriveKeyPair(s, privateKeyURI)
 	if err != nil {
 		return nil, err
 	}
@@ -97,22 +117,28 @@ func Sign(data []byte, privateKeyURI string) ([]byte, error) {
 	return signature, nil
 }
 
-// Verify verifies data using the provided signature and the key under the derivation path. Requires the subkey
-// command to be in path
+// Verify verifies data using the provided signature and the sr25519 key under the derivation path
 func Verify(data []byte, sig []byte, privateKeyURI string) (bool, error) {
+	return VerifyWithScheme(SchemeSr25519, data, sig, privateKeyURI)
+}
+
+// VerifyWithScheme verifies data using the provided signature and the key of the given crypto scheme under the
+// derivation path. Use VerifyWithPublicKey to verify signatures without the private key.
+func VerifyWithScheme(scheme Scheme, data []byte, sig []byte, privateKeyURI string) (bool, error) {
 	// if data is longer than 256 bytes, hash it first
-	if len(data) > 256 {
-		h := blake2b.Sum256(data)
-		data = h[:]
+	data = PreparePayload(data)
+
+	s, err := scheme.subkeyScheme()
+	if err != nil {
+		return false, err
 	}
 
-	scheme := sr25519.Scheme{}
-	kyr, err := subkey.DeriveKeyPair(scheme, privateKeyURI)
+	kyr, err := subkey.DeriveKeyPair(s, privateKeyURI)
 	if err != nil {
 		return false, err
 	}
 
-	if len(sig) != 64 {
+	if len(sig) != scheme.SignatureLength() {
 		return false, errors.New("wrong signature length")
 	}
 
@@ -121,6 +147,40 @@ func Verify(data []byte, sig []byte, privateKeyURI string) (bool, error) {
 	return v, nil
 }
 
+// VerifyWithPublicKey verifies data using the provided signature and the public key of the given crypto scheme, the
+// private key is not needed. Like when signing, data that is longer than 256 bytes is hashed first.
+func VerifyWithPublicKey(scheme Scheme, data []byte, sig []byte, publicKey []byte) (bool, error) {
+	return verifyWithPublicKey(scheme, PreparePayload(data), sig, publicKey)
+}
+
+// verifyWithPublicKey verifies the signature of data as is, without hashing long data first
+func verifyWithPublicKey(scheme Scheme, data []byte, sig []byte, publicKey []byte) (bool, error) {
+	if len(sig) != scheme.SignatureLength() {
+		return false, errors.New("wrong signature length")
+	}
+
+	if scheme == SchemeEthereum {
+		return verifyEthereum(data, sig, publicKey)
+	}
+
+	s, err := scheme.subkeyScheme()
+	if err != nil {
+		return false, err
+	}
+
+	// ed25519 public keys are not validated by subkey
+	if scheme == SchemeEd25519 && len(publicKey) != 32 {
+		return false, fmt.Errorf("expected an ed25519 public key of 32 bytes, got %d", len(publicKey))
+	}
+
+	pub, err := s.FromPublicKey(publicKey)
+	if err != nil {
+		return false, fmt.Errorf("invalid %v public key: %w", scheme, err)
+	}
+
+	return pub.Verify(data, sig), nil
+}
+
 // LoadKeyringPairFromEnv looks up whether the env variable TEST_PRIV_KEY is set and is not empty and tries to use its
 // content as a private phrase, seed or URI to derive a key ring pair. Panics if the private phrase, seed or URI is
 // not valid or the keyring pair cannot be derived
@@ -22,7 +22,9 @@ import (
 
 	. "github.com/centrifuge/go-substrate-rpc-client/v4/signature"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	secp256k1 "github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/blake2b"
 )
 
 var testSecretPhrase = "little orbit comfort eyebrow talk pink flame ridge bring milk equip blood"
@@ -141,3 +143,122 @@ func TestSignAndVerifyLong(t *testing.T) {
 
 	assert.True(t, ok)
 }
+
+func TestKeyringPairFromSecretWithScheme(t *testing.T) {
+	p, err := KeyringPairFromSecretWithScheme(SchemeEd25519, "//Alice", 42)
+	assert.NoError(t, err)
+	assert.Equal(t, KeyringPair{
+		URI:       "//Alice",
+		Address:   "5FA9nQDVg267DEd8m1ZypXLBnvN7SFxYwV7ndqSYGiN9TTpu",
+		PublicKey: types.MustHexDecodeString("0x88dc3417d5058ec4b4503e0c12ea1a0a89be200fe98922423d4334014fa6b0ee"),
+		Type:      SchemeEd25519,
+	}, p)
+
+	p, err = KeyringPairFromSecretWithScheme(SchemeEcdsa, "//Alice", 42)
+	assert.NoError(t, err)
+	assert.Equal(t, KeyringPair{
+		URI:       "//Alice",
+		Address:   "5C7C2Z5sWbytvHpuLTvzKunnnRwQxft1jiqrLD5rhucQ5S9X",
+		PublicKey: types.MustHexDecodeString("0x020a1091341fe5664bfa1782d5e04779689068c916b04cb365ec3153755684d9a1"),
+		Type:      SchemeEcdsa,
+	}, p)
+	assert.Equal(t, types.MustHexDecodeString("0x01e552298e47454041ea31273b4b630c64c104e4514aa3643490b8aaca9cf8ed"),
+		SchemeEcdsa.AccountID(p.PublicKey))
+
+	p, err = KeyringPairFromSecretWithScheme(SchemeSr25519, "//Alice", 42)
+	assert.NoError(t, err)
+	assert.Equal(t, TestKeyringPairAlice.PublicKey, p.PublicKey)
+	assert.Equal(t, TestKeyringPairAlice.Address, p.Address)
+
+	_, err = KeyringPairFromSecretWithScheme(Scheme(4), "//Alice", 42)
+	assert.EqualError(t, err, "unsupported scheme unknown scheme 4")
+}
+
+func TestSignAndVerifyWithScheme(t *testing.T) {
+	for _, scheme := range []Scheme{SchemeSr25519, SchemeEd25519, SchemeEcdsa} {
+		for _, n := range []int{32, 300} {
+			data := make([]byte, n)
+			_, err := rand.Read(data)
+			assert.NoError(t, err)
+
+			sig, err := SignWithScheme(scheme, data, testSecretPhrase)
+			assert.NoError(t, err)
+			assert.Len(t, sig, scheme.SignatureLength())
+
+			ok, err := VerifyWithScheme(scheme, data, sig, testSecretPhrase)
+			assert.NoError(t, err)
+			assert.True(t, ok, "%v signature over %d bytes", scheme, n)
+
+			_, err = VerifyWithScheme(scheme, data, sig[:63], testSecretPhrase)
+			assert.EqualError(t, err, "wrong signature length")
+		}
+	}
+}
+
+func TestSignWithScheme_EcdsaRecoverable(t *testing.T) {
+	p, err := KeyringPairFromSecretWithScheme(SchemeEcdsa, testSecretPhrase, 42)
+	assert.NoError(t, err)
+
+	data := []byte("hello world")
+
+	sig, err := p.Sign(data)
+	assert.NoError(t, err)
+	assert.Len(t, sig, 65)
+
+	// the signature is created over the blake2b-256 hash of the data and the public key can be recovered from it
+	digest := blake2b.Sum256(data)
+	pub, err := secp256k1.SigToPub(digest[:], sig)
+	assert.NoError(t, err)
+	assert.Equal(t, p.PublicKey, secp256k1.CompressPubkey(pub))
+}
+
+func TestVerifyWithPublicKey(t *testing.T) {
+	alith, err := NewEthereumSignerFromMnemonic(devPhrase, "", DefaultEthereumDerivationPath)
+	assert.NoError(t, err)
+
+	signers := []Signer{alith}
+	for _, scheme := range []Scheme{SchemeSr25519, SchemeEd25519, SchemeEcdsa} {
+		s, err := NewKeyPairSigner(scheme, testSecretPhrase)
+		assert.NoError(t, err)
+		signers = append(signers, s)
+	}
+
+	for _, s := range signers {
+		for _, n := range []int{32, 300} {
+			data := make([]byte, n)
+			_, err := rand.Read(data)
+			assert.NoError(t, err)
+
+			sig, err := s.Sign(data)
+			assert.NoError(t, err)
+
+			ok, err := VerifyWithPublicKey(s.Scheme(), data, sig, s.Public())
+			assert.NoError(t, err)
+			assert.True(t, ok, "%v signature over %d bytes", s.Scheme(), n)
+
+			data[0]++
+			ok, err = VerifyWithPublicKey(s.Scheme(), data, sig, s.Public())
+			assert.NoError(t, err)
+			assert.False(t, ok, "%v signature over modified data", s.Scheme())
+
+			_, err = VerifyWithPublicKey(s.Scheme(), data, sig[:63], s.Public())
+			assert.EqualError(t, err, "wrong signature length")
+		}
+	}
+}
+
+func TestVerifyWithPublicKey_InvalidPublicKey(t *testing.T) {
+	sig := make([]byte, 64)
+
+	_, err := VerifyWithPublicKey(SchemeSr25519, []byte("data"), sig, []byte{1, 2, 3})
+	assert.EqualError(t, err, "invalid sr25519 public key: expected 32 bytes")
+
+	_, err = VerifyWithPublicKey(SchemeEd25519, []byte("data"), sig, []byte{1, 2, 3})
+	assert.EqualError(t, err, "expected an ed25519 public key of 32 bytes, got 3")
+
+	_, err = VerifyWithPublicKey(SchemeEcdsa, []byte("data"), append(sig, 0), []byte{1, 2, 3})
+	assert.Error(t, err)
+
+	_, err = VerifyWithPublicKey(SchemeEthereum, []byte("data"), append(sig, 0), []byte{1, 2, 3})
+	assert.EqualError(t, err, "invalid ethereum public key")
+}
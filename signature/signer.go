@@ -0,0 +1,180 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature
+
+import (
+	"fmt"
+
+	"github.com/vedhavyas/go-subkey/v2"
+	"github.com/vedhavyas/go-subkey/v2/ecdsa"
+	"github.com/vedhavyas/go-subkey/v2/ed25519"
+	"github.com/vedhavyas/go-subkey/v2/sr25519"
+	"golang.org/x/crypto/blake2b"
+)
+
+// Scheme is the crypto scheme of a Signer or a KeyringPair, the zero value is sr25519
+type Scheme uint8
+
+const (
+	SchemeSr25519  Scheme = 0
+	SchemeEd25519  Scheme = 1
+	SchemeEcdsa    Scheme = 2
+	SchemeEthereum Scheme = 3 // secp256k1 over keccak-256 with 20 byte account IDs, see EthereumSigner
+)
+
+func (s Scheme) String() string {
+	switch s {
+	case SchemeEd25519:
+		return "ed25519"
+	case SchemeSr25519:
+		return "sr25519"
+	case SchemeEcdsa:
+		return "ecdsa"
+	case SchemeEthereum:
+		return "ethereum"
+	default:
+		return fmt.Sprintf("unknown scheme %d", uint8(s))
+	}
+}
+
+// subkeyScheme returns the subkey implementation of the scheme
+func (s Scheme) subkeyScheme() (subkey.Scheme, error) {
+	switch s {
+	case SchemeSr25519:
+		return sr25519.Scheme{}, nil
+	case SchemeEd25519:
+		return ed25519.Scheme{}, nil
+	case SchemeEcdsa:
+		return ecdsa.Scheme{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported scheme %v", s)
+	}
+}
+
+// SignatureLength returns the length of the signatures of the scheme, ECDSA and Ethereum signatures use the 65 byte
+// recoverable form
+func (s Scheme) SignatureLength() int {
+	if s == SchemeEcdsa || s == SchemeEthereum {
+		return 65
+	}
+	return 64
+}
+
+// AccountID returns the account ID for a public key of the scheme. ECDSA public keys are 33 bytes long, their
+// account ID is the blake2b-256 hash of the public key. The account ID of Ethereum public keys is their 20 byte
+// Ethereum address, nil is returned for invalid Ethereum public keys.
+func (s Scheme) AccountID(publicKey []byte) []byte {
+	switch s {
+	case SchemeEcdsa:
+		h := blake2b.Sum256(publicKey)
+		return h[:]
+	case SchemeEthereum:
+		return ethereumAddress(publicKey)
+	default:
+		return publicKey
+	}
+}
+
+// Signer signs payloads, it can be backed by a key in memory, a remote signing service, a KMS or a hardware wallet.
+type Signer interface {
+	// Public returns the public key of the signer
+	Public() []byte
+	// Scheme returns the crypto scheme of the signer
+	Scheme() Scheme
+	// Sign signs the payload and returns the signature. The payload is passed as is, signers have to hash payloads that
+	// are longer than 256 bytes with blake2b-256 before signing them, see PreparePayload.
+	Sign(payload []byte) ([]byte, error)
+}
+
+// PreparePayload returns the data that is signed for a payload, payloads longer than 256 bytes are hashed first
+func PreparePayload(payload []byte) []byte {
+	if len(payload) > 256 {
+		h := blake2b.Sum256(payload)
+		return h[:]
+	}
+
+	return payload
+}
+
+// Public returns the public key of the keyring pair
+func (k KeyringPair) Public() []byte {
+	return k.PublicKey
+}
+
+// Scheme returns the crypto scheme of the keyring pair
+func (k KeyringPair) Scheme() Scheme {
+	return k.Type
+}
+
+// Sign signs the payload with the private key under the derivation path of the keyring pair. The key is derived on
+// every call, use a KeyPairSigner to avoid that.
+func (k KeyringPair) Sign(payload []byte) ([]byte, error) {
+	return SignWithScheme(k.Type, payload, k.URI)
+}
+
+// SignUnhashed signs the data as is, without hashing it first if it is longer than 256 bytes, see RawSigner
+func (k KeyringPair) SignUnhashed(data []byte) ([]byte, error) {
+	return signWithScheme(k.Type, data, k.URI)
+}
+
+// KeyPairSigner is a Signer backed by a key pair that is derived once, so that the secret does not have to be kept
+// around as a string.
+type KeyPairSigner struct {
+	kp     subkey.KeyPair
+	scheme Scheme
+}
+
+// NewKeyPairSigner derives the key pair of the given scheme from a seed, phrase or URI and returns a signer for it
+func NewKeyPairSigner(scheme Scheme, seedOrPhrase string) (*KeyPairSigner, error) {
+	s, err := scheme.subkeyScheme()
+	if err != nil {
+		return nil, err
+	}
+
+	kp, err := subkey.DeriveKeyPair(s, seedOrPhrase)
+	if err != nil {
+		return nil, err
+	}
+
+	return &KeyPairSigner{kp: kp, scheme: scheme}, nil
+}
+
+// Public returns the public key of the signer
+func (s *KeyPairSigner) Public() []byte {
+	return s.kp.Public()
+}
+
+// Scheme returns the crypto scheme of the signer
+func (s *KeyPairSigner) Scheme() Scheme {
+	return s.scheme
+}
+
+// Sign signs the payload, hashing it first if it is longer than 256 bytes. ECDSA signers hash the payload with
+// blake2b-256 and return a 65 byte recoverable signature.
+func (s *KeyPairSigner) Sign(payload []byte) ([]byte, error) {
+	return s.kp.Sign(PreparePayload(payload))
+}
+
+// SignUnhashed signs the data as is, without hashing it first if it is longer than 256 bytes, see RawSigner
+func (s *KeyPairSigner) SignUnhashed(data []byte) ([]byte, error) {
+	return s.kp.Sign(data)
+}
+
+// SS58Address returns the SS58 address of the signer for the given network
+func (s *KeyPairSigner) SS58Address(network uint16) string {
+	return s.kp.SS58Address(network)
+}
@@ -0,0 +1,113 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature_test
+
+import (
+	"crypto/rand"
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/blake2b"
+)
+
+var _ Signer = KeyringPair{}
+var _ Signer = &KeyPairSigner{}
+
+func TestKeyringPair_Signer(t *testing.T) {
+	data := make([]byte, 300)
+	_, err := rand.Read(data)
+	assert.NoError(t, err)
+
+	assert.Equal(t, TestKeyringPairAlice.PublicKey, TestKeyringPairAlice.Public())
+	assert.Equal(t, SchemeSr25519, TestKeyringPairAlice.Scheme())
+
+	sig, err := TestKeyringPairAlice.Sign(data)
+	assert.NoError(t, err)
+
+	ok, err := Verify(data, sig, TestKeyringPairAlice.URI)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestKeyPairSigner(t *testing.T) {
+	signer, err := NewKeyPairSigner(SchemeSr25519, testSecretPhrase)
+	assert.NoError(t, err)
+
+	assert.Equal(t, types.MustHexDecodeString(testPubKey), signer.Public())
+	assert.Equal(t, SchemeSr25519, signer.Scheme())
+	assert.Equal(t, testAddressSS58, signer.SS58Address(42))
+
+	for _, n := range []int{32, 256, 257} {
+		data := make([]byte, n)
+		_, err = rand.Read(data)
+		assert.NoError(t, err)
+
+		sig, err := signer.Sign(data)
+		assert.NoError(t, err)
+
+		ok, err := Verify(data, sig, testSecretPhrase)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+	}
+}
+
+func TestNewKeyPairSigner_Errors(t *testing.T) {
+	_, err := NewKeyPairSigner(Scheme(42), testSecretPhrase)
+	assert.EqualError(t, err, "unsupported scheme unknown scheme 42")
+
+	_, err = NewKeyPairSigner(SchemeSr25519, "foo")
+	assert.Error(t, err)
+}
+
+func TestPreparePayload(t *testing.T) {
+	short := make([]byte, 256)
+	assert.Equal(t, short, PreparePayload(short))
+
+	long := make([]byte, 257)
+	h := blake2b.Sum256(long)
+	assert.Equal(t, h[:], PreparePayload(long))
+}
+
+func TestScheme_String(t *testing.T) {
+	assert.Equal(t, "ed25519", SchemeEd25519.String())
+	assert.Equal(t, "sr25519", SchemeSr25519.String())
+	assert.Equal(t, "ecdsa", SchemeEcdsa.String())
+}
+
+func TestKeyPairSigner_Schemes(t *testing.T) {
+	for _, scheme := range []Scheme{SchemeEd25519, SchemeEcdsa} {
+		signer, err := NewKeyPairSigner(scheme, "//Alice")
+		assert.NoError(t, err)
+
+		p, err := KeyringPairFromSecretWithScheme(scheme, "//Alice", 42)
+		assert.NoError(t, err)
+
+		assert.Equal(t, p.PublicKey, signer.Public())
+		assert.Equal(t, p.Address, signer.SS58Address(42))
+		assert.Equal(t, scheme, signer.Scheme())
+
+		sig, err := signer.Sign([]byte("payload"))
+		assert.NoError(t, err)
+		assert.Len(t, sig, scheme.SignatureLength())
+
+		ok, err := VerifyWithScheme(scheme, []byte("payload"), sig, "//Alice")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+	}
+}
@@ -0,0 +1,164 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ss58
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// Network is a network of the SS58 registry, see https://github.com/paritytech/ss58-registry
+type Network struct {
+	// Prefix is the address prefix of the network
+	Prefix uint16
+	// Name is the unique name of the network, e.g. polkadot
+	Name string
+	// DisplayName is the human readable name of the network, e.g. Polkadot Relay Chain
+	DisplayName string
+	// Symbols are the symbols of the tokens of the network
+	Symbols []string
+	// Decimals are the decimals of the tokens of the network, in the same order as the symbols
+	Decimals []uint8
+}
+
+// Address returns the address of the account ID on the network
+func (n Network) Address(accountID types.AccountID) (string, error) {
+	return EncodeAccountID(accountID, n.Prefix)
+}
+
+// AccountID returns the account ID of an address of the network
+func (n Network) AccountID(address string) (types.AccountID, error) {
+	return DecodeAccountID(address, n.Prefix)
+}
+
+// The networks of the bundled registry, see Networks
+var (
+	Polkadot = Network{
+		Prefix: 0, Name: "polkadot", DisplayName: "Polkadot Relay Chain", Symbols: []string{"DOT"}, Decimals: []uint8{10},
+	}
+	Kusama = Network{
+		Prefix: 2, Name: "kusama", DisplayName: "Kusama Relay Chain", Symbols: []string{"KSM"}, Decimals: []uint8{12},
+	}
+	Astar = Network{
+		Prefix: 5, Name: "astar", DisplayName: "Astar Network", Symbols: []string{"ASTR"}, Decimals: []uint8{18},
+	}
+	Bifrost = Network{
+		Prefix: 6, Name: "bifrost", DisplayName: "Bifrost", Symbols: []string{"BNC"}, Decimals: []uint8{12},
+	}
+	Edgeware = Network{
+		Prefix: 7, Name: "edgeware", DisplayName: "Edgeware", Symbols: []string{"EDG"}, Decimals: []uint8{18},
+	}
+	Karura = Network{
+		Prefix: 8, Name: "karura", DisplayName: "Karura", Symbols: []string{"KAR"}, Decimals: []uint8{12},
+	}
+	Acala = Network{
+		Prefix: 10, Name: "acala", DisplayName: "Acala", Symbols: []string{"ACA"}, Decimals: []uint8{12},
+	}
+	Polymesh = Network{
+		Prefix: 12, Name: "polymesh", DisplayName: "Polymesh", Symbols: []string{"POLYX"}, Decimals: []uint8{6},
+	}
+	Centrifuge = Network{
+		Prefix: 36, Name: "centrifuge", DisplayName: "Centrifuge Chain", Symbols: []string{"CFG"}, Decimals: []uint8{18},
+	}
+	Substrate = Network{
+		Prefix: 42, Name: "substrate", DisplayName: "Substrate",
+	}
+	HydraDX = Network{
+		Prefix: 63, Name: "hydradx", DisplayName: "HydraDX", Symbols: []string{"HDX"}, Decimals: []uint8{12},
+	}
+	Altair = Network{
+		Prefix: 136, Name: "altair", DisplayName: "Altair", Symbols: []string{"AIR"}, Decimals: []uint8{18},
+	}
+	Moonbeam = Network{
+		Prefix: 1284, Name: "moonbeam", DisplayName: "Moonbeam", Symbols: []string{"GLMR"}, Decimals: []uint8{18},
+	}
+	Moonriver = Network{
+		Prefix: 1285, Name: "moonriver", DisplayName: "Moonriver", Symbols: []string{"MOVR"}, Decimals: []uint8{18},
+	}
+)
+
+var (
+	registryMu sync.RWMutex
+	registry   = []Network{
+		Polkadot, Kusama, Astar, Bifrost, Edgeware, Karura, Acala, Polymesh, Centrifuge, Substrate, HydraDX, Altair,
+		Moonbeam, Moonriver,
+	}
+)
+
+// RegisterNetwork adds a network to the registry, a network that is already registered with the same prefix or name is
+// replaced.
+func RegisterNetwork(n Network) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	networks := registry[:0:0]
+	for _, registered := range registry {
+		if registered.Prefix != n.Prefix && registered.Name != n.Name {
+			networks = append(networks, registered)
+		}
+	}
+
+	registry = append(networks, n)
+}
+
+// Networks returns the registered networks
+func Networks() []Network {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	return append([]Network{}, registry...)
+}
+
+// NetworkByPrefix returns the registered network with the given prefix
+func NetworkByPrefix(prefix uint16) (Network, bool) {
+	return findNetwork(func(n Network) bool { return n.Prefix == prefix })
+}
+
+// NetworkByName returns the registered network with the given name
+func NetworkByName(name string) (Network, bool) {
+	return findNetwork(func(n Network) bool { return n.Name == name })
+}
+
+// NetworkOf returns the registered network of the address
+func NetworkOf(address string) (Network, error) {
+	_, prefix, err := Decode(address)
+	if err != nil {
+		return Network{}, err
+	}
+
+	n, ok := NetworkByPrefix(prefix)
+	if !ok {
+		return Network{}, fmt.Errorf("network with prefix %d not found in registry", prefix)
+	}
+
+	return n, nil
+}
+
+func findNetwork(match func(n Network) bool) (Network, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	for _, n := range registry {
+		if match(n) {
+			return n, true
+		}
+	}
+
+	return Network{}, false
+}
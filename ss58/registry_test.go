@@ -0,0 +1,94 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ss58
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkByPrefix(t *testing.T) {
+	n, ok := NetworkByPrefix(2)
+	assert.True(t, ok)
+	assert.Equal(t, Kusama, n)
+	assert.Equal(t, []string{"KSM"}, n.Symbols)
+	assert.Equal(t, []uint8{12}, n.Decimals)
+
+	_, ok = NetworkByPrefix(9999)
+	assert.False(t, ok)
+}
+
+func TestNetworkByName(t *testing.T) {
+	n, ok := NetworkByName("polkadot")
+	assert.True(t, ok)
+	assert.Equal(t, Polkadot, n)
+
+	_, ok = NetworkByName("unknown")
+	assert.False(t, ok)
+}
+
+func TestNetworkOf(t *testing.T) {
+	n, err := NetworkOf("15oF4uVJwmo4TdGW7VfQxNLavjCXviqxT9S1MgbjMNHr6Sp5")
+	assert.NoError(t, err)
+	assert.Equal(t, Polkadot, n)
+
+	address, err := Encode(testAlice[:], 9999)
+	assert.NoError(t, err)
+
+	_, err = NetworkOf(address)
+	assert.EqualError(t, err, "network with prefix 9999 not found in registry")
+}
+
+func TestNetwork_Address(t *testing.T) {
+	address, err := Kusama.Address(testAlice)
+	assert.NoError(t, err)
+	assert.Equal(t, "HNZata7iMYWmk5RvZRTiAsSDhV8366zq2YGb3tLH5Upf74F", address)
+
+	accountID, err := Kusama.AccountID(address)
+	assert.NoError(t, err)
+	assert.Equal(t, testAlice, accountID)
+
+	_, err = Polkadot.AccountID(address)
+	assert.Error(t, err)
+}
+
+func TestRegisterNetwork(t *testing.T) {
+	defer func(networks []Network) {
+		registry = networks
+	}(Networks())
+
+	custom := Network{Prefix: 9999, Name: "custom", DisplayName: "Custom", Symbols: []string{"CST"},
+		Decimals: []uint8{9}}
+	RegisterNetwork(custom)
+
+	n, ok := NetworkByPrefix(9999)
+	assert.True(t, ok)
+	assert.Equal(t, custom, n)
+
+	// a network with the same name replaces the registered one
+	replaced := Network{Prefix: 9998, Name: "custom"}
+	RegisterNetwork(replaced)
+
+	_, ok = NetworkByPrefix(9999)
+	assert.False(t, ok)
+
+	n, ok = NetworkByName("custom")
+	assert.True(t, ok)
+	assert.Equal(t, replaced, n)
+	assert.Len(t, Networks(), len(registry))
+}
@@ -0,0 +1,161 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ss58 implements the SS58 address format of Substrate based chains, see
+// https://docs.substrate.io/reference/address-formats/
+package ss58
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"golang.org/x/crypto/blake2b"
+)
+
+// MaxPrefix is the largest prefix that can be encoded in an address
+const MaxPrefix = 16383
+
+var checksumPrefix = []byte("SS58PRE")
+
+// checksumLengths maps the supported payload lengths to the lengths of their checksums
+var checksumLengths = map[int]int{
+	1:  1,
+	2:  1,
+	4:  1,
+	8:  1,
+	32: 2,
+	33: 2,
+}
+
+// Encode returns the address of the public key or account ID for the network with the given prefix
+func Encode(pubkey []byte, prefix uint16) (string, error) {
+	checksumLen, ok := checksumLengths[len(pubkey)]
+	if !ok {
+		return "", fmt.Errorf("invalid payload length %d", len(pubkey))
+	}
+
+	body, err := encodePrefix(prefix)
+	if err != nil {
+		return "", err
+	}
+
+	body = append(body, pubkey...)
+
+	return base58.Encode(append(body, checksum(body)[:checksumLen]...)), nil
+}
+
+// Decode returns the public key or account ID of the address together with the prefix of the network. It returns an
+// error if the checksum of the address is invalid.
+func Decode(address string) (pubkey []byte, prefix uint16, err error) {
+	data := base58.Decode(address)
+	if len(data) < 2 {
+		return nil, 0, errors.New("invalid address length")
+	}
+
+	var prefixLen int
+
+	switch {
+	case data[0] < 64:
+		prefix = uint16(data[0])
+		prefixLen = 1
+	case data[0] < 128:
+		prefix = uint16(data[0]&0x3f)<<2 | uint16(data[1]>>6) | uint16(data[1]&0x3f)<<8
+		prefixLen = 2
+	default:
+		return nil, 0, fmt.Errorf("invalid address prefix %d", data[0])
+	}
+
+	if isReserved(prefix) {
+		return nil, 0, fmt.Errorf("reserved address prefix %d", prefix)
+	}
+
+	var checksumLen int
+
+	for payloadLen, l := range checksumLengths {
+		if prefixLen+payloadLen+l == len(data) {
+			checksumLen = l
+			break
+		}
+	}
+
+	if checksumLen == 0 {
+		return nil, 0, fmt.Errorf("invalid address length %d", len(data))
+	}
+
+	body := data[:len(data)-checksumLen]
+	if !bytes.Equal(checksum(body)[:checksumLen], data[len(body):]) {
+		return nil, 0, errors.New("invalid address checksum")
+	}
+
+	return body[prefixLen:], prefix, nil
+}
+
+// EncodeAccountID returns the address of the account ID for the network with the given prefix
+func EncodeAccountID(accountID types.AccountID, prefix uint16) (string, error) {
+	return Encode(accountID[:], prefix)
+}
+
+// DecodeAccountID returns the account ID of an address of the network with the given prefix. It returns an error if
+// the address belongs to another network.
+func DecodeAccountID(address string, prefix uint16) (types.AccountID, error) {
+	pubkey, addressPrefix, err := Decode(address)
+	if err != nil {
+		return types.AccountID{}, err
+	}
+
+	if addressPrefix != prefix {
+		return types.AccountID{}, fmt.Errorf("address of network %d, expected an address of network %d",
+			addressPrefix, prefix)
+	}
+
+	if len(pubkey) != len(types.AccountID{}) {
+		return types.AccountID{}, fmt.Errorf("invalid account ID length %d", len(pubkey))
+	}
+
+	return types.NewAccountID(pubkey), nil
+}
+
+// encodePrefix returns the one or two bytes encoding of the prefix
+func encodePrefix(prefix uint16) ([]byte, error) {
+	switch {
+	case isReserved(prefix):
+		return nil, fmt.Errorf("reserved address prefix %d", prefix)
+	case prefix < 64:
+		return []byte{byte(prefix)}, nil
+	case prefix <= MaxPrefix:
+		// the lower six bits of the first byte are the upper six bits of the lower byte of the prefix, the second byte
+		// holds the lower two bits of the lower byte followed by the upper byte of the prefix
+		return []byte{
+			byte(prefix&0xfc)>>2 | 0x40,
+			byte(prefix>>8) | byte(prefix&0x03)<<6,
+		}, nil
+	default:
+		return nil, fmt.Errorf("address prefix %d is larger than %d", prefix, MaxPrefix)
+	}
+}
+
+// isReserved returns true for the prefixes that are reserved by the SS58 format
+func isReserved(prefix uint16) bool {
+	return prefix == 46 || prefix == 47
+}
+
+func checksum(body []byte) []byte {
+	h := blake2b.Sum512(append(append([]byte{}, checksumPrefix...), body...))
+	return h[:]
+}
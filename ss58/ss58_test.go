@@ -0,0 +1,115 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ss58
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/vedhavyas/go-subkey/v2"
+)
+
+var testAlice = types.NewAccountID(signature.TestKeyringPairAlice.PublicKey)
+
+func TestEncodeDecode(t *testing.T) {
+	for _, test := range []struct {
+		prefix  uint16
+		address string
+	}{
+		{0, "15oF4uVJwmo4TdGW7VfQxNLavjCXviqxT9S1MgbjMNHr6Sp5"},
+		{2, "HNZata7iMYWmk5RvZRTiAsSDhV8366zq2YGb3tLH5Upf74F"},
+		{42, "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"},
+		{63, subkey.SS58Encode(testAlice[:], 63)},
+		{64, subkey.SS58Encode(testAlice[:], 64)},
+		{1284, subkey.SS58Encode(testAlice[:], 1284)},
+		{MaxPrefix, subkey.SS58Encode(testAlice[:], MaxPrefix)},
+	} {
+		address, err := Encode(testAlice[:], test.prefix)
+		assert.NoError(t, err)
+		assert.Equal(t, test.address, address)
+
+		pubkey, prefix, err := Decode(address)
+		assert.NoError(t, err)
+		assert.Equal(t, testAlice[:], pubkey)
+		assert.Equal(t, test.prefix, prefix)
+	}
+}
+
+func TestEncodeDecode_ShortPayloads(t *testing.T) {
+	for _, payload := range [][]byte{{1}, {1, 2}, {1, 2, 3, 4}, {1, 2, 3, 4, 5, 6, 7, 8}} {
+		address, err := Encode(payload, 42)
+		assert.NoError(t, err)
+
+		// the checksum of short payloads is a single byte
+		assert.Len(t, base58.Decode(address), len(payload)+2)
+
+		pubkey, prefix, err := Decode(address)
+		assert.NoError(t, err)
+		assert.Equal(t, payload, pubkey)
+		assert.Equal(t, uint16(42), prefix)
+	}
+}
+
+func TestEncode_Errors(t *testing.T) {
+	_, err := Encode(testAlice[:31], 42)
+	assert.EqualError(t, err, "invalid payload length 31")
+
+	_, err = Encode(testAlice[:], 46)
+	assert.EqualError(t, err, "reserved address prefix 46")
+
+	_, err = Encode(testAlice[:], MaxPrefix+1)
+	assert.EqualError(t, err, "address prefix 16384 is larger than 16383")
+}
+
+func TestDecode_Errors(t *testing.T) {
+	_, _, err := Decode("")
+	assert.EqualError(t, err, "invalid address length")
+
+	// the last character of the address changes the checksum
+	_, _, err = Decode("5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQZ")
+	assert.EqualError(t, err, "invalid address checksum")
+
+	data := base58.Decode("5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY")
+
+	_, _, err = Decode(base58.Encode(data[:len(data)-1]))
+	assert.EqualError(t, err, "invalid address length 34")
+
+	_, _, err = Decode(base58.Encode(append([]byte{0x80}, data[1:]...)))
+	assert.EqualError(t, err, "invalid address prefix 128")
+}
+
+func TestEncodeDecodeAccountID(t *testing.T) {
+	address, err := EncodeAccountID(testAlice, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "15oF4uVJwmo4TdGW7VfQxNLavjCXviqxT9S1MgbjMNHr6Sp5", address)
+
+	accountID, err := DecodeAccountID(address, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, testAlice, accountID)
+
+	_, err = DecodeAccountID(address, 2)
+	assert.EqualError(t, err, "address of network 0, expected an address of network 2")
+
+	short, err := Encode(testAlice[:8], 0)
+	assert.NoError(t, err)
+
+	_, err = DecodeAccountID(short, 0)
+	assert.EqualError(t, err, "invalid account ID length 8")
+}
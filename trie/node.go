@@ -0,0 +1,239 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trie
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+)
+
+const (
+	// hashLength is the length of blake2b-256 hashes, child references of this length are hashes, shorter ones are
+	// inline nodes
+	hashLength = 32
+	// nibbleSizeBound is the maximum length of a partial key in nibbles
+	nibbleSizeBound = 65535
+
+	emptyTrie                = 0b0000_0000
+	leafPrefix               = 0b0100_0000
+	branchWithoutValuePrefix = 0b1000_0000
+	branchWithValuePrefix    = 0b1100_0000
+	// hashedValueLeafPrefix and hashedValueBranchPrefix are used by state version V1 for nodes whose value is
+	// referenced by its hash
+	hashedValueLeafPrefix   = 0b0010_0000
+	hashedValueBranchPrefix = 0b0001_0000
+)
+
+type nodeKind uint8
+
+const (
+	emptyNode nodeKind = iota
+	leafNode
+	branchNode
+)
+
+// node is a decoded trie node
+type node struct {
+	kind nodeKind
+	// partial is the partial key of the node in nibbles
+	partial []byte
+	// value is the inline value of the node or the hash of the value if hashedValue is set
+	value       []byte
+	hasValue    bool
+	hashedValue bool
+	// children are the references of the children of a branch, either a hash or an encoded inline node
+	children [16][]byte
+}
+
+// decodeNode decodes a node that is encoded with the node codec of Substrate
+func decodeNode(data []byte) (*node, error) { //nolint:funlen
+	r := bytes.NewReader(data)
+
+	header, err := r.ReadByte()
+	if err != nil {
+		return nil, errors.New("empty node")
+	}
+
+	n := &node{}
+
+	var nibbles int
+
+	switch {
+	case header == emptyTrie:
+		if r.Len() != 0 {
+			return nil, errors.New("empty node with data")
+		}
+		return n, nil
+	case header&0b1100_0000 == leafPrefix:
+		n.kind, n.hasValue = leafNode, true
+		nibbles, err = decodeSize(header, r, 2)
+	case header&0b1100_0000 == branchWithValuePrefix:
+		n.kind, n.hasValue = branchNode, true
+		nibbles, err = decodeSize(header, r, 2)
+	case header&0b1100_0000 == branchWithoutValuePrefix:
+		n.kind = branchNode
+		nibbles, err = decodeSize(header, r, 2)
+	case header&0b1110_0000 == hashedValueLeafPrefix:
+		n.kind, n.hasValue, n.hashedValue = leafNode, true, true
+		nibbles, err = decodeSize(header, r, 3)
+	case header&0b1111_0000 == hashedValueBranchPrefix:
+		n.kind, n.hasValue, n.hashedValue = branchNode, true, true
+		nibbles, err = decodeSize(header, r, 4)
+	default:
+		return nil, fmt.Errorf("invalid node header %#x", header)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	n.partial, err = decodePartial(r, nibbles)
+	if err != nil {
+		return nil, err
+	}
+
+	var bitmap uint16
+
+	if n.kind == branchNode {
+		b, err := readBytes(r, 2)
+		if err != nil {
+			return nil, err
+		}
+
+		bitmap = binary.LittleEndian.Uint16(b)
+		if bitmap == 0 {
+			return nil, errors.New("branch without children")
+		}
+	}
+
+	if n.hasValue {
+		if n.hashedValue {
+			n.value, err = readBytes(r, hashLength)
+		} else {
+			n.value, err = readCompactBytes(r)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range n.children {
+		if bitmap&(1<<i) == 0 {
+			continue
+		}
+
+		n.children[i], err = readCompactBytes(r)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if r.Len() != 0 {
+		return nil, fmt.Errorf("%d trailing bytes after node", r.Len())
+	}
+
+	return n, nil
+}
+
+// decodeSize decodes the number of nibbles of the partial key, which is stored in the lower bits of the header and
+// continued in the following bytes if it does not fit
+func decodeSize(header byte, r *bytes.Reader, prefixBits int) (int, error) {
+	maxValue := int(byte(255) >> prefixBits)
+
+	size := int(header) & maxValue
+	if size < maxValue {
+		return size, nil
+	}
+
+	size--
+
+	for size <= nibbleSizeBound {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, io.ErrUnexpectedEOF
+		}
+
+		if b < 255 {
+			return size + int(b) + 1, nil
+		}
+
+		size += 255
+	}
+
+	return nibbleSizeBound, nil
+}
+
+// decodePartial reads a partial key of the given number of nibbles, keys with an odd number of nibbles are padded
+// with a zero nibble at the start
+func decodePartial(r *bytes.Reader, nibbles int) ([]byte, error) {
+	b, err := readBytes(r, (nibbles+1)/2)
+	if err != nil {
+		return nil, err
+	}
+
+	padded := nibbles%2 == 1
+	if padded && b[0]&0xf0 != 0 {
+		return nil, errors.New("invalid padding of partial key")
+	}
+
+	partial := toNibbles(b)
+	if padded {
+		partial = partial[1:]
+	}
+
+	return partial, nil
+}
+
+func readCompactBytes(r *bytes.Reader) ([]byte, error) {
+	n, err := scale.NewDecoder(r).DecodeUintCompact()
+	if err != nil {
+		return nil, err
+	}
+
+	if !n.IsUint64() || n.Uint64() > uint64(r.Len()) {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	return readBytes(r, int(n.Uint64()))
+}
+
+func readBytes(r *bytes.Reader, n int) ([]byte, error) {
+	if n > r.Len() {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	b := make([]byte, n)
+	_, err := io.ReadFull(r, b)
+
+	return b, err
+}
+
+// toNibbles splits bytes into nibbles, the high nibble of a byte comes first
+func toNibbles(b []byte) []byte {
+	nibbles := make([]byte, 0, 2*len(b))
+	for _, c := range b {
+		nibbles = append(nibbles, c>>4, c&0x0f)
+	}
+
+	return nibbles
+}
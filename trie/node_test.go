@@ -0,0 +1,126 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trie
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// the encodings of the codec tests of sp-trie
+var (
+	// a leaf with the key 0xaa and the value 0xbb
+	singleLeaf = []byte{0x42, 0xaa, 0x04, 0xbb}
+	// a branch with the leaves 0x1314 => 0xff and 0x4819 => 0xfe in its slots 1 and 4
+	disjointBranch = []byte{
+		0x80, 0x12, 0x00,
+		0x14, 0x43, 0x03, 0x14, 0x04, 0xff,
+		0x14, 0x43, 0x08, 0x19, 0x04, 0xfe,
+	}
+)
+
+func TestDecodeNode_Leaf(t *testing.T) {
+	n, err := decodeNode(singleLeaf)
+	assert.NoError(t, err)
+	assert.Equal(t, leafNode, n.kind)
+	assert.Equal(t, []byte{0xa, 0xa}, n.partial)
+	assert.True(t, n.hasValue)
+	assert.False(t, n.hashedValue)
+	assert.Equal(t, []byte{0xbb}, n.value)
+}
+
+func TestDecodeNode_Branch(t *testing.T) {
+	n, err := decodeNode(disjointBranch)
+	assert.NoError(t, err)
+	assert.Equal(t, branchNode, n.kind)
+	assert.Empty(t, n.partial)
+	assert.False(t, n.hasValue)
+
+	for i, child := range n.children {
+		switch i {
+		case 1:
+			assert.Equal(t, []byte{0x43, 0x03, 0x14, 0x04, 0xff}, child)
+		case 4:
+			assert.Equal(t, []byte{0x43, 0x08, 0x19, 0x04, 0xfe}, child)
+		default:
+			assert.Nil(t, child)
+		}
+	}
+
+	leaf, err := decodeNode(n.children[1])
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{3, 1, 4}, leaf.partial)
+	assert.Equal(t, []byte{0xff}, leaf.value)
+}
+
+func TestDecodeNode_HashedValue(t *testing.T) {
+	hash := bytes.Repeat([]byte{1}, 32)
+
+	n, err := decodeNode(append([]byte{0x22, 0xaa}, hash...))
+	assert.NoError(t, err)
+	assert.Equal(t, leafNode, n.kind)
+	assert.Equal(t, []byte{0xa, 0xa}, n.partial)
+	assert.True(t, n.hashedValue)
+	assert.Equal(t, hash, n.value)
+
+	n, err = decodeNode(append(append([]byte{0x11, 0x0a, 0x01, 0x00}, hash...), singleLeafRef()...))
+	assert.NoError(t, err)
+	assert.Equal(t, branchNode, n.kind)
+	assert.Equal(t, []byte{0xa}, n.partial)
+	assert.True(t, n.hashedValue)
+	assert.Equal(t, hash, n.value)
+	assert.Equal(t, singleLeaf, n.children[0])
+}
+
+func singleLeafRef() []byte {
+	return append([]byte{byte(len(singleLeaf) << 2)}, singleLeaf...)
+}
+
+func TestDecodeNode_LongPartial(t *testing.T) {
+	for _, nibbles := range []int{62, 63, 64, 317, 318, 600} {
+		key := make([]byte, nibbles)
+		for i := range key {
+			key[i] = byte(i % 16)
+		}
+
+		enc := encodeLeaf(key, []byte{1}, false)
+
+		n, err := decodeNode(enc)
+		assert.NoError(t, err, "%d nibbles", nibbles)
+		assert.Equal(t, key, n.partial, "%d nibbles", nibbles)
+	}
+}
+
+func TestDecodeNode_Errors(t *testing.T) {
+	for name, enc := range map[string][]byte{
+		"empty":           nil,
+		"empty with data": {0x00, 0x01},
+		"invalid header":  {0x01},
+		"bad padding":     {0x41, 0x1a, 0x04, 0xbb},
+		"short partial":   {0x44, 0xaa},
+		"short value":     {0x42, 0xaa, 0x08, 0xbb},
+		"trailing bytes":  append(append([]byte{}, singleLeaf...), 0),
+		"no children":     {0x80, 0x00, 0x00},
+		"short child":     {0x80, 0x01, 0x00, 0x14, 0x43},
+		"short size":      {0x7f},
+	} {
+		_, err := decodeNode(enc)
+		assert.Error(t, err, name)
+	}
+}
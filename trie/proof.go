@@ -0,0 +1,215 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package trie verifies storage proofs of Substrate based chains against the state root of a block, so that storage
+// can be read from untrusted nodes. The state is stored in a base-16 Patricia-Merkle trie whose nodes are hashed with
+// blake2b-256, a proof is a set of encoded trie nodes, e.g. the proof of a types.ReadProof as returned by
+// state_getReadProof.
+//
+// Both state versions are supported. In state version V1, values that are longer than 32 bytes are not stored in the
+// trie nodes but referenced by their hash, such values are part of the proof as well.
+package trie
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"golang.org/x/crypto/blake2b"
+)
+
+var (
+	// ErrIncompleteProof is returned if a node or a value that is needed to read a key is missing from the proof
+	ErrIncompleteProof = errors.New("incomplete proof")
+	// ErrValueMismatch is returned if the value of a key differs from the value proven by the proof
+	ErrValueMismatch = errors.New("value does not match the proof")
+)
+
+// EmptyRoot is the root of an empty trie, the blake2b-256 hash of the encoded empty node
+var EmptyRoot = types.Hash(blake2b.Sum256([]byte{emptyTrie}))
+
+// Proof is a set of encoded trie nodes that proves storage values against a state root
+type Proof struct {
+	nodes map[types.Hash][]byte
+}
+
+// NewProof creates a proof from encoded trie nodes, e.g. from the proof of a types.ReadProof. The order of the nodes
+// does not matter and nodes that are not needed are ignored.
+func NewProof(nodes [][]byte) *Proof {
+	p := &Proof{nodes: make(map[types.Hash][]byte, len(nodes))}
+	for _, n := range nodes {
+		p.nodes[blake2b.Sum256(n)] = n
+	}
+
+	return p
+}
+
+// Read reads the value of a key from the trie with the given root. Ok is false if the proof proves that the key is
+// absent, ErrIncompleteProof is returned if the proof neither proves the value nor the absence of the key.
+func (p *Proof) Read(root types.Hash, key []byte) (value []byte, ok bool, err error) {
+	if root == EmptyRoot {
+		return nil, false, nil
+	}
+
+	encoded, ok := p.nodes[root]
+	if !ok {
+		return nil, false, fmt.Errorf("%w: root node %v not found", ErrIncompleteProof, root.Hex())
+	}
+
+	nibbles := toNibbles(key)
+
+	for {
+		n, err := decodeNode(encoded)
+		if err != nil {
+			return nil, false, fmt.Errorf("invalid trie node: %w", err)
+		}
+
+		if n.kind == emptyNode || !bytes.HasPrefix(nibbles, n.partial) {
+			return nil, false, nil
+		}
+
+		nibbles = nibbles[len(n.partial):]
+
+		if len(nibbles) == 0 {
+			if !n.hasValue {
+				return nil, false, nil
+			}
+
+			return p.nodeValue(n)
+		}
+
+		if n.kind == leafNode {
+			return nil, false, nil
+		}
+
+		child := n.children[nibbles[0]]
+		if child == nil {
+			return nil, false, nil
+		}
+
+		nibbles = nibbles[1:]
+
+		if len(child) < hashLength {
+			encoded = child
+			continue
+		}
+
+		encoded, ok = p.nodes[types.NewHash(child)]
+		if !ok {
+			return nil, false, fmt.Errorf("%w: node %#x not found", ErrIncompleteProof, child)
+		}
+	}
+}
+
+// nodeValue returns the value of a node, resolving values that are referenced by their hash
+func (p *Proof) nodeValue(n *node) ([]byte, bool, error) {
+	if !n.hashedValue {
+		return n.value, true, nil
+	}
+
+	value, ok := p.nodes[types.NewHash(n.value)]
+	if !ok {
+		return nil, false, fmt.Errorf("%w: value %#x not found", ErrIncompleteProof, n.value)
+	}
+
+	return value, true, nil
+}
+
+// ReadChild reads the value of a key from a child trie. The root of the child trie is read from the trie with the
+// given root first, childStorageKey is the prefixed key of the child trie, e.g. ":child_storage:default:" followed by
+// the ID of the child trie. Keys of a child trie that does not exist are absent.
+func (p *Proof) ReadChild(root types.Hash, childStorageKey types.StorageKey, key []byte) (value []byte, ok bool,
+	err error) {
+	childRoot, ok, err := p.childRoot(root, childStorageKey)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	return p.Read(childRoot, key)
+}
+
+func (p *Proof) childRoot(root types.Hash, childStorageKey types.StorageKey) (types.Hash, bool, error) {
+	childRoot, ok, err := p.Read(root, childStorageKey)
+	if err != nil || !ok {
+		return types.Hash{}, false, err
+	}
+
+	if len(childRoot) != hashLength {
+		return types.Hash{}, false, fmt.Errorf("invalid root of child trie %#x", []byte(childStorageKey))
+	}
+
+	return types.NewHash(childRoot), true, nil
+}
+
+// Verify checks that the items are proven against the trie with the given root: the value of items with storage
+// data has to match the proven value and items without storage data have to be proven absent. Such items are returned
+// by state_queryStorageAt, see types.KeyValueOption.
+func (p *Proof) Verify(root types.Hash, items ...types.KeyValueOption) error {
+	for _, item := range items {
+		value, ok, err := p.Read(root, item.StorageKey)
+		if err != nil {
+			return fmt.Errorf("unable to read key %#x: %w", []byte(item.StorageKey), err)
+		}
+
+		err = checkItem(item, value, ok)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// VerifyChild checks that the items are proven against the child trie, see Verify and ReadChild
+func (p *Proof) VerifyChild(root types.Hash, childStorageKey types.StorageKey, items ...types.KeyValueOption) error {
+	childRoot, exists, err := p.childRoot(root, childStorageKey)
+	if err != nil {
+		return fmt.Errorf("unable to read the root of child trie %#x: %w", []byte(childStorageKey), err)
+	}
+
+	if !exists {
+		childRoot = EmptyRoot
+	}
+
+	return p.Verify(childRoot, items...)
+}
+
+func checkItem(item types.KeyValueOption, value []byte, ok bool) error {
+	switch {
+	case item.HasStorageData && !ok:
+		return fmt.Errorf("%w: key %#x is absent", ErrValueMismatch, []byte(item.StorageKey))
+	case !item.HasStorageData && ok:
+		return fmt.Errorf("%w: key %#x is present", ErrValueMismatch, []byte(item.StorageKey))
+	case ok && !bytes.Equal(item.StorageData, value):
+		return fmt.Errorf("%w: key %#x has the value %#x", ErrValueMismatch, []byte(item.StorageKey), value)
+	default:
+		return nil
+	}
+}
+
+// VerifyReadProof checks that the items are proven by a read proof against a state root, e.g. the StateRoot of the
+// header of the block the proof was created at, see Proof.Verify
+func VerifyReadProof(stateRoot types.Hash, proof types.ReadProof, items ...types.KeyValueOption) error {
+	return NewProof(proof.Proof).Verify(stateRoot, items...)
+}
+
+// VerifyChildReadProof checks that the items are proven by a child read proof against a state root, see
+// Proof.VerifyChild
+func VerifyChildReadProof(stateRoot types.Hash, proof types.ReadProof, childStorageKey types.StorageKey,
+	items ...types.KeyValueOption) error {
+	return NewProof(proof.Proof).VerifyChild(stateRoot, childStorageKey, items...)
+}
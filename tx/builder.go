@@ -0,0 +1,323 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tx
+
+import (
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// Builder builds signed extrinsics for a call and a signer. Everything that is needed for signing is resolved via RPC
+// when Build is called, unless it is overridden:
+//   - the metadata via state_getMetadata
+//   - the genesis hash via chain_getBlockHash
+//   - the spec and transaction version via state_getRuntimeVersion
+//   - the nonce via system_accountNextIndex, or a NonceManager
+//   - a mortal era of DefaultMortalPeriod blocks, anchored on the latest finalized block
+type Builder struct {
+	api    *rpc.RPC
+	call   types.Call
+	signer signature.Signer
+
+	meta               *types.Metadata
+	genesisHash        *types.Hash
+	blockHash          *types.Hash
+	specVersion        *types.U32
+	transactionVersion *types.U32
+	nonce              *uint64
+	nonceManager       *NonceManager
+	era                *types.ExtrinsicEra
+	mortalPeriod       uint64
+	tip                types.UCompact
+	assetID            interface{}
+	metadataHash       *types.Hash
+}
+
+// NewBuilder creates a new Builder for the call, signed by the signer
+func NewBuilder(api *rpc.RPC, call types.Call, signer signature.Signer) *Builder {
+	return &Builder{
+		api:          api,
+		call:         call,
+		signer:       signer,
+		mortalPeriod: types.DefaultMortalPeriod,
+		tip:          types.NewUCompactFromUInt(0),
+	}
+}
+
+// WithMetadata sets the metadata, signed extensions are encoded according to metadata v14 and later
+func (b *Builder) WithMetadata(meta *types.Metadata) *Builder {
+	b.meta = meta
+	return b
+}
+
+// WithGenesisHash sets the genesis hash
+func (b *Builder) WithGenesisHash(genesisHash types.Hash) *Builder {
+	b.genesisHash = &genesisHash
+	return b
+}
+
+// WithBlockHash sets the hash of the block the era is anchored on. For mortal eras, it has to be the hash of the block
+// at which the era starts, for immortal eras it has to be the genesis hash.
+func (b *Builder) WithBlockHash(blockHash types.Hash) *Builder {
+	b.blockHash = &blockHash
+	return b
+}
+
+// WithSpecVersion sets the spec version of the runtime
+func (b *Builder) WithSpecVersion(specVersion types.U32) *Builder {
+	b.specVersion = &specVersion
+	return b
+}
+
+// WithTransactionVersion sets the transaction version of the runtime
+func (b *Builder) WithTransactionVersion(transactionVersion types.U32) *Builder {
+	b.transactionVersion = &transactionVersion
+	return b
+}
+
+// WithNonce sets the nonce, instead of retrieving the next nonce of the signer via system_accountNextIndex
+func (b *Builder) WithNonce(nonce uint64) *Builder {
+	b.nonce = &nonce
+	return b
+}
+
+// WithNonceManager sets a NonceManager that hands out the nonce, instead of retrieving the next nonce of the signer via
+// system_accountNextIndex. The manager has to belong to the signer. If Build fails, the nonce is reclaimed, if
+// submitting the extrinsic fails, it has to be reclaimed by the caller.
+func (b *Builder) WithNonceManager(m *NonceManager) *Builder {
+	b.nonceManager = m
+	return b
+}
+
+// WithEra sets the era. If no block hash is set, the block hash of a mortal era is resolved from the latest finalized
+// block.
+func (b *Builder) WithEra(era types.ExtrinsicEra) *Builder {
+	b.era = &era
+	return b
+}
+
+// WithMortalPeriod sets the period in blocks of the mortal era that is created for the latest finalized block
+func (b *Builder) WithMortalPeriod(period uint64) *Builder {
+	b.mortalPeriod = period
+	return b
+}
+
+// WithImmortalEra makes the extrinsic immortal. Immortal extrinsics can be replayed once the account of the signer
+// has been reaped and its nonce starts from zero again, use them with care.
+func (b *Builder) WithImmortalEra() *Builder {
+	return b.WithEra(types.ExtrinsicEra{IsImmortalEra: true})
+}
+
+// WithTip sets the tip that is paid to the block author
+func (b *Builder) WithTip(tip types.UCompact) *Builder {
+	b.tip = tip
+	return b
+}
+
+// WithAssetID sets the asset the fee is paid in, see types.SignatureOptions
+func (b *Builder) WithAssetID(assetID interface{}) *Builder {
+	b.assetID = assetID
+	return b
+}
+
+// WithMetadataHash enables the CheckMetadataHash signed extension with the given metadata hash
+func (b *Builder) WithMetadataHash(metadataHash types.Hash) *Builder {
+	b.metadataHash = &metadataHash
+	return b
+}
+
+// SignatureOptions resolves all options that are not overridden and returns the options used for signing
+func (b *Builder) SignatureOptions() (types.SignatureOptions, error) {
+	o := types.SignatureOptions{
+		Tip:          b.tip,
+		AssetID:      b.assetID,
+		MetadataHash: b.metadataHash,
+	}
+
+	if b.genesisHash != nil {
+		o.GenesisHash = *b.genesisHash
+	} else {
+		genesisHash, err := b.api.Chain.GetBlockHash(0)
+		if err != nil {
+			return types.SignatureOptions{}, fmt.Errorf("unable to get genesis hash: %w", err)
+		}
+		o.GenesisHash = genesisHash
+	}
+
+	if b.specVersion != nil && b.transactionVersion != nil {
+		o.SpecVersion = *b.specVersion
+		o.TransactionVersion = *b.transactionVersion
+	} else {
+		rv, err := b.api.State.GetRuntimeVersionLatest()
+		if err != nil {
+			return types.SignatureOptions{}, fmt.Errorf("unable to get runtime version: %w", err)
+		}
+
+		o.SpecVersion = rv.SpecVersion
+		if b.specVersion != nil {
+			o.SpecVersion = *b.specVersion
+		}
+
+		o.TransactionVersion = rv.TransactionVersion
+		if b.transactionVersion != nil {
+			o.TransactionVersion = *b.transactionVersion
+		}
+	}
+
+	err := b.resolveEra(&o)
+	if err != nil {
+		return types.SignatureOptions{}, err
+	}
+
+	// the nonce is resolved last, so that a nonce of a nonce manager is only taken if everything else succeeded
+	nonce, err := b.resolveNonce()
+	if err != nil {
+		return types.SignatureOptions{}, err
+	}
+	o.Nonce = types.NewUCompactFromUInt(nonce)
+
+	return o, nil
+}
+
+func (b *Builder) resolveNonce() (uint64, error) {
+	if b.nonce != nil {
+		return *b.nonce, nil
+	}
+
+	if b.nonceManager != nil {
+		return b.nonceManager.Next()
+	}
+
+	// the nonce of Ethereum accounts is queried with their hex encoded address
+	accountID := b.signer.Scheme().AccountID(b.signer.Public())
+	address := types.NewAccountID(accountID).String()
+	if b.signer.Scheme() == signature.SchemeEthereum {
+		address = types.NewAccountID20(accountID).String()
+	}
+
+	nonce, err := b.api.System.AccountNextIndex(address)
+	if err != nil {
+		return 0, fmt.Errorf("unable to get nonce of %v: %w", address, err)
+	}
+
+	return uint64(nonce), nil
+}
+
+// resolveEra sets the era and the block hash of the options
+func (b *Builder) resolveEra(o *types.SignatureOptions) error {
+	if b.era != nil && !b.era.IsMortalEra {
+		o.Era = types.ExtrinsicEra{IsImmortalEra: true}
+		o.BlockHash = o.GenesisHash
+		if b.blockHash != nil {
+			o.BlockHash = *b.blockHash
+		}
+		return nil
+	}
+
+	if b.era != nil && b.blockHash != nil {
+		o.Era = *b.era
+		o.BlockHash = *b.blockHash
+		return nil
+	}
+
+	finalizedHash, err := b.api.Chain.GetFinalizedHead()
+	if err != nil {
+		return fmt.Errorf("unable to get finalized head: %w", err)
+	}
+
+	header, err := b.api.Chain.GetHeader(finalizedHash)
+	if err != nil {
+		return fmt.Errorf("unable to get header of finalized head %v: %w", finalizedHash.Hex(), err)
+	}
+
+	current := uint64(header.Number)
+
+	if b.era == nil {
+		o.Era = types.NewMortalEra(b.mortalPeriod, current)
+	} else {
+		o.Era = *b.era
+	}
+
+	if b.blockHash != nil {
+		o.BlockHash = *b.blockHash
+		return nil
+	}
+
+	birth := o.Era.Birth(current)
+	if birth == current {
+		o.BlockHash = finalizedHash
+		return nil
+	}
+
+	if birth > current {
+		return fmt.Errorf("era starts at block %d, after the finalized block %d", birth, current)
+	}
+
+	o.BlockHash, err = b.api.Chain.GetBlockHash(birth)
+	if err != nil {
+		return fmt.Errorf("unable to get hash of block %d: %w", birth, err)
+	}
+
+	return nil
+}
+
+// Build resolves all options that are not overridden and returns the signed extrinsic. With metadata v14,
+// signed extensions are encoded according to the metadata, see types.Extrinsic.SignWithMetadata.
+func (b *Builder) Build() (types.Extrinsic, error) {
+	meta, err := b.metadata()
+	if err != nil {
+		return types.Extrinsic{}, err
+	}
+
+	o, err := b.SignatureOptions()
+	if err != nil {
+		return types.Extrinsic{}, err
+	}
+
+	ext := types.NewExtrinsic(b.call)
+
+	if meta.Version == 14 {
+		err = ext.SignWithMetadata(b.signer, meta, o)
+	} else {
+		err = ext.Sign(b.signer, o)
+	}
+	if err != nil {
+		if b.nonce == nil && b.nonceManager != nil {
+			b.nonceManager.Reclaim(uint64(o.Nonce.Int64()))
+		}
+		return types.Extrinsic{}, fmt.Errorf("unable to sign extrinsic: %w", err)
+	}
+
+	return ext, nil
+}
+
+// metadata returns the metadata of the builder, or the latest metadata if none is set
+func (b *Builder) metadata() (*types.Metadata, error) {
+	if b.meta != nil {
+		return b.meta, nil
+	}
+
+	meta, err := b.api.State.GetMetadataLatest()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get metadata: %w", err)
+	}
+
+	return meta, nil
+}
@@ -0,0 +1,215 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tx
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc"
+	chainMocks "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chain/mocks"
+	stateMocks "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state/mocks"
+	systemMocks "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/system/mocks"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testGenesisHash   = types.NewHash(types.MustHexDecodeString("0xdcd1346701ca8396496e52aa2785b1748deb6db09551b72159dcb3e08991025b"))
+	testFinalizedHash = types.NewHash(types.MustHexDecodeString("0xde8f69eeb5e065e18c6950ff708d7e551f68dc9bf59a07c52367c0280f805ec7"))
+	testBirthHash     = types.NewHash(types.MustHexDecodeString("0x1234567890123456789012345678901234567890123456789012345678901234"))
+)
+
+type testAPI struct {
+	rpc   *rpc.RPC
+	chain *chainMocks.Chain
+	state *stateMocks.State
+	sys   *systemMocks.System
+}
+
+func newTestAPI() testAPI {
+	chain := &chainMocks.Chain{}
+	state := &stateMocks.State{}
+	sys := &systemMocks.System{}
+
+	return testAPI{
+		rpc:   &rpc.RPC{Chain: chain, State: state, System: sys},
+		chain: chain,
+		state: state,
+		sys:   sys,
+	}
+}
+
+func decodeMetadata(t *testing.T) *types.Metadata {
+	var meta types.Metadata
+	err := types.DecodeFromHex(types.MetadataV14Data, &meta)
+	assert.NoError(t, err)
+	return &meta
+}
+
+func newTestSigner(t *testing.T) signature.Signer {
+	// ed25519 signatures are deterministic, which allows to compare extrinsics
+	signer, err := signature.NewKeyPairSigner(signature.SchemeEd25519, "//Alice")
+	assert.NoError(t, err)
+	return signer
+}
+
+func newTestCall(t *testing.T, meta *types.Metadata) types.Call {
+	bob, err := types.NewMultiAddressFromHexAccountID(
+		"0x8eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48")
+	assert.NoError(t, err)
+
+	c, err := types.NewCall(meta, "Balances.transfer", bob, types.NewUCompactFromUInt(12345))
+	assert.NoError(t, err)
+	return c
+}
+
+func expectedExtrinsic(t *testing.T, meta *types.Metadata, call types.Call, signer signature.Signer,
+	o types.SignatureOptions) types.Extrinsic {
+	ext := types.NewExtrinsic(call)
+	err := ext.SignWithMetadata(signer, meta, o)
+	assert.NoError(t, err)
+	return ext
+}
+
+func TestBuilder_Build(t *testing.T) {
+	api := newTestAPI()
+	meta := decodeMetadata(t)
+	signer := newTestSigner(t)
+	call := newTestCall(t, meta)
+
+	api.state.On("GetMetadataLatest").Return(meta, nil)
+	api.state.On("GetRuntimeVersionLatest").Return(&types.RuntimeVersion{SpecVersion: 268, TransactionVersion: 2}, nil)
+	api.chain.On("GetBlockHash", uint64(0)).Return(testGenesisHash, nil)
+	api.chain.On("GetFinalizedHead").Return(testFinalizedHash, nil)
+	api.chain.On("GetHeader", testFinalizedHash).Return(&types.Header{Number: 1000}, nil)
+	api.sys.On("AccountNextIndex", "5FA9nQDVg267DEd8m1ZypXLBnvN7SFxYwV7ndqSYGiN9TTpu").Return(types.U64(7), nil)
+
+	ext, err := NewBuilder(api.rpc, call, signer).Build()
+	assert.NoError(t, err)
+
+	o := types.SignatureOptions{
+		Era:                types.NewMortalEra(types.DefaultMortalPeriod, 1000),
+		Nonce:              types.NewUCompactFromUInt(7),
+		Tip:                types.NewUCompactFromUInt(0),
+		SpecVersion:        268,
+		GenesisHash:        testGenesisHash,
+		BlockHash:          testFinalizedHash,
+		TransactionVersion: 2,
+	}
+	assert.Equal(t, expectedExtrinsic(t, meta, call, signer, o), ext)
+	assert.Equal(t, uint64(1000), o.Era.Birth(1000))
+
+	api.state.AssertExpectations(t)
+	api.chain.AssertExpectations(t)
+	api.sys.AssertExpectations(t)
+}
+
+func TestBuilder_Build_Overrides(t *testing.T) {
+	api := newTestAPI()
+	meta := decodeMetadata(t)
+	signer := newTestSigner(t)
+	call := newTestCall(t, meta)
+	metadataHash := types.NewHash(types.MustHexDecodeString(
+		"0x0102030405060708090001020304050607080900010203040506070809000102"))
+
+	ext, err := NewBuilder(api.rpc, call, signer).
+		WithMetadata(meta).
+		WithGenesisHash(testGenesisHash).
+		WithSpecVersion(1).
+		WithTransactionVersion(3).
+		WithNonce(5).
+		WithImmortalEra().
+		WithTip(types.NewUCompactFromUInt(10)).
+		WithAssetID(types.NewU32(1)).
+		WithMetadataHash(metadataHash).
+		Build()
+	assert.NoError(t, err)
+
+	o := types.SignatureOptions{
+		Era:                types.ExtrinsicEra{IsImmortalEra: true},
+		Nonce:              types.NewUCompactFromUInt(5),
+		Tip:                types.NewUCompactFromUInt(10),
+		SpecVersion:        1,
+		GenesisHash:        testGenesisHash,
+		BlockHash:          testGenesisHash,
+		TransactionVersion: 3,
+		AssetID:            types.NewU32(1),
+		MetadataHash:       &metadataHash,
+	}
+	assert.Equal(t, expectedExtrinsic(t, meta, call, signer, o), ext)
+
+	// all options are overridden, no RPC calls are made
+	api.state.AssertExpectations(t)
+	api.chain.AssertExpectations(t)
+	api.sys.AssertExpectations(t)
+}
+
+func TestBuilder_SignatureOptions_Era(t *testing.T) {
+	api := newTestAPI()
+	signer := newTestSigner(t)
+
+	api.chain.On("GetFinalizedHead").Return(testFinalizedHash, nil)
+	api.chain.On("GetHeader", testFinalizedHash).Return(&types.Header{Number: 1000003}, nil)
+	api.chain.On("GetBlockHash", uint64(1000000)).Return(testBirthHash, nil)
+
+	b := NewBuilder(api.rpc, types.Call{}, signer).
+		WithGenesisHash(testGenesisHash).
+		WithSpecVersion(1).
+		WithTransactionVersion(1).
+		WithNonce(0)
+
+	// the phase of long periods is quantized, the era starts before the finalized block
+	o, err := b.WithMortalPeriod(65536).SignatureOptions()
+	assert.NoError(t, err)
+	assert.Equal(t, types.NewMortalEra(65536, 1000003), o.Era)
+	assert.Equal(t, testBirthHash, o.BlockHash)
+
+	// an era and a block hash are used as is
+	era := types.NewMortalEra(128, 42)
+	o, err = b.WithEra(era).WithBlockHash(testBirthHash).SignatureOptions()
+	assert.NoError(t, err)
+	assert.Equal(t, era, o.Era)
+	assert.Equal(t, testBirthHash, o.BlockHash)
+
+	api.chain.AssertExpectations(t)
+}
+
+func TestBuilder_Build_Errors(t *testing.T) {
+	api := newTestAPI()
+	meta := decodeMetadata(t)
+	signer := newTestSigner(t)
+
+	api.state.On("GetMetadataLatest").Return(nil, errors.New("metadata error")).Once()
+
+	_, err := NewBuilder(api.rpc, types.Call{}, signer).Build()
+	assert.EqualError(t, err, "unable to get metadata: metadata error")
+
+	api.chain.On("GetBlockHash", uint64(0)).Return(testGenesisHash, nil)
+	api.state.On("GetRuntimeVersionLatest").Return(&types.RuntimeVersion{SpecVersion: 268, TransactionVersion: 2}, nil)
+	api.sys.On("AccountNextIndex", "5FA9nQDVg267DEd8m1ZypXLBnvN7SFxYwV7ndqSYGiN9TTpu").
+		Return(types.U64(0), errors.New("nonce error"))
+
+	_, err = NewBuilder(api.rpc, types.Call{}, signer).WithMetadata(meta).WithImmortalEra().Build()
+	assert.EqualError(t, err, "unable to get nonce of 5FA9nQDVg267DEd8m1ZypXLBnvN7SFxYwV7ndqSYGiN9TTpu: nonce error")
+
+	api.chain.On("GetFinalizedHead").Return(types.Hash{}, errors.New("finalized head error"))
+
+	_, err = NewBuilder(api.rpc, types.Call{}, signer).WithMetadata(meta).WithNonce(1).Build()
+	assert.EqualError(t, err, "unable to get finalized head: finalized head error")
+}
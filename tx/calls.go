@@ -0,0 +1,187 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tx
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// NewBatchCall wraps the calls into Utility.batch, which dispatches the calls until the first one fails
+func NewBatchCall(meta *types.Metadata, calls ...types.Call) (types.Call, error) {
+	return newCall(meta, "Utility.batch", map[string]interface{}{"calls": calls})
+}
+
+// NewBatchAllCall wraps the calls into Utility.batch_all, which dispatches all calls or none of them
+func NewBatchAllCall(meta *types.Metadata, calls ...types.Call) (types.Call, error) {
+	return newCall(meta, "Utility.batch_all", map[string]interface{}{"calls": calls})
+}
+
+// NewSudoCall wraps the call into Sudo.sudo, which dispatches the call with the root origin
+func NewSudoCall(meta *types.Metadata, call types.Call) (types.Call, error) {
+	return newCall(meta, "Sudo.sudo", map[string]interface{}{"call": call})
+}
+
+// NewSudoUncheckedWeightCall wraps the call into Sudo.sudo_unchecked_weight, which dispatches the call with the root
+// origin and the given weight. Runtimes with a single dimensional weight only use the ref time of the weight.
+func NewSudoUncheckedWeightCall(meta *types.Metadata, call types.Call, weight types.WeightV2) (types.Call, error) {
+	return newCall(meta, "Sudo.sudo_unchecked_weight", map[string]interface{}{"call": call, "weight": weight})
+}
+
+// NewSudoAsCall wraps the call into Sudo.sudo_as, which dispatches the call with the signed origin of who
+func NewSudoAsCall(meta *types.Metadata, who types.AccountID, call types.Call) (types.Call, error) {
+	return newCall(meta, "Sudo.sudo_as", map[string]interface{}{"who": who, "call": call})
+}
+
+// NewProxyCall wraps the call into Proxy.proxy, which dispatches the call on behalf of real. forceProxyType is the
+// name of the proxy type to use, an empty string allows any proxy type of the signer.
+func NewProxyCall(meta *types.Metadata, real types.AccountID, forceProxyType string, call types.Call) (types.Call,
+	error) {
+	var proxyType interface{}
+	if forceProxyType != "" {
+		proxyType = forceProxyType
+	}
+
+	return newCall(meta, "Proxy.proxy", map[string]interface{}{
+		"real":             real,
+		"force_proxy_type": proxyType,
+		"call":             call,
+	})
+}
+
+// newCall creates a call with the given name, like types.NewCall, but the arguments are given by name and encoded
+// according to the types found in the metadata, see types.MetadataV14.EncodeValue. This allows the same arguments to
+// be used across runtimes:
+//   - arguments that are not part of the call are ignored, e.g. store_call of older Multisig pallets
+//   - a types.AccountID is wrapped into the Id variant of a MultiAddress
+//   - a types.WeightV2 only uses its ref time for runtimes with a single dimensional weight
+//   - a value that is not nil is wrapped into the Some variant of an Option
+//   - a types.Call is wrapped into a WrapperKeepOpaque
+func newCall(meta *types.Metadata, name string, args map[string]interface{}) (types.Call, error) {
+	if meta.Version != 14 {
+		return types.Call{}, fmt.Errorf("call wrappers are only supported from metadata v14, got v%d", meta.Version)
+	}
+
+	m := &meta.AsMetadataV14
+
+	callIndex, err := m.FindCallIndex(name)
+	if err != nil {
+		return types.Call{}, err
+	}
+
+	fields, err := callFields(m, callIndex)
+	if err != nil {
+		return types.Call{}, err
+	}
+
+	var buf bytes.Buffer
+	encoder := scale.NewEncoder(&buf)
+
+	for _, field := range fields {
+		value, ok := args[string(field.Name)]
+		if !ok {
+			return types.Call{}, fmt.Errorf("missing argument %v of call %v", field.Name, name)
+		}
+
+		value, err = adaptArg(m, field.Type.Int64(), value)
+		if err != nil {
+			return types.Call{}, fmt.Errorf("argument %v of call %v: %w", field.Name, name, err)
+		}
+
+		err = m.EncodeValue(*encoder, field.Type, value)
+		if err != nil {
+			return types.Call{}, fmt.Errorf("unable to encode argument %v of call %v: %w", field.Name, name, err)
+		}
+	}
+
+	return types.Call{CallIndex: callIndex, Args: buf.Bytes()}, nil
+}
+
+// callFields returns the fields of the call with the given index
+func callFields(m *types.MetadataV14, callIndex types.CallIndex) ([]types.Si1Field, error) {
+	for _, pallet := range m.Pallets {
+		if !pallet.HasCalls || uint8(pallet.Index) != callIndex.SectionIndex {
+			continue
+		}
+
+		typ, ok := m.EfficientLookup[pallet.Calls.Type.Int64()]
+		if !ok {
+			return nil, fmt.Errorf("call type of pallet %v not found in metadata", pallet.Name)
+		}
+
+		for _, variant := range typ.Def.Variant.Variants {
+			if uint8(variant.Index) == callIndex.MethodIndex {
+				return variant.Fields, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("call %v not found in metadata", callIndex)
+}
+
+// adaptArg adapts the value of an argument to the type of the argument, see newCall
+func adaptArg(m *types.MetadataV14, typeID int64, value interface{}) (interface{}, error) {
+	typ, ok := m.EfficientLookup[typeID]
+	if !ok {
+		return nil, fmt.Errorf("type %d not found in metadata", typeID)
+	}
+
+	def := typ.Def
+	path := typ.Path
+
+	switch {
+	case def.IsPrimitive || def.IsCompact:
+		if w, ok := value.(types.WeightV2); ok {
+			return uint64(w.RefTime), nil
+		}
+	case def.IsVariant && value != nil:
+		if _, ok := value.(types.DynamicVariant); ok {
+			return value, nil
+		}
+
+		if len(path) > 0 && path[len(path)-1] == "Option" {
+			for _, variant := range def.Variant.Variants {
+				if variant.Name != "Some" || len(variant.Fields) != 1 {
+					continue
+				}
+
+				inner, err := adaptArg(m, variant.Fields[0].Type.Int64(), value)
+				if err != nil {
+					return nil, err
+				}
+
+				return types.DynamicVariant{Name: "Some", Fields: types.DynamicComposite{{Value: inner}}}, nil
+			}
+		}
+
+		if accountID, ok := value.(types.AccountID); ok && len(path) > 0 && path[len(path)-1] == "MultiAddress" {
+			return types.NewMultiAddressFromAccountID(accountID[:]), nil
+		}
+	case def.IsComposite && len(path) > 0 && path[len(path)-1] == "WrapperKeepOpaque":
+		enc, err := types.Encode(value)
+		if err != nil {
+			return nil, err
+		}
+
+		return types.DynamicComposite{{Value: types.NewUCompactFromUInt(uint64(len(enc)))}, {Value: value}}, nil
+	}
+
+	return value, nil
+}
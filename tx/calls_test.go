@@ -0,0 +1,117 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tx
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func decodeTestCall(t *testing.T, meta *types.Metadata, call types.Call) types.DecodedCall {
+	decoded, err := types.DecodeCall(meta, call)
+	assert.NoError(t, err)
+	return decoded
+}
+
+func TestNewBatchCall(t *testing.T) {
+	meta := decodeMetadata(t)
+	transfer := newTestCall(t, meta)
+
+	batch, err := NewBatchCall(meta, transfer, transfer)
+	assert.NoError(t, err)
+
+	expected, err := types.NewCall(meta, "Utility.batch", []types.Call{transfer, transfer})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, batch)
+
+	batchAll, err := NewBatchAllCall(meta, transfer)
+	assert.NoError(t, err)
+
+	decoded := decodeTestCall(t, meta, batchAll)
+	assert.Equal(t, "batch_all", decoded.Name)
+	assert.Equal(t, types.DynamicComposite{{Name: "calls", Value: []interface{}{decodeTestCall(t, meta, transfer)}}},
+		decoded.Args)
+}
+
+func TestNewSudoCall(t *testing.T) {
+	meta := decodeMetadata(t)
+	transfer := newTestCall(t, meta)
+
+	sudo, err := NewSudoCall(meta, transfer)
+	assert.NoError(t, err)
+
+	expected, err := types.NewCall(meta, "Sudo.sudo", transfer)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, sudo)
+
+	// the runtime has a single dimensional weight, only the ref time is used
+	unchecked, err := NewSudoUncheckedWeightCall(meta, transfer, types.NewWeightV2(1000, 20))
+	assert.NoError(t, err)
+
+	expected, err = types.NewCall(meta, "Sudo.sudo_unchecked_weight", transfer, types.U64(1000))
+	assert.NoError(t, err)
+	assert.Equal(t, expected, unchecked)
+
+	alice := types.NewAccountID(signature.TestKeyringPairAlice.PublicKey)
+
+	sudoAs, err := NewSudoAsCall(meta, alice, transfer)
+	assert.NoError(t, err)
+
+	expected, err = types.NewCall(meta, "Sudo.sudo_as", types.NewMultiAddressFromAccountID(alice[:]), transfer)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, sudoAs)
+}
+
+func TestNewProxyCall(t *testing.T) {
+	meta := decodeMetadata(t)
+	transfer := newTestCall(t, meta)
+	alice := types.NewAccountID(signature.TestKeyringPairAlice.PublicKey)
+
+	proxy, err := NewProxyCall(meta, alice, "", transfer)
+	assert.NoError(t, err)
+
+	expected, err := types.NewCall(meta, "Proxy.proxy", alice, types.NewOptionBytesEmpty(), transfer)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, proxy)
+
+	proxy, err = NewProxyCall(meta, alice, "Any", transfer)
+	assert.NoError(t, err)
+
+	forceProxyType, ok := decodeTestCall(t, meta, proxy).Arg("force_proxy_type")
+	assert.True(t, ok)
+	assert.Equal(t, types.DynamicVariant{Name: "Some", Fields: types.DynamicComposite{
+		{Value: types.DynamicVariant{Name: "Any", Fields: types.DynamicComposite{}}}}}, forceProxyType)
+
+	_, err = NewProxyCall(meta, alice, "Unknown", transfer)
+	assert.Error(t, err)
+}
+
+func TestNewCall_Errors(t *testing.T) {
+	meta := decodeMetadata(t)
+
+	_, err := newCall(meta, "Sudo.sudo", map[string]interface{}{})
+	assert.EqualError(t, err, "missing argument call of call Sudo.sudo")
+
+	_, err = newCall(meta, "Unknown.call", map[string]interface{}{})
+	assert.Error(t, err)
+
+	_, err = NewSudoCall(&types.Metadata{Version: 13}, types.Call{})
+	assert.EqualError(t, err, "call wrappers are only supported from metadata v14, got v13")
+}
@@ -0,0 +1,224 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tx
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"golang.org/x/crypto/blake2b"
+)
+
+// Timepoint is the block height and the extrinsic index of the first approval of a multisig operation
+type Timepoint struct {
+	Height types.U32
+	Index  types.U32
+}
+
+// MultisigOperation is an operation of a multisig account that is pending approval, as stored in Multisig.Multisigs
+type MultisigOperation struct {
+	When      Timepoint
+	Deposit   types.U128
+	Depositor types.AccountID
+	Approvals []types.AccountID
+}
+
+// CallHash returns the blake2b-256 hash of the encoded call, which identifies the call in multisig operations
+func CallHash(call types.Call) (types.Hash, error) {
+	enc, err := types.Encode(call)
+	if err != nil {
+		return types.Hash{}, err
+	}
+
+	return blake2b.Sum256(enc), nil
+}
+
+// NewAsMultiCall creates a Multisig.as_multi call that approves the call and dispatches it if the threshold is
+// reached. The timepoint is nil for the first approval. The other signatories are sorted as required by the pallet.
+func NewAsMultiCall(meta *types.Metadata, threshold uint16, otherSignatories []types.AccountID, timepoint *Timepoint,
+	call types.Call, maxWeight types.WeightV2) (types.Call, error) {
+	return newCall(meta, "Multisig.as_multi", map[string]interface{}{
+		"threshold":         threshold,
+		"other_signatories": sortedAccountIDs(otherSignatories),
+		"maybe_timepoint":   timepointValue(timepoint),
+		"call":              call,
+		"store_call":        false,
+		"max_weight":        maxWeight,
+	})
+}
+
+// NewApproveAsMultiCall creates a Multisig.approve_as_multi call that approves the call with the given hash without
+// dispatching it. The timepoint is nil for the first approval.
+func NewApproveAsMultiCall(meta *types.Metadata, threshold uint16, otherSignatories []types.AccountID,
+	timepoint *Timepoint, callHash types.Hash, maxWeight types.WeightV2) (types.Call, error) {
+	return newCall(meta, "Multisig.approve_as_multi", map[string]interface{}{
+		"threshold":         threshold,
+		"other_signatories": sortedAccountIDs(otherSignatories),
+		"maybe_timepoint":   timepointValue(timepoint),
+		"call_hash":         callHash,
+		"max_weight":        maxWeight,
+	})
+}
+
+// NewAsMultiThreshold1Call creates a Multisig.as_multi_threshold_1 call that dispatches the call from a multisig
+// account with a threshold of 1
+func NewAsMultiThreshold1Call(meta *types.Metadata, otherSignatories []types.AccountID, call types.Call) (types.Call,
+	error) {
+	return newCall(meta, "Multisig.as_multi_threshold_1", map[string]interface{}{
+		"other_signatories": sortedAccountIDs(otherSignatories),
+		"call":              call,
+	})
+}
+
+func timepointValue(timepoint *Timepoint) interface{} {
+	if timepoint == nil {
+		return nil
+	}
+
+	return *timepoint
+}
+
+func sortedAccountIDs(accountIDs []types.AccountID) []types.AccountID {
+	res := make([]types.AccountID, len(accountIDs))
+	copy(res, accountIDs)
+
+	sort.Slice(res, func(i, j int) bool {
+		return bytes.Compare(res[i][:], res[j][:]) < 0
+	})
+
+	return res
+}
+
+// Multisig is a multisig account of a set of signatories and a threshold. It creates the calls that approve the
+// operations of the account, based on the pending operations found in the storage of the Multisig pallet.
+type Multisig struct {
+	api         *rpc.RPC
+	meta        *types.Metadata
+	threshold   uint16
+	signatories []types.AccountID
+	accountID   types.AccountID
+}
+
+// NewMultisig creates a new Multisig for the signatories and the threshold
+func NewMultisig(api *rpc.RPC, meta *types.Metadata, threshold uint16, signatories []types.AccountID) (*Multisig,
+	error) {
+	if threshold == 0 || int(threshold) > len(signatories) {
+		return nil, fmt.Errorf("invalid threshold %d for %d signatories", threshold, len(signatories))
+	}
+
+	sorted := sortedAccountIDs(signatories)
+
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i] == sorted[i-1] {
+			return nil, fmt.Errorf("duplicate signatory %v", sorted[i])
+		}
+	}
+
+	return &Multisig{
+		api:         api,
+		meta:        meta,
+		threshold:   threshold,
+		signatories: sorted,
+		accountID:   types.NewMultisigAccountID(sorted, threshold),
+	}, nil
+}
+
+// AccountID returns the account ID of the multisig account
+func (m *Multisig) AccountID() types.AccountID {
+	return m.accountID
+}
+
+// Operation returns the pending operation of the call with the given hash, or nil if there is none
+func (m *Multisig) Operation(callHash types.Hash) (*MultisigOperation, error) {
+	key, err := types.CreateStorageKey(m.meta, "Multisig", "Multisigs", m.accountID[:], callHash[:])
+	if err != nil {
+		return nil, err
+	}
+
+	var op MultisigOperation
+
+	ok, err := m.api.State.GetStorageLatest(key, &op)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get multisig operation %v: %w", callHash.Hex(), err)
+	}
+
+	if !ok {
+		return nil, nil
+	}
+
+	return &op, nil
+}
+
+// ApproveCall returns the call with which the signatory approves the call. The approval that reaches the threshold
+// uses Multisig.as_multi to dispatch the call with the given max weight, all other approvals use
+// Multisig.approve_as_multi with the call hash only. The timepoint of a pending operation is taken from the storage.
+func (m *Multisig) ApproveCall(signatory types.AccountID, call types.Call, maxWeight types.WeightV2) (types.Call,
+	error) {
+	others := make([]types.AccountID, 0, len(m.signatories))
+	found := false
+
+	for _, s := range m.signatories {
+		if s == signatory {
+			found = true
+			continue
+		}
+
+		others = append(others, s)
+	}
+
+	if !found {
+		return types.Call{}, errors.New("account is not a signatory of the multisig account")
+	}
+
+	if m.threshold == 1 {
+		return NewAsMultiThreshold1Call(m.meta, others, call)
+	}
+
+	callHash, err := CallHash(call)
+	if err != nil {
+		return types.Call{}, err
+	}
+
+	op, err := m.Operation(callHash)
+	if err != nil {
+		return types.Call{}, err
+	}
+
+	var timepoint *Timepoint
+	approvals := 0
+
+	if op != nil {
+		for _, a := range op.Approvals {
+			if a == signatory {
+				return types.Call{}, fmt.Errorf("operation %v is already approved by the signatory", callHash.Hex())
+			}
+		}
+
+		timepoint = &op.When
+		approvals = len(op.Approvals)
+	}
+
+	if approvals+1 >= int(m.threshold) {
+		return NewAsMultiCall(m.meta, m.threshold, others, timepoint, call, maxWeight)
+	}
+
+	return NewApproveAsMultiCall(m.meta, m.threshold, others, timepoint, callHash, maxWeight)
+}
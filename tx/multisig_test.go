@@ -0,0 +1,194 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tx
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/blake2b"
+)
+
+var (
+	testAlice   = types.NewAccountID(signature.TestKeyringPairAlice.PublicKey)
+	testBob     = types.NewAccountID(signature.TestKeyringPairBob.PublicKey)
+	testCharlie = types.NewAccountID(signature.TestKeyringPairCharlie.PublicKey)
+)
+
+func TestCallHash(t *testing.T) {
+	meta := decodeMetadata(t)
+	call := newTestCall(t, meta)
+
+	h, err := CallHash(call)
+	assert.NoError(t, err)
+
+	enc, err := types.Encode(call)
+	assert.NoError(t, err)
+	assert.Equal(t, types.Hash(blake2b.Sum256(enc)), h)
+}
+
+func TestNewMultisig(t *testing.T) {
+	meta := decodeMetadata(t)
+
+	m, err := NewMultisig(nil, meta, 2, []types.AccountID{testCharlie, testAlice, testBob})
+	assert.NoError(t, err)
+	assert.Equal(t, "5DjYJStmdZ2rcqXbXGX7TW85JsrW6uG4y9MUcLq2BoPMpRA7", m.AccountID().String())
+
+	// the order of the signatories does not matter
+	other, err := NewMultisig(nil, meta, 2, []types.AccountID{testBob, testCharlie, testAlice})
+	assert.NoError(t, err)
+	assert.Equal(t, m.AccountID(), other.AccountID())
+
+	_, err = NewMultisig(nil, meta, 4, []types.AccountID{testCharlie, testAlice, testBob})
+	assert.EqualError(t, err, "invalid threshold 4 for 3 signatories")
+
+	_, err = NewMultisig(nil, meta, 0, []types.AccountID{testCharlie, testAlice, testBob})
+	assert.EqualError(t, err, "invalid threshold 0 for 3 signatories")
+
+	_, err = NewMultisig(nil, meta, 1, []types.AccountID{testAlice, testAlice})
+	assert.Error(t, err)
+}
+
+func mockMultisigOperation(api testAPI, m *Multisig, callHash types.Hash, op *MultisigOperation) {
+	key, err := types.CreateStorageKey(m.meta, "Multisig", "Multisigs", m.accountID[:], callHash[:])
+	if err != nil {
+		panic(err)
+	}
+
+	api.state.On("GetStorageLatest", key, mock.Anything).Return(func(_ types.StorageKey, target interface{}) bool {
+		if op == nil {
+			return false
+		}
+		*target.(*MultisigOperation) = *op
+		return true
+	}, nil).Once()
+}
+
+func TestMultisig_ApproveCall(t *testing.T) {
+	api := newTestAPI()
+	meta := decodeMetadata(t)
+	call := newTestCall(t, meta)
+	weight := types.NewWeightV2(1000, 0)
+
+	callHash, err := CallHash(call)
+	assert.NoError(t, err)
+
+	m, err := NewMultisig(api.rpc, meta, 3, []types.AccountID{testAlice, testBob, testCharlie})
+	assert.NoError(t, err)
+
+	// the first approval only approves the call hash
+	mockMultisigOperation(api, m, callHash, nil)
+
+	approve, err := m.ApproveCall(testAlice, call, weight)
+	assert.NoError(t, err)
+
+	expected, err := NewApproveAsMultiCall(meta, 3, []types.AccountID{testCharlie, testBob}, nil, callHash, weight)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, approve)
+
+	decoded := decodeTestCall(t, meta, approve)
+	assert.Equal(t, "approve_as_multi", decoded.Name)
+
+	timepoint, ok := decoded.Arg("maybe_timepoint")
+	assert.True(t, ok)
+	assert.Equal(t, types.DynamicVariant{Name: "None", Fields: types.DynamicComposite{}}, timepoint)
+
+	// the second approval takes the timepoint of the pending operation
+	op := &MultisigOperation{When: Timepoint{Height: 10, Index: 2}, Depositor: testAlice,
+		Approvals: []types.AccountID{testAlice}}
+	mockMultisigOperation(api, m, callHash, op)
+
+	approve, err = m.ApproveCall(testBob, call, weight)
+	assert.NoError(t, err)
+
+	expected, err = NewApproveAsMultiCall(meta, 3, []types.AccountID{testAlice, testCharlie}, &op.When, callHash, weight)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, approve)
+
+	timepoint, ok = decodeTestCall(t, meta, approve).Arg("maybe_timepoint")
+	assert.True(t, ok)
+	assert.Equal(t, types.DynamicVariant{Name: "Some", Fields: types.DynamicComposite{{Value: types.DynamicComposite{
+		{Name: "height", Value: uint32(10)}, {Name: "index", Value: uint32(2)}}}}}, timepoint)
+
+	// the last approval dispatches the call
+	op.Approvals = []types.AccountID{testAlice, testBob}
+	mockMultisigOperation(api, m, callHash, op)
+
+	asMulti, err := m.ApproveCall(testCharlie, call, weight)
+	assert.NoError(t, err)
+
+	decoded = decodeTestCall(t, meta, asMulti)
+	assert.Equal(t, "as_multi", decoded.Name)
+
+	inner, ok := decoded.Arg("call")
+	assert.True(t, ok)
+	assert.Equal(t, types.DynamicComposite{{Value: types.NewUCompactFromUInt(uint64(len(call.Args) + 2))},
+		{Value: decodeTestCall(t, meta, call)}}, inner)
+
+	maxWeight, ok := decoded.Arg("max_weight")
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1000), maxWeight)
+
+	// a signatory can't approve twice
+	mockMultisigOperation(api, m, callHash, op)
+
+	_, err = m.ApproveCall(testBob, call, weight)
+	assert.EqualError(t, err, "operation "+callHash.Hex()+" is already approved by the signatory")
+
+	api.state.AssertExpectations(t)
+}
+
+func TestMultisig_ApproveCall_Threshold1(t *testing.T) {
+	api := newTestAPI()
+	meta := decodeMetadata(t)
+	call := newTestCall(t, meta)
+
+	m, err := NewMultisig(api.rpc, meta, 1, []types.AccountID{testAlice, testBob})
+	assert.NoError(t, err)
+
+	res, err := m.ApproveCall(testBob, call, types.WeightV2{})
+	assert.NoError(t, err)
+
+	expected, err := types.NewCall(meta, "Multisig.as_multi_threshold_1", []types.AccountID{testAlice}, call)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, res)
+
+	// no storage is queried
+	api.state.AssertExpectations(t)
+}
+
+func TestMultisig_ApproveCall_Errors(t *testing.T) {
+	api := newTestAPI()
+	meta := decodeMetadata(t)
+	call := newTestCall(t, meta)
+
+	m, err := NewMultisig(api.rpc, meta, 2, []types.AccountID{testAlice, testBob})
+	assert.NoError(t, err)
+
+	_, err = m.ApproveCall(testCharlie, call, types.WeightV2{})
+	assert.EqualError(t, err, "account is not a signatory of the multisig account")
+
+	api.state.On("GetStorageLatest", mock.Anything, mock.Anything).Return(false, errors.New("storage error")).Once()
+
+	_, err = m.ApproveCall(testAlice, call, types.WeightV2{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "storage error")
+}
@@ -0,0 +1,241 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tx
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// NonceSource retrieves the next nonce of an account, taking the transaction pool into account, e.g. system.System
+type NonceSource interface {
+	AccountNextIndex(address string) (types.U64, error)
+}
+
+// NonceState is the persistable state of a NonceManager
+type NonceState struct {
+	GenesisHash types.Hash `json:"genesisHash"`
+	Address     string     `json:"address"`
+	Next        uint64     `json:"next"`
+	Reclaimed   []uint64   `json:"reclaimed,omitempty"`
+}
+
+// NonceManager hands out nonces of an account on a chain, it is safe for concurrent use. The manager is seeded via
+// system_accountNextIndex when the first nonce is requested, after that nonces are handed out without RPC calls. Nonces
+// of extrinsics that did not make it into a block have to be returned with Reclaim or HandleStatus, otherwise all
+// extrinsics with higher nonces are stuck in the future queue of the transaction pool. Nonces that have been handed out
+// are pending until they are reclaimed, reported to HandleStatus as included or used on chain. If no nonce is pending
+// when the manager is resynced, the next nonce is reset to the one on chain, which closes gaps left by a restart or by
+// extrinsics that have been dropped without notice.
+type NonceManager struct {
+	source      NonceSource
+	genesisHash types.Hash
+	address     string
+
+	mu        sync.Mutex
+	seeded    bool
+	next      uint64
+	reclaimed []uint64
+	pending   map[uint64]struct{}
+}
+
+// NewNonceManager creates a new NonceManager for the account with the given SS58 address on the chain with the given
+// genesis hash
+func NewNonceManager(source NonceSource, genesisHash types.Hash, address string) *NonceManager {
+	return &NonceManager{
+		source:      source,
+		genesisHash: genesisHash,
+		address:     address,
+		pending:     make(map[uint64]struct{}),
+	}
+}
+
+// NewNonceManagerFromState creates a NonceManager from a state that has been persisted before. The manager is seeded
+// again when the first nonce is requested. Since no nonce is pending after a restart, the next nonce on chain is used
+// if it differs from the persisted one, reclaimed nonces below it are dropped.
+func NewNonceManagerFromState(source NonceSource, state NonceState) *NonceManager {
+	m := NewNonceManager(source, state.GenesisHash, state.Address)
+	m.next = state.Next
+	m.reclaimed = append([]uint64(nil), state.Reclaimed...)
+	sort.Slice(m.reclaimed, func(i, j int) bool { return m.reclaimed[i] < m.reclaimed[j] })
+	return m
+}
+
+// GenesisHash returns the genesis hash of the chain of the manager
+func (m *NonceManager) GenesisHash() types.Hash {
+	return m.genesisHash
+}
+
+// Address returns the SS58 address of the account of the manager
+func (m *NonceManager) Address() string {
+	return m.address
+}
+
+// Next returns the next nonce, reclaimed nonces are handed out first
+func (m *NonceManager) Next() (uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.seeded {
+		err := m.resync()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if len(m.reclaimed) > 0 {
+		nonce := m.reclaimed[0]
+		m.reclaimed = m.reclaimed[1:]
+		m.pending[nonce] = struct{}{}
+		return nonce, nil
+	}
+
+	nonce := m.next
+	m.next++
+	m.pending[nonce] = struct{}{}
+
+	return nonce, nil
+}
+
+// Reclaim returns a nonce that has been handed out but has not been used, e.g. because submitting the extrinsic
+// failed. The nonce is handed out again before any new nonce.
+func (m *NonceManager) Reclaim(nonce uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.reclaim(nonce)
+}
+
+// Resync updates the manager with the next nonce of the account on chain. Reclaimed nonces that have been used in the
+// meantime are dropped. If no nonce is pending, the next nonce is reset to the one on chain.
+func (m *NonceManager) Resync() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.resync()
+}
+
+// HandleStatus updates the manager according to a status of an extrinsic with the given nonce, see
+// author.SubmitAndWatchExtrinsic. If the extrinsic is Invalid or Dropped, the manager is resynced and the nonce is
+// reclaimed unless it has been used on chain in the meantime. If the extrinsic is Usurped, another extrinsic with the
+// same nonce has been included and the manager is resynced. If the extrinsic is InBlock or Finalized, the nonce is no
+// longer pending. Other statuses are ignored.
+func (m *NonceManager) HandleStatus(nonce uint64, status types.ExtrinsicStatus) error {
+	if !status.IsInvalid && !status.IsDropped && !status.IsUsurped && !status.IsInBlock && !status.IsFinalized {
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.pending, nonce)
+
+	if status.IsInBlock || status.IsFinalized {
+		return nil
+	}
+
+	onChain, err := m.fetch()
+	if err != nil {
+		return err
+	}
+
+	m.update(onChain)
+
+	if !status.IsUsurped && nonce >= onChain {
+		m.reclaim(nonce)
+	}
+
+	return nil
+}
+
+// State returns the current state of the manager, which can be persisted and restored with NewNonceManagerFromState
+func (m *NonceManager) State() NonceState {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return NonceState{
+		GenesisHash: m.genesisHash,
+		Address:     m.address,
+		Next:        m.next,
+		Reclaimed:   append([]uint64(nil), m.reclaimed...),
+	}
+}
+
+func (m *NonceManager) fetch() (uint64, error) {
+	n, err := m.source.AccountNextIndex(m.address)
+	if err != nil {
+		return 0, fmt.Errorf("unable to get next nonce of %v: %w", m.address, err)
+	}
+
+	return uint64(n), nil
+}
+
+func (m *NonceManager) resync() error {
+	onChain, err := m.fetch()
+	if err != nil {
+		return err
+	}
+
+	m.update(onChain)
+
+	return nil
+}
+
+// update drops all reclaimed and pending nonces below the next nonce on chain and makes sure that the next nonce that
+// is handed out is not below it. If no nonce is pending, the nonces between the one on chain and the next one have not
+// been used and the next nonce is reset to the one on chain.
+func (m *NonceManager) update(onChain uint64) {
+	for nonce := range m.pending {
+		if nonce < onChain {
+			delete(m.pending, nonce)
+		}
+	}
+
+	if len(m.pending) == 0 && m.next > onChain {
+		m.next = onChain
+		m.reclaimed = nil
+	}
+
+	i := sort.Search(len(m.reclaimed), func(i int) bool { return m.reclaimed[i] >= onChain })
+	m.reclaimed = m.reclaimed[i:]
+
+	if m.next < onChain {
+		m.next = onChain
+	}
+
+	m.seeded = true
+}
+
+func (m *NonceManager) reclaim(nonce uint64) {
+	delete(m.pending, nonce)
+
+	if nonce >= m.next {
+		return
+	}
+
+	i := sort.Search(len(m.reclaimed), func(i int) bool { return m.reclaimed[i] >= nonce })
+	if i < len(m.reclaimed) && m.reclaimed[i] == nonce {
+		return
+	}
+
+	m.reclaimed = append(m.reclaimed, 0)
+	copy(m.reclaimed[i+1:], m.reclaimed[i:])
+	m.reclaimed[i] = nonce
+}
@@ -0,0 +1,302 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tx
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+
+	systemMocks "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/system/mocks"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+const testAddress = "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"
+
+func newTestNonceManager(onChain uint64) (*NonceManager, *systemMocks.System) {
+	source := &systemMocks.System{}
+	source.On("AccountNextIndex", testAddress).Return(types.U64(onChain), nil).Once()
+	return NewNonceManager(source, testGenesisHash, testAddress), source
+}
+
+func TestNonceManager_Next(t *testing.T) {
+	m, source := newTestNonceManager(10)
+
+	for i := uint64(10); i < 20; i++ {
+		nonce, err := m.Next()
+		assert.NoError(t, err)
+		assert.Equal(t, i, nonce)
+	}
+
+	// the manager is only seeded once
+	source.AssertNumberOfCalls(t, "AccountNextIndex", 1)
+	assert.Equal(t, testGenesisHash, m.GenesisHash())
+	assert.Equal(t, testAddress, m.Address())
+}
+
+func TestNonceManager_Next_Concurrent(t *testing.T) {
+	m, _ := newTestNonceManager(0)
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		nonces = make(map[uint64]bool)
+	)
+
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			nonce, err := m.Next()
+			assert.NoError(t, err)
+
+			mu.Lock()
+			defer mu.Unlock()
+			nonces[nonce] = true
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Len(t, nonces, 100)
+	for i := uint64(0); i < 100; i++ {
+		assert.True(t, nonces[i])
+	}
+}
+
+func TestNonceManager_Next_Error(t *testing.T) {
+	source := &systemMocks.System{}
+	source.On("AccountNextIndex", testAddress).Return(types.U64(0), errors.New("rpc error"))
+	m := NewNonceManager(source, testGenesisHash, testAddress)
+
+	_, err := m.Next()
+	assert.EqualError(t, err, "unable to get next nonce of "+testAddress+": rpc error")
+}
+
+func TestNonceManager_Reclaim(t *testing.T) {
+	m, _ := newTestNonceManager(0)
+
+	for i := 0; i < 5; i++ {
+		_, err := m.Next()
+		assert.NoError(t, err)
+	}
+
+	m.Reclaim(3)
+	m.Reclaim(1)
+	m.Reclaim(3)
+	// nonces that have not been handed out are ignored
+	m.Reclaim(7)
+
+	for _, exp := range []uint64{1, 3, 5, 6} {
+		nonce, err := m.Next()
+		assert.NoError(t, err)
+		assert.Equal(t, exp, nonce)
+	}
+}
+
+func TestNonceManager_HandleStatus(t *testing.T) {
+	m, source := newTestNonceManager(0)
+
+	for i := 0; i < 5; i++ {
+		_, err := m.Next()
+		assert.NoError(t, err)
+	}
+
+	// statuses other than Invalid, Dropped and Usurped are ignored
+	err := m.HandleStatus(0, types.ExtrinsicStatus{IsInBlock: true})
+	assert.NoError(t, err)
+	source.AssertNumberOfCalls(t, "AccountNextIndex", 1)
+
+	// nonce 2 has been dropped, nonce 0 and 1 have been included
+	source.On("AccountNextIndex", testAddress).Return(types.U64(2), nil).Once()
+	err = m.HandleStatus(2, types.ExtrinsicStatus{IsDropped: true})
+	assert.NoError(t, err)
+
+	// nonce 1 is invalid, but it has been used on chain in the meantime, so it's not reclaimed
+	source.On("AccountNextIndex", testAddress).Return(types.U64(2), nil).Once()
+	err = m.HandleStatus(1, types.ExtrinsicStatus{IsInvalid: true})
+	assert.NoError(t, err)
+
+	nonce, err := m.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), nonce)
+
+	// nonce 3 has been usurped, another extrinsic of the account has been included, the chain is ahead
+	source.On("AccountNextIndex", testAddress).Return(types.U64(8), nil).Once()
+	err = m.HandleStatus(3, types.ExtrinsicStatus{IsUsurped: true})
+	assert.NoError(t, err)
+
+	nonce, err = m.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(8), nonce)
+
+	source.On("AccountNextIndex", testAddress).Return(types.U64(0), errors.New("rpc error")).Once()
+	err = m.HandleStatus(8, types.ExtrinsicStatus{IsInvalid: true})
+	assert.EqualError(t, err, "unable to get next nonce of "+testAddress+": rpc error")
+}
+
+func TestNonceManager_Resync(t *testing.T) {
+	m, source := newTestNonceManager(0)
+
+	for i := 0; i < 5; i++ {
+		_, err := m.Next()
+		assert.NoError(t, err)
+	}
+	m.Reclaim(1)
+	m.Reclaim(4)
+
+	source.On("AccountNextIndex", testAddress).Return(types.U64(3), nil).Once()
+	err := m.Resync()
+	assert.NoError(t, err)
+
+	assert.Equal(t, NonceState{GenesisHash: testGenesisHash, Address: testAddress, Next: 5, Reclaimed: []uint64{4}},
+		m.State())
+}
+
+func TestNonceManager_State(t *testing.T) {
+	m, _ := newTestNonceManager(10)
+
+	for i := 0; i < 5; i++ {
+		_, err := m.Next()
+		assert.NoError(t, err)
+	}
+	m.Reclaim(12)
+
+	b, err := json.Marshal(m.State())
+	assert.NoError(t, err)
+
+	var state NonceState
+	err = json.Unmarshal(b, &state)
+	assert.NoError(t, err)
+	assert.Equal(t, m.State(), state)
+
+	// the persisted state is kept if it matches the chain
+	source := &systemMocks.System{}
+	source.On("AccountNextIndex", testAddress).Return(types.U64(15), nil).Once()
+	restored := NewNonceManagerFromState(source, NonceState{GenesisHash: testGenesisHash, Address: testAddress,
+		Next: 15})
+
+	nonce, err := restored.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(15), nonce)
+
+	// the persisted state is updated if the chain is ahead of it
+	source = &systemMocks.System{}
+	source.On("AccountNextIndex", testAddress).Return(types.U64(20), nil).Once()
+	restored = NewNonceManagerFromState(source, state)
+
+	nonce, err = restored.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(20), nonce)
+}
+
+func TestNonceManager_State_AheadOfChain(t *testing.T) {
+	// nonces 11 to 14 have been handed out before a restart, but never made it into the pool
+	state := NonceState{GenesisHash: testGenesisHash, Address: testAddress, Next: 15, Reclaimed: []uint64{12}}
+
+	source := &systemMocks.System{}
+	source.On("AccountNextIndex", testAddress).Return(types.U64(11), nil).Once()
+	restored := NewNonceManagerFromState(source, state)
+
+	for _, exp := range []uint64{11, 12, 13} {
+		nonce, err := restored.Next()
+		assert.NoError(t, err)
+		assert.Equal(t, exp, nonce)
+	}
+
+	assert.Equal(t, NonceState{GenesisHash: testGenesisHash, Address: testAddress, Next: 14}, restored.State())
+}
+
+func TestNonceManager_Resync_Gap(t *testing.T) {
+	m, source := newTestNonceManager(0)
+
+	for i := 0; i < 4; i++ {
+		_, err := m.Next()
+		assert.NoError(t, err)
+	}
+
+	// nonce 0 has been included, submitting nonce 2 failed
+	err := m.HandleStatus(0, types.ExtrinsicStatus{IsInBlock: true})
+	assert.NoError(t, err)
+	m.Reclaim(2)
+
+	// nonce 3 has been dropped, the next nonce is kept while nonce 1 is pending
+	source.On("AccountNextIndex", testAddress).Return(types.U64(1), nil).Once()
+	err = m.HandleStatus(3, types.ExtrinsicStatus{IsDropped: true})
+	assert.NoError(t, err)
+	assert.Equal(t, NonceState{GenesisHash: testGenesisHash, Address: testAddress, Next: 4, Reclaimed: []uint64{2, 3}},
+		m.State())
+
+	// nonce 1 has been included, nothing is pending and the next nonce is reset to the one on chain
+	err = m.HandleStatus(1, types.ExtrinsicStatus{IsFinalized: true})
+	assert.NoError(t, err)
+
+	source.On("AccountNextIndex", testAddress).Return(types.U64(2), nil).Once()
+	err = m.Resync()
+	assert.NoError(t, err)
+	assert.Equal(t, NonceState{GenesisHash: testGenesisHash, Address: testAddress, Next: 2}, m.State())
+
+	nonce, err := m.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), nonce)
+}
+
+type failingSigner struct {
+	signature.Signer
+}
+
+func (s failingSigner) Sign([]byte) ([]byte, error) {
+	return nil, errors.New("signer error")
+}
+
+func TestBuilder_Build_NonceManager(t *testing.T) {
+	api := newTestAPI()
+	meta := decodeMetadata(t)
+	signer := newTestSigner(t)
+	m, _ := newTestNonceManager(3)
+
+	b := NewBuilder(api.rpc, newTestCall(t, meta), signer).
+		WithMetadata(meta).
+		WithGenesisHash(testGenesisHash).
+		WithSpecVersion(268).
+		WithTransactionVersion(2).
+		WithImmortalEra().
+		WithNonceManager(m)
+
+	ext, err := b.Build()
+	assert.NoError(t, err)
+	assert.Equal(t, types.NewUCompactFromUInt(3), ext.Signature.Nonce)
+
+	// the nonce is reclaimed if signing fails
+	_, err = NewBuilder(api.rpc, newTestCall(t, meta), failingSigner{signer}).
+		WithMetadata(meta).
+		WithGenesisHash(testGenesisHash).
+		WithSpecVersion(268).
+		WithTransactionVersion(2).
+		WithImmortalEra().
+		WithNonceManager(m).
+		Build()
+	assert.EqualError(t, err, "unable to sign extrinsic: signer error")
+
+	ext, err = b.Build()
+	assert.NoError(t, err)
+	assert.Equal(t, types.NewUCompactFromUInt(4), ext.Signature.Nonce)
+}
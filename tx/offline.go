@@ -0,0 +1,545 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tx
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"golang.org/x/crypto/blake2b"
+)
+
+// OfflineFormatVersion is the version of the offline signing file format, files of other versions are rejected
+const OfflineFormatVersion = 1
+
+// OfflinePayload is an unsigned extrinsic that is exported by an online machine, see Builder.Export, to be signed on
+// an offline machine that has no node access. It holds everything that is needed to recreate the signing payload and
+// to display the call, see Verify.
+//
+// The payload is only checked against the call, the options and the metadata of the same file. The metadata defines
+// the names of the displayed pallets, calls and arguments, so a compromised online machine can make Summary show a
+// different call than the runtime executes by exporting doctored metadata. The offline machine should therefore pass
+// its own trusted copy of the metadata of the runtime to Verify, Summary and Sign.
+//
+// The payload is serialized as JSON, all byte values are 0x prefixed hex strings:
+//
+//	{
+//	  "version": 1,                     // OfflineFormatVersion
+//	  "scheme": "sr25519",              // sr25519, ed25519, ecdsa or ethereum
+//	  "signer": "0x...",                // public key of the signer
+//	  "call": "0x...",                  // SCALE encoded types.Call
+//	  "era": "0x...",                   // SCALE encoded types.ExtrinsicEra
+//	  "nonce": 1,
+//	  "tip": "0",                       // decimal string
+//	  "specVersion": 268,
+//	  "transactionVersion": 2,
+//	  "genesisHash": "0x...",
+//	  "blockHash": "0x...",             // block the era is anchored on
+//	  "metadataHash": "0x...",          // optional, enables CheckMetadataHash
+//	  "metadata": "0x...",              // SCALE encoded types.Metadata, v14 or later
+//	  "payload": "0x..."                // the payload to sign, see types.Extrinsic.SigningPayload
+//	}
+type OfflinePayload struct {
+	Scheme   signature.Scheme
+	Signer   []byte
+	Call     types.Call
+	Options  types.SignatureOptions
+	Metadata *types.Metadata
+	Payload  []byte
+}
+
+// OfflineSignature is the signature of an OfflinePayload that is returned by the offline machine. It is serialized as
+// JSON, the payload hash is the blake2b-256 hash of the signed payload:
+//
+//	{
+//	  "version": 1,
+//	  "scheme": "sr25519",
+//	  "signer": "0x...",
+//	  "payloadHash": "0x...",
+//	  "signature": "0x..."
+//	}
+type OfflineSignature struct {
+	Scheme      signature.Scheme
+	Signer      []byte
+	PayloadHash types.Hash
+	Signature   []byte
+}
+
+// NewOfflinePayload creates an OfflinePayload for the call, signed by the key with the given scheme and public key.
+// The metadata has to be v14 or later, fees paid in another asset are not supported.
+func NewOfflinePayload(meta *types.Metadata, call types.Call, scheme signature.Scheme, publicKey []byte,
+	o types.SignatureOptions) (*OfflinePayload, error) {
+	if o.AssetID != nil {
+		return nil, errors.New("offline payloads do not support an asset ID")
+	}
+
+	if !o.Era.IsMortalEra {
+		o.Era = types.ExtrinsicEra{IsImmortalEra: true}
+	}
+
+	p := &OfflinePayload{
+		Scheme:   scheme,
+		Signer:   publicKey,
+		Call:     call,
+		Options:  o,
+		Metadata: meta,
+	}
+
+	payload, err := p.signingPayload(meta)
+	if err != nil {
+		return nil, err
+	}
+
+	p.Payload = payload
+
+	return p, nil
+}
+
+// Export resolves all options that are not overridden, like Build does, and returns the unsigned OfflinePayload
+// instead of signing it. The signer of the builder only has to provide the public key, see NewWatchOnlySigner. If a
+// NonceManager is used, the nonce has to be reclaimed by the caller if the payload is never submitted.
+func (b *Builder) Export() (*OfflinePayload, error) {
+	meta, err := b.metadata()
+	if err != nil {
+		return nil, err
+	}
+
+	o, err := b.SignatureOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewOfflinePayload(meta, b.call, b.signer.Scheme(), b.signer.Public(), o)
+}
+
+// Verify recreates the signing payload from the call, the options and the trusted metadata and checks that it is equal
+// to Payload, so that what is displayed by Summary is what is signed. If the trusted metadata is nil, the metadata of
+// the payload is used, which only checks that the payload is consistent, see OfflinePayload.
+func (p *OfflinePayload) Verify(trusted *types.Metadata) error {
+	meta := p.metadata(trusted)
+	if meta == nil {
+		return errors.New("offline payload has no metadata")
+	}
+
+	payload, err := p.signingPayload(meta)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(payload, p.Payload) {
+		return errors.New("offline payload does not match the call and the options")
+	}
+
+	return nil
+}
+
+// Summary verifies the payload with the trusted metadata, see Verify, and returns a human-readable summary of the call
+// and the options. The names of the call and its arguments are taken from the trusted metadata.
+func (p *OfflinePayload) Summary(trusted *types.Metadata) (string, error) {
+	err := p.Verify(trusted)
+	if err != nil {
+		return "", err
+	}
+
+	call, err := types.DecodeCall(p.metadata(trusted), p.Call)
+	if err != nil {
+		return "", fmt.Errorf("unable to decode call: %w", err)
+	}
+
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "Call: %v.%v\n", call.Pallet, call.Name)
+	writeArgs(&sb, call.Args, "  ")
+	fmt.Fprintf(&sb, "Signer: %v (%v)\n", p.signerAddress(), p.Scheme)
+	fmt.Fprintf(&sb, "Nonce: %v\n", p.Options.Nonce.Int64())
+	fmt.Fprintf(&sb, "Tip: %v\n", (*big.Int)(&p.Options.Tip).String())
+
+	if p.Options.Era.IsMortalEra {
+		period, phase, err := p.Options.Era.AsMortalEra.PeriodAndPhase()
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&sb, "Era: mortal, period %d, phase %d, block %v\n", period, phase, p.Options.BlockHash.Hex())
+	} else {
+		sb.WriteString("Era: immortal\n")
+	}
+
+	fmt.Fprintf(&sb, "Spec version: %d, transaction version: %d\n", p.Options.SpecVersion,
+		p.Options.TransactionVersion)
+	fmt.Fprintf(&sb, "Genesis hash: %v\n", p.Options.GenesisHash.Hex())
+
+	return sb.String(), nil
+}
+
+// writeArgs writes the arguments of a decoded call, nested calls are written indented below their argument
+func writeArgs(sb *strings.Builder, args types.DynamicComposite, indent string) {
+	for _, arg := range args {
+		if call, ok := arg.Value.(types.DecodedCall); ok {
+			fmt.Fprintf(sb, "%v%v: %v.%v\n", indent, arg.Name, call.Pallet, call.Name)
+			writeArgs(sb, call.Args, indent+"  ")
+			continue
+		}
+
+		fmt.Fprintf(sb, "%v%v: %v\n", indent, arg.Name, formatValue(arg.Value))
+	}
+}
+
+func formatValue(v interface{}) string {
+	switch v := v.(type) {
+	case []byte:
+		return fmt.Sprintf("%#x", v)
+	case types.UCompact:
+		return (*big.Int)(&v).String()
+	case types.DynamicComposite:
+		if len(v) == 1 && v[0].Name == "" {
+			return formatValue(v[0].Value)
+		}
+
+		fields := make([]string, 0, len(v))
+		for _, f := range v {
+			if f.Name == "" {
+				fields = append(fields, formatValue(f.Value))
+			} else {
+				fields = append(fields, f.Name+": "+formatValue(f.Value))
+			}
+		}
+
+		return "{" + strings.Join(fields, ", ") + "}"
+	case types.DynamicVariant:
+		if len(v.Fields) == 0 {
+			return v.Name
+		}
+
+		fields := formatValue(v.Fields)
+		if strings.HasPrefix(fields, "{") {
+			return v.Name + fields
+		}
+
+		return v.Name + "(" + fields + ")"
+	case []interface{}:
+		elems := make([]string, 0, len(v))
+		for _, e := range v {
+			elems = append(elems, formatValue(e))
+		}
+
+		return "[" + strings.Join(elems, ", ") + "]"
+	case types.DecodedCall:
+		return v.Pallet + "." + v.Name + formatValue(v.Args)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// Sign verifies the payload with the trusted metadata, see Verify, and signs it with the signer, which has to hold the
+// key of the payload
+func (p *OfflinePayload) Sign(signer signature.Signer, trusted *types.Metadata) (*OfflineSignature, error) {
+	err := p.Verify(trusted)
+	if err != nil {
+		return nil, err
+	}
+
+	if signer.Scheme() != p.Scheme || !bytes.Equal(signer.Public(), p.Signer) {
+		return nil, errors.New("signer does not match the signer of the offline payload")
+	}
+
+	sig, err := signer.Sign(p.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("unable to sign offline payload: %w", err)
+	}
+
+	return &OfflineSignature{
+		Scheme:      p.Scheme,
+		Signer:      p.Signer,
+		PayloadHash: p.payloadHash(),
+		Signature:   sig,
+	}, nil
+}
+
+// Attach verifies that the signature is a signature of the payload by its signer and returns the signed extrinsic,
+// ready to be submitted
+func (p *OfflinePayload) Attach(sig *OfflineSignature) (types.Extrinsic, error) {
+	if sig.Scheme != p.Scheme || !bytes.Equal(sig.Signer, p.Signer) {
+		return types.Extrinsic{}, errors.New("signer of the offline signature does not match the offline payload")
+	}
+
+	if sig.PayloadHash != p.payloadHash() {
+		return types.Extrinsic{}, errors.New("offline signature is not a signature of the offline payload")
+	}
+
+	ok, err := signature.VerifyWithPublicKey(p.Scheme, p.Payload, sig.Signature, p.Signer)
+	if err != nil {
+		return types.Extrinsic{}, fmt.Errorf("unable to verify offline signature: %w", err)
+	}
+
+	if !ok {
+		return types.Extrinsic{}, errors.New("offline signature is not a valid signature of the offline payload")
+	}
+
+	ms, err := types.NewMultiSignature(p.Scheme, sig.Signature)
+	if err != nil {
+		return types.Extrinsic{}, err
+	}
+
+	ext := types.NewExtrinsic(p.Call)
+	signer := types.NewSignerAddress(p.Scheme, p.Signer)
+
+	err = ext.AttachSignature(p.Metadata, signer, ms, p.Options)
+	if err != nil {
+		return types.Extrinsic{}, err
+	}
+
+	return ext, nil
+}
+
+func (p *OfflinePayload) signingPayload(meta *types.Metadata) ([]byte, error) {
+	ext := types.NewExtrinsic(p.Call)
+
+	return ext.SigningPayload(meta, p.Options)
+}
+
+// metadata returns the trusted metadata, or the metadata of the payload if there is none
+func (p *OfflinePayload) metadata(trusted *types.Metadata) *types.Metadata {
+	if trusted != nil {
+		return trusted
+	}
+
+	return p.Metadata
+}
+
+// signerAddress returns the SS58 address of the signer, or the checksummed hex address of Ethereum signers
+func (p *OfflinePayload) signerAddress() string {
+	if p.Scheme == signature.SchemeEthereum {
+		return types.NewAccountID20(p.Scheme.AccountID(p.Signer)).String()
+	}
+
+	return types.NewAccountID(p.Scheme.AccountID(p.Signer)).String()
+}
+
+func (p *OfflinePayload) payloadHash() types.Hash {
+	return blake2b.Sum256(p.Payload)
+}
+
+type offlinePayloadJSON struct {
+	Version            int         `json:"version"`
+	Scheme             string      `json:"scheme"`
+	Signer             string      `json:"signer"`
+	Call               string      `json:"call"`
+	Era                string      `json:"era"`
+	Nonce              uint64      `json:"nonce"`
+	Tip                string      `json:"tip"`
+	SpecVersion        types.U32   `json:"specVersion"`
+	TransactionVersion types.U32   `json:"transactionVersion"`
+	GenesisHash        types.Hash  `json:"genesisHash"`
+	BlockHash          types.Hash  `json:"blockHash"`
+	MetadataHash       *types.Hash `json:"metadataHash,omitempty"`
+	Metadata           string      `json:"metadata"`
+	Payload            string      `json:"payload"`
+}
+
+// MarshalJSON returns the JSON encoding of the offline payload in the current OfflineFormatVersion
+func (p OfflinePayload) MarshalJSON() ([]byte, error) {
+	call, err := types.EncodeToHex(p.Call)
+	if err != nil {
+		return nil, err
+	}
+
+	era, err := types.EncodeToHex(p.Options.Era)
+	if err != nil {
+		return nil, err
+	}
+
+	meta, err := types.EncodeToHex(p.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(offlinePayloadJSON{
+		Version:            OfflineFormatVersion,
+		Scheme:             p.Scheme.String(),
+		Signer:             types.HexEncodeToString(p.Signer),
+		Call:               call,
+		Era:                era,
+		Nonce:              uint64(p.Options.Nonce.Int64()),
+		Tip:                (*big.Int)(&p.Options.Tip).String(),
+		SpecVersion:        p.Options.SpecVersion,
+		TransactionVersion: p.Options.TransactionVersion,
+		GenesisHash:        p.Options.GenesisHash,
+		BlockHash:          p.Options.BlockHash,
+		MetadataHash:       p.Options.MetadataHash,
+		Metadata:           meta,
+		Payload:            types.HexEncodeToString(p.Payload),
+	})
+}
+
+// UnmarshalJSON decodes the JSON encoding of an offline payload, only the current OfflineFormatVersion is supported
+func (p *OfflinePayload) UnmarshalJSON(b []byte) error {
+	var tmp offlinePayloadJSON
+	err := json.Unmarshal(b, &tmp)
+	if err != nil {
+		return err
+	}
+
+	if tmp.Version != OfflineFormatVersion {
+		return fmt.Errorf("unsupported offline payload version %d", tmp.Version)
+	}
+
+	res := OfflinePayload{
+		Metadata: &types.Metadata{},
+		Options: types.SignatureOptions{
+			Nonce:              types.NewUCompactFromUInt(tmp.Nonce),
+			SpecVersion:        tmp.SpecVersion,
+			TransactionVersion: tmp.TransactionVersion,
+			GenesisHash:        tmp.GenesisHash,
+			BlockHash:          tmp.BlockHash,
+			MetadataHash:       tmp.MetadataHash,
+		},
+	}
+
+	res.Scheme, err = parseScheme(tmp.Scheme)
+	if err != nil {
+		return err
+	}
+
+	tip, ok := new(big.Int).SetString(tmp.Tip, 10)
+	if !ok {
+		return fmt.Errorf("invalid tip %v", tmp.Tip)
+	}
+	res.Options.Tip = types.NewUCompact(tip)
+
+	res.Signer, err = types.HexDecodeString(tmp.Signer)
+	if err != nil {
+		return fmt.Errorf("invalid signer: %w", err)
+	}
+
+	res.Payload, err = types.HexDecodeString(tmp.Payload)
+	if err != nil {
+		return fmt.Errorf("invalid payload: %w", err)
+	}
+
+	err = types.DecodeFromHex(tmp.Call, &res.Call)
+	if err != nil {
+		return fmt.Errorf("invalid call: %w", err)
+	}
+
+	err = types.DecodeFromHex(tmp.Era, &res.Options.Era)
+	if err != nil {
+		return fmt.Errorf("invalid era: %w", err)
+	}
+
+	err = types.DecodeFromHex(tmp.Metadata, res.Metadata)
+	if err != nil {
+		return fmt.Errorf("invalid metadata: %w", err)
+	}
+
+	*p = res
+
+	return nil
+}
+
+type offlineSignatureJSON struct {
+	Version     int        `json:"version"`
+	Scheme      string     `json:"scheme"`
+	Signer      string     `json:"signer"`
+	PayloadHash types.Hash `json:"payloadHash"`
+	Signature   string     `json:"signature"`
+}
+
+// MarshalJSON returns the JSON encoding of the offline signature in the current OfflineFormatVersion
+func (s OfflineSignature) MarshalJSON() ([]byte, error) {
+	return json.Marshal(offlineSignatureJSON{
+		Version:     OfflineFormatVersion,
+		Scheme:      s.Scheme.String(),
+		Signer:      types.HexEncodeToString(s.Signer),
+		PayloadHash: s.PayloadHash,
+		Signature:   types.HexEncodeToString(s.Signature),
+	})
+}
+
+// UnmarshalJSON decodes the JSON encoding of an offline signature, only the current OfflineFormatVersion is supported
+func (s *OfflineSignature) UnmarshalJSON(b []byte) error {
+	var tmp offlineSignatureJSON
+	err := json.Unmarshal(b, &tmp)
+	if err != nil {
+		return err
+	}
+
+	if tmp.Version != OfflineFormatVersion {
+		return fmt.Errorf("unsupported offline signature version %d", tmp.Version)
+	}
+
+	res := OfflineSignature{PayloadHash: tmp.PayloadHash}
+
+	res.Scheme, err = parseScheme(tmp.Scheme)
+	if err != nil {
+		return err
+	}
+
+	res.Signer, err = types.HexDecodeString(tmp.Signer)
+	if err != nil {
+		return fmt.Errorf("invalid signer: %w", err)
+	}
+
+	res.Signature, err = types.HexDecodeString(tmp.Signature)
+	if err != nil {
+		return fmt.Errorf("invalid signature: %w", err)
+	}
+
+	*s = res
+
+	return nil
+}
+
+func parseScheme(s string) (signature.Scheme, error) {
+	for _, scheme := range []signature.Scheme{signature.SchemeSr25519, signature.SchemeEd25519,
+		signature.SchemeEcdsa, signature.SchemeEthereum} {
+		if scheme.String() == s {
+			return scheme, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unsupported scheme %v", s)
+}
+
+// watchOnlySigner is a signature.Signer that only knows the public key
+type watchOnlySigner struct {
+	scheme    signature.Scheme
+	publicKey []byte
+}
+
+// NewWatchOnlySigner returns a signature.Signer that only knows the public key of an account, it can be used with a
+// Builder to export an OfflinePayload. Signing always fails.
+func NewWatchOnlySigner(scheme signature.Scheme, publicKey []byte) signature.Signer {
+	return watchOnlySigner{scheme: scheme, publicKey: publicKey}
+}
+
+func (s watchOnlySigner) Public() []byte {
+	return s.publicKey
+}
+
+func (s watchOnlySigner) Scheme() signature.Scheme {
+	return s.scheme
+}
+
+func (s watchOnlySigner) Sign([]byte) ([]byte, error) {
+	return nil, errors.New("watch-only signer can not sign")
+}
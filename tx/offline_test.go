@@ -0,0 +1,231 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tx
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func exportTestPayload(t *testing.T, meta *types.Metadata, signer signature.Signer) []byte {
+	api := newTestAPI()
+
+	api.chain.On("GetFinalizedHead").Return(testFinalizedHash, nil)
+	api.chain.On("GetHeader", testFinalizedHash).Return(&types.Header{Number: 1000}, nil)
+	api.sys.On("AccountNextIndex", "5FA9nQDVg267DEd8m1ZypXLBnvN7SFxYwV7ndqSYGiN9TTpu").Return(types.U64(7), nil)
+
+	watchOnly := NewWatchOnlySigner(signer.Scheme(), signer.Public())
+
+	p, err := NewBuilder(api.rpc, newTestCall(t, meta), watchOnly).
+		WithMetadata(meta).
+		WithGenesisHash(testGenesisHash).
+		WithSpecVersion(268).
+		WithTransactionVersion(2).
+		WithTip(types.NewUCompactFromUInt(3)).
+		Export()
+	assert.NoError(t, err)
+
+	b, err := json.Marshal(p)
+	assert.NoError(t, err)
+
+	api.chain.AssertExpectations(t)
+	api.sys.AssertExpectations(t)
+
+	return b
+}
+
+func TestOfflinePayload_Flow(t *testing.T) {
+	meta := decodeMetadata(t)
+	signer := newTestSigner(t)
+
+	// online: export the unsigned payload
+	exported := exportTestPayload(t, meta, signer)
+
+	// offline: decode, show and sign the payload
+	var p OfflinePayload
+	err := json.Unmarshal(exported, &p)
+	assert.NoError(t, err)
+
+	summary, err := p.Summary(meta)
+	assert.NoError(t, err)
+	assert.Equal(t, "Call: Balances.transfer\n"+
+		"  dest: Id(0x8eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48)\n"+
+		"  value: 12345\n"+
+		"Signer: 5FA9nQDVg267DEd8m1ZypXLBnvN7SFxYwV7ndqSYGiN9TTpu (ed25519)\n"+
+		"Nonce: 7\n"+
+		"Tip: 3\n"+
+		"Era: mortal, period 64, phase 40, block "+testFinalizedHash.Hex()+"\n"+
+		"Spec version: 268, transaction version: 2\n"+
+		"Genesis hash: "+testGenesisHash.Hex()+"\n", summary)
+
+	sig, err := p.Sign(signer, meta)
+	assert.NoError(t, err)
+
+	signed, err := json.Marshal(sig)
+	assert.NoError(t, err)
+
+	// online: attach the signature to the exported payload
+	var online OfflinePayload
+	err = json.Unmarshal(exported, &online)
+	assert.NoError(t, err)
+
+	var decodedSig OfflineSignature
+	err = json.Unmarshal(signed, &decodedSig)
+	assert.NoError(t, err)
+	assert.Equal(t, *sig, decodedSig)
+
+	ext, err := online.Attach(&decodedSig)
+	assert.NoError(t, err)
+
+	o := types.SignatureOptions{
+		Era:                types.NewMortalEra(types.DefaultMortalPeriod, 1000),
+		Nonce:              types.NewUCompactFromUInt(7),
+		Tip:                types.NewUCompactFromUInt(3),
+		SpecVersion:        268,
+		GenesisHash:        testGenesisHash,
+		BlockHash:          testFinalizedHash,
+		TransactionVersion: 2,
+	}
+	assert.Equal(t, expectedExtrinsic(t, meta, newTestCall(t, meta), signer, o), ext)
+}
+
+func TestOfflinePayload_Tampered(t *testing.T) {
+	meta := decodeMetadata(t)
+	signer := newTestSigner(t)
+
+	var p OfflinePayload
+	err := json.Unmarshal(exportTestPayload(t, meta, signer), &p)
+	assert.NoError(t, err)
+
+	// the displayed call does not match the payload
+	tampered := p
+	tampered.Call.Args = append(types.Args{}, p.Call.Args...)
+	tampered.Call.Args[len(tampered.Call.Args)-1]++
+
+	_, err = tampered.Summary(meta)
+	assert.EqualError(t, err, "offline payload does not match the call and the options")
+
+	_, err = tampered.Sign(signer, meta)
+	assert.EqualError(t, err, "offline payload does not match the call and the options")
+
+	// a different key
+	other, err := signature.NewKeyPairSigner(signature.SchemeEd25519, "//Bob")
+	assert.NoError(t, err)
+
+	_, err = p.Sign(other, meta)
+	assert.EqualError(t, err, "signer does not match the signer of the offline payload")
+
+	// a signature of another payload
+	sig, err := p.Sign(signer, meta)
+	assert.NoError(t, err)
+
+	tampered.Payload = append([]byte{}, p.Payload...)
+	tampered.Payload[0]++
+
+	_, err = tampered.Attach(sig)
+	assert.EqualError(t, err, "offline signature is not a signature of the offline payload")
+
+	// a signature by another key that claims to be by the signer of the payload
+	otherSig, err := other.Sign(p.Payload)
+	assert.NoError(t, err)
+
+	_, err = p.Attach(&OfflineSignature{Scheme: sig.Scheme, Signer: sig.Signer, PayloadHash: sig.PayloadHash,
+		Signature: otherSig})
+	assert.EqualError(t, err, "offline signature is not a valid signature of the offline payload")
+
+	// a corrupted signature
+	corrupted := *sig
+	corrupted.Signature = append([]byte{}, sig.Signature...)
+	corrupted.Signature[0]++
+
+	_, err = p.Attach(&corrupted)
+	assert.EqualError(t, err, "offline signature is not a valid signature of the offline payload")
+
+	corrupted.Signature = corrupted.Signature[1:]
+
+	_, err = p.Attach(&corrupted)
+	assert.EqualError(t, err, "unable to verify offline signature: wrong signature length")
+
+	// the watch-only signer can't sign
+	_, err = NewWatchOnlySigner(signer.Scheme(), signer.Public()).Sign(p.Payload)
+	assert.Error(t, err)
+}
+
+func TestOfflinePayload_DoctoredMetadata(t *testing.T) {
+	meta := decodeMetadata(t)
+	signer := newTestSigner(t)
+
+	var p OfflinePayload
+	err := json.Unmarshal(exportTestPayload(t, meta, signer), &p)
+	assert.NoError(t, err)
+
+	// the names of the metadata do not change the signing payload
+	for i, pallet := range p.Metadata.AsMetadataV14.Pallets {
+		if pallet.Name == "Balances" {
+			p.Metadata.AsMetadataV14.Pallets[i].Name = "Staking"
+		}
+	}
+
+	assert.NoError(t, p.Verify(nil))
+
+	summary, err := p.Summary(nil)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(summary, "Call: Staking.transfer\n"))
+
+	// the trusted metadata of the offline machine shows the call that is executed
+	summary, err = p.Summary(meta)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(summary, "Call: Balances.transfer\n"))
+
+	_, err = p.Sign(signer, meta)
+	assert.NoError(t, err)
+}
+
+func TestOfflinePayload_Summary_Ethereum(t *testing.T) {
+	meta := decodeMetadata(t)
+
+	// Alith, a development account of Moonbeam
+	alith, err := signature.NewEthereumSigner(
+		types.MustHexDecodeString("0x5fb92d6e98884f76de468fa3f6278f8807c48bebc13595d45af5bdc4da702133"))
+	assert.NoError(t, err)
+
+	p, err := NewOfflinePayload(meta, newTestCall(t, meta), signature.SchemeEthereum, alith.Public(),
+		types.SignatureOptions{SpecVersion: 268, TransactionVersion: 2, GenesisHash: testGenesisHash})
+	assert.NoError(t, err)
+
+	summary, err := p.Summary(nil)
+	assert.NoError(t, err)
+	assert.Contains(t, summary, "Signer: 0xf24FF3a9CF04c71Dbc94D0b566f7A27B94566cac (ethereum)\n")
+}
+
+func TestOfflinePayload_UnmarshalJSON_Version(t *testing.T) {
+	var p OfflinePayload
+	err := json.Unmarshal([]byte(`{"version":2}`), &p)
+	assert.EqualError(t, err, "unsupported offline payload version 2")
+
+	var s OfflineSignature
+	err = json.Unmarshal([]byte(`{"version":0}`), &s)
+	assert.EqualError(t, err, "unsupported offline signature version 0")
+
+	err = json.Unmarshal([]byte(`{"version":1,"scheme":"rsa"}`), &s)
+	assert.EqualError(t, err, "unsupported scheme rsa")
+}
@@ -0,0 +1,271 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tx
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// Until is the status of an extrinsic SubmitAndWait waits for
+type Until uint8
+
+const (
+	// InBlock waits until the extrinsic is included in a block
+	InBlock Until = iota
+	// Finalized waits until the block the extrinsic is included in is finalized
+	Finalized
+)
+
+var (
+	// ErrInvalid is returned if the extrinsic is invalid, e.g. because of a stale nonce or a bad signature
+	ErrInvalid = errors.New("extrinsic is invalid")
+	// ErrDropped is returned if the extrinsic has been dropped from the transaction pool, e.g. because it is full
+	ErrDropped = errors.New("extrinsic has been dropped from the transaction pool")
+	// ErrUsurped is returned if the extrinsic has been replaced by another extrinsic with the same nonce
+	ErrUsurped = errors.New("extrinsic has been usurped")
+	// ErrFinalityTimeout is returned if the block the extrinsic is included in has not been finalized in time
+	ErrFinalityTimeout = errors.New("finality timeout")
+	// ErrMortalityExpired is returned if the era of a mortal extrinsic ended before it was included in a block
+	ErrMortalityExpired = errors.New("mortality of extrinsic expired")
+	// ErrSubscriptionClosed is returned if a subscription ended before the extrinsic reached the awaited status
+	ErrSubscriptionClosed = errors.New("subscription closed")
+)
+
+// Result is the result of an extrinsic that has been included in a block
+type Result struct {
+	// BlockHash is the hash of the block the extrinsic is included in
+	BlockHash types.Hash
+	// ExtrinsicIndex is the index of the extrinsic within the block
+	ExtrinsicIndex uint32
+	// Events are the events emitted by the extrinsic, including System.ExtrinsicSuccess or System.ExtrinsicFailed
+	Events []types.EventRecord
+	// DispatchError is the error of a failed dispatch, nil if the dispatch was successful
+	DispatchError *types.DispatchError
+}
+
+// IsSuccess returns true if the extrinsic has been dispatched successfully
+func (r *Result) IsSuccess() bool {
+	return r.DispatchError == nil
+}
+
+// Submitter submits extrinsics and waits for their results
+type Submitter struct {
+	api  *rpc.RPC
+	meta *types.Metadata
+}
+
+// NewSubmitter creates a new Submitter. The metadata is used to decode the events, it has to be metadata v14. If it is
+// nil, the metadata of the block the extrinsic is included in is retrieved.
+func NewSubmitter(api *rpc.RPC, meta *types.Metadata) *Submitter {
+	return &Submitter{api: api, meta: meta}
+}
+
+// SubmitAndWait submits the extrinsic and waits until it is included in a block or until that block is finalized.
+// Invalid, Dropped, Usurped and FinalityTimeout statuses are returned as ErrInvalid, ErrDropped, ErrUsurped and
+// ErrFinalityTimeout. A mortal extrinsic that is not included in a block before its era ends results in
+// ErrMortalityExpired. A failed dispatch is not an error, it is returned in the DispatchError of the Result.
+func (s *Submitter) SubmitAndWait(ctx context.Context, xt types.Extrinsic, until Until) (*Result, error) {
+	var w watch
+
+	if xt.IsSigned() && xt.Signature.Era.IsMortalEra {
+		header, err := s.api.Chain.GetHeaderLatest()
+		if err != nil {
+			return nil, fmt.Errorf("unable to get latest header: %w", err)
+		}
+
+		heads, err := s.api.Chain.SubscribeNewHeads()
+		if err != nil {
+			return nil, fmt.Errorf("unable to subscribe to new heads: %w", err)
+		}
+		defer heads.Unsubscribe()
+
+		w.heads = heads.Chan()
+		w.death = xt.Signature.Era.Death(uint64(header.Number))
+	}
+
+	sub, err := s.api.Author.SubmitAndWatchExtrinsic(xt)
+	if err != nil {
+		return nil, err
+	}
+	defer sub.Unsubscribe()
+
+	w.statuses = sub.Chan()
+	w.errs = sub.Err()
+
+	return s.wait(ctx, xt, until, w)
+}
+
+// watch holds the channels SubmitAndWait waits on
+type watch struct {
+	statuses <-chan types.ExtrinsicStatus
+	errs     <-chan error
+	// heads is nil for immortal extrinsics
+	heads <-chan types.Header
+	// death is the first block at which a mortal extrinsic is no longer valid
+	death uint64
+}
+
+func (s *Submitter) wait(ctx context.Context, xt types.Extrinsic, until Until, w watch) (*Result, error) {
+	inBlock := false
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case err := <-w.errs:
+			if err == nil {
+				return nil, ErrSubscriptionClosed
+			}
+			return nil, fmt.Errorf("extrinsic status subscription failed: %w", err)
+		case head, ok := <-w.heads:
+			if !ok {
+				w.heads = nil
+				continue
+			}
+
+			if !inBlock && uint64(head.Number) >= w.death {
+				return nil, fmt.Errorf("%w at block %d", ErrMortalityExpired, w.death)
+			}
+		case status, ok := <-w.statuses:
+			if !ok {
+				return nil, ErrSubscriptionClosed
+			}
+
+			switch {
+			case status.IsInBlock:
+				inBlock = true
+
+				if until == InBlock {
+					return s.result(xt, status.AsInBlock)
+				}
+			case status.IsRetracted:
+				inBlock = false
+			case status.IsFinalized:
+				return s.result(xt, status.AsFinalized)
+			case status.IsFinalityTimeout:
+				return nil, fmt.Errorf("%w for block %v", ErrFinalityTimeout, status.AsFinalityTimeout.Hex())
+			case status.IsUsurped:
+				return nil, fmt.Errorf("%w by %v", ErrUsurped, status.AsUsurped.Hex())
+			case status.IsDropped:
+				return nil, ErrDropped
+			case status.IsInvalid:
+				return nil, ErrInvalid
+			}
+		}
+	}
+}
+
+// result looks up the extrinsic in the block and decodes its events
+func (s *Submitter) result(xt types.Extrinsic, blockHash types.Hash) (*Result, error) {
+	block, err := s.api.Chain.GetBlock(blockHash)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get block %v: %w", blockHash.Hex(), err)
+	}
+
+	index, err := findExtrinsic(block.Block.Extrinsics, xt)
+	if err != nil {
+		return nil, fmt.Errorf("block %v: %w", blockHash.Hex(), err)
+	}
+
+	meta := s.meta
+	if meta == nil {
+		meta, err = s.api.State.GetMetadata(blockHash)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get metadata of block %v: %w", blockHash.Hex(), err)
+		}
+	}
+
+	key, err := types.CreateStorageKey(meta, "System", "Events")
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := s.api.State.GetStorageRaw(key, blockHash)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get events of block %v: %w", blockHash.Hex(), err)
+	}
+
+	records, err := types.EventRecordsRaw(*raw).DecodeEventRecordsDynamic(meta)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode events of block %v: %w", blockHash.Hex(), err)
+	}
+
+	r := &Result{BlockHash: blockHash, ExtrinsicIndex: index}
+
+	for _, record := range records {
+		if !record.Phase.IsApplyExtrinsic || record.Phase.AsApplyExtrinsic != index {
+			continue
+		}
+
+		r.Events = append(r.Events, record)
+
+		if record.Module == "System" && record.Event == "ExtrinsicFailed" {
+			r.DispatchError, err = decodeDispatchError(meta, record)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return r, nil
+}
+
+// findExtrinsic returns the index of the extrinsic within the extrinsics of a block
+func findExtrinsic(extrinsics []types.Extrinsic, xt types.Extrinsic) (uint32, error) {
+	encoded, err := types.Encode(xt)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, ext := range extrinsics {
+		b, err := types.Encode(ext)
+		if err != nil {
+			continue
+		}
+
+		if bytes.Equal(b, encoded) {
+			return uint32(i), nil
+		}
+	}
+
+	return 0, errors.New("extrinsic not found in block")
+}
+
+// decodeDispatchError decodes the dispatch error of a System.ExtrinsicFailed event into a types.DispatchError
+func decodeDispatchError(meta *types.Metadata, record types.EventRecord) (*types.DispatchError, error) {
+	raw, err := types.EncodeEventRecords(meta, []types.EventRecord{record})
+	if err != nil {
+		return nil, err
+	}
+
+	var events struct {
+		System_ExtrinsicFailed []types.EventSystemExtrinsicFailed //nolint:stylecheck,revive
+	}
+
+	err = raw.DecodeEventRecords(meta, &events)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode dispatch error: %w", err)
+	}
+
+	return &events.System_ExtrinsicFailed[0].DispatchError, nil
+}
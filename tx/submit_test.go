@@ -0,0 +1,216 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tx
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testBlockHash     = types.NewHash(types.MustHexDecodeString("0xabcdef0000000000000000000000000000000000000000000000000000000001"))
+	testRetractedHash = types.NewHash(types.MustHexDecodeString("0xabcdef0000000000000000000000000000000000000000000000000000000002"))
+	testDispatchInfo  = types.DispatchInfo{Weight: 10000, Class: types.DispatchClass{IsNormal: true}, PaysFee: types.Pays{IsYes: true}}
+)
+
+type submitTest struct {
+	api       testAPI
+	submitter *Submitter
+	xt        types.Extrinsic
+}
+
+// newSubmitTest creates a block with the extrinsic at index 1 and the given events
+func newSubmitTest(t *testing.T, records []types.EventRecord) submitTest {
+	api := newTestAPI()
+	meta := decodeMetadata(t)
+
+	xt, err := NewBuilder(api.rpc, newTestCall(t, meta), newTestSigner(t)).
+		WithMetadata(meta).
+		WithGenesisHash(testGenesisHash).
+		WithSpecVersion(268).
+		WithTransactionVersion(2).
+		WithNonce(0).
+		WithEra(types.NewMortalEra(64, 100)).
+		WithBlockHash(testFinalizedHash).
+		Build()
+	assert.NoError(t, err)
+
+	block := types.SignedBlock{Block: types.Block{Extrinsics: []types.Extrinsic{
+		types.NewExtrinsic(types.Call{CallIndex: types.CallIndex{SectionIndex: 0, MethodIndex: 1}}),
+		xt,
+	}}}
+
+	events, err := types.EncodeEventRecords(meta, records)
+	assert.NoError(t, err)
+	raw := types.StorageDataRaw(events)
+
+	key, err := types.CreateStorageKey(meta, "System", "Events")
+	assert.NoError(t, err)
+
+	api.chain.On("GetBlock", testBlockHash).Return(&block, nil)
+	api.state.On("GetStorageRaw", key, testBlockHash).Return(&raw, nil)
+
+	return submitTest{api: api, submitter: NewSubmitter(api.rpc, meta), xt: xt}
+}
+
+func applyExtrinsic(index uint32) types.Phase {
+	return types.Phase{IsApplyExtrinsic: true, AsApplyExtrinsic: index}
+}
+
+func newWatch(statuses ...types.ExtrinsicStatus) (watch, chan types.Header) {
+	c := make(chan types.ExtrinsicStatus, len(statuses))
+	for _, status := range statuses {
+		c <- status
+	}
+
+	heads := make(chan types.Header, 1)
+
+	return watch{statuses: c, errs: make(chan error), heads: heads, death: 164}, heads
+}
+
+func TestSubmitter_Wait_InBlock(t *testing.T) {
+	transfer := types.EventRecord{
+		Phase:  applyExtrinsic(1),
+		Module: "Balances",
+		Event:  "Transfer",
+		Fields: []interface{}{
+			types.DynamicComposite{{Value: make([]byte, 32)}},
+			types.DynamicComposite{{Value: append([]byte{2}, make([]byte, 31)...)}},
+			types.NewU128(*big.NewInt(12345)),
+		},
+		Topics: []types.Hash{},
+	}
+	test := newSubmitTest(t, []types.EventRecord{
+		{Phase: applyExtrinsic(0), Module: "System", Event: "ExtrinsicSuccess",
+			Fields: []interface{}{testDispatchInfo}},
+		transfer,
+		{Phase: applyExtrinsic(1), Module: "System", Event: "ExtrinsicSuccess",
+			Fields: []interface{}{testDispatchInfo}},
+	})
+
+	w, _ := newWatch(types.ExtrinsicStatus{IsReady: true},
+		types.ExtrinsicStatus{IsInBlock: true, AsInBlock: testBlockHash})
+
+	r, err := test.submitter.wait(context.Background(), test.xt, InBlock, w)
+	assert.NoError(t, err)
+	assert.Equal(t, testBlockHash, r.BlockHash)
+	assert.Equal(t, uint32(1), r.ExtrinsicIndex)
+	assert.True(t, r.IsSuccess())
+	assert.Len(t, r.Events, 2)
+	assert.Equal(t, "Transfer", r.Events[0].Event)
+	assert.Equal(t, "ExtrinsicSuccess", r.Events[1].Event)
+}
+
+func TestSubmitter_Wait_Finalized(t *testing.T) {
+	test := newSubmitTest(t, []types.EventRecord{
+		{Phase: applyExtrinsic(1), Module: "System", Event: "ExtrinsicFailed",
+			Fields: []interface{}{types.DynamicVariant{Name: "BadOrigin"}, testDispatchInfo}},
+	})
+
+	w, heads := newWatch(types.ExtrinsicStatus{IsInBlock: true, AsInBlock: testRetractedHash},
+		types.ExtrinsicStatus{IsRetracted: true, AsRetracted: testRetractedHash},
+		types.ExtrinsicStatus{IsInBlock: true, AsInBlock: testBlockHash},
+		types.ExtrinsicStatus{IsFinalized: true, AsFinalized: testBlockHash})
+	close(heads)
+
+	r, err := test.submitter.wait(context.Background(), test.xt, Finalized, w)
+	assert.NoError(t, err)
+	assert.Equal(t, testBlockHash, r.BlockHash)
+	assert.False(t, r.IsSuccess())
+	assert.Equal(t, &types.DispatchError{IsBadOrigin: true}, r.DispatchError)
+	assert.Len(t, r.Events, 1)
+
+	test.api.chain.AssertNotCalled(t, "GetBlock", testRetractedHash)
+}
+
+func TestSubmitter_Wait_Errors(t *testing.T) {
+	test := newSubmitTest(t, nil)
+
+	for _, c := range []struct {
+		status types.ExtrinsicStatus
+		err    error
+	}{
+		{types.ExtrinsicStatus{IsInvalid: true}, ErrInvalid},
+		{types.ExtrinsicStatus{IsDropped: true}, ErrDropped},
+		{types.ExtrinsicStatus{IsUsurped: true, AsUsurped: testBlockHash}, ErrUsurped},
+		{types.ExtrinsicStatus{IsFinalityTimeout: true, AsFinalityTimeout: testBlockHash}, ErrFinalityTimeout},
+	} {
+		w, _ := newWatch(types.ExtrinsicStatus{IsReady: true}, c.status)
+
+		_, err := test.submitter.wait(context.Background(), test.xt, Finalized, w)
+		assert.ErrorIs(t, err, c.err)
+	}
+}
+
+func TestSubmitter_Wait_MortalityExpired(t *testing.T) {
+	test := newSubmitTest(t, nil)
+
+	w, heads := newWatch()
+	heads <- types.Header{Number: 163}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go func() {
+		// the extrinsic is still valid at block 163
+		heads <- types.Header{Number: 164}
+	}()
+
+	_, err := test.submitter.wait(ctx, test.xt, InBlock, w)
+	assert.ErrorIs(t, err, ErrMortalityExpired)
+	assert.EqualError(t, err, "mortality of extrinsic expired at block 164")
+
+	cancel()
+
+	_, err = test.submitter.wait(ctx, test.xt, InBlock, w)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestSubmitter_Wait_Subscription(t *testing.T) {
+	test := newSubmitTest(t, nil)
+
+	w, _ := newWatch()
+	statuses := make(chan types.ExtrinsicStatus)
+	close(statuses)
+	w.statuses = statuses
+
+	_, err := test.submitter.wait(context.Background(), test.xt, InBlock, w)
+	assert.ErrorIs(t, err, ErrSubscriptionClosed)
+
+	w, _ = newWatch()
+	errs := make(chan error, 1)
+	errs <- errors.New("connection lost")
+	w.errs = errs
+
+	_, err = test.submitter.wait(context.Background(), test.xt, InBlock, w)
+	assert.EqualError(t, err, "extrinsic status subscription failed: connection lost")
+}
+
+func TestSubmitter_Result_NotFound(t *testing.T) {
+	test := newSubmitTest(t, nil)
+
+	other := types.NewExtrinsic(types.Call{CallIndex: types.CallIndex{SectionIndex: 0, MethodIndex: 2}})
+
+	w, _ := newWatch(types.ExtrinsicStatus{IsInBlock: true, AsInBlock: testBlockHash})
+
+	_, err := test.submitter.wait(context.Background(), other, InBlock, w)
+	assert.EqualError(t, err, "block "+testBlockHash.Hex()+": extrinsic not found in block")
+}
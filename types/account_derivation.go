@@ -0,0 +1,241 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"golang.org/x/crypto/blake2b"
+)
+
+// The prefixes and type IDs that are used by Substrate to derive account IDs
+const (
+	utilityDerivationPrefix = "modlpy/utilisuba"
+	proxyDerivationPrefix   = "modlpy/proxy____"
+
+	parachainTypeID = "para"
+	siblingTypeID   = "sibl"
+	parentTypeID    = "Parent"
+)
+
+// NewMultisigAccountID returns the account ID of the multisig account of the signatories and the threshold, as
+// derived by the Multisig pallet. The signatories can be given in any order.
+func NewMultisigAccountID(signatories []AccountID, threshold uint16) AccountID {
+	sorted := make([]AccountID, len(signatories))
+	copy(sorted, signatories)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i][:], sorted[j][:]) < 0
+	})
+
+	return hashedAccountID(utilityDerivationPrefix, sorted, threshold)
+}
+
+// NewDerivativeAccountID returns the account ID of the derivative account of who with the given index, as used by
+// Utility.as_derivative.
+func NewDerivativeAccountID(who AccountID, index uint16) AccountID {
+	return hashedAccountID(utilityDerivationPrefix, who, index)
+}
+
+// NewPureProxyAccountID returns the account ID of a pure proxy account, as derived by the Proxy pallet. spawner is the
+// account that created the pure proxy, proxyType the index of the proxy type variant and index the disambiguation
+// index given to Proxy.create_pure. height and extrinsicIndex identify the extrinsic that created the pure proxy,
+// they are emitted in the PureCreated event.
+func NewPureProxyAccountID(spawner AccountID, proxyType uint8, index uint16, height BlockNumber,
+	extrinsicIndex uint32) AccountID {
+	return hashedAccountID(proxyDerivationPrefix, spawner, U32(height), extrinsicIndex, proxyType, index)
+}
+
+// NewParachainSovereignAccountID returns the account ID of the sovereign account of a parachain on the relay chain
+func NewParachainSovereignAccountID(paraID uint32) AccountID {
+	return typedAccountID(parachainTypeID, paraID)
+}
+
+// NewSiblingSovereignAccountID returns the account ID of the sovereign account of a parachain on its sibling
+// parachains
+func NewSiblingSovereignAccountID(paraID uint32) AccountID {
+	return typedAccountID(siblingTypeID, paraID)
+}
+
+// NewParentAccountID returns the account ID of the parent, usually the relay chain, on a parachain
+func NewParentAccountID() AccountID {
+	return typedAccountID(parentTypeID)
+}
+
+// NewAccountIDFromMultiLocationV1 converts a location into the account ID it controls, as seen from the chain the
+// location is relative to. The conversion follows the converters that are used by the Polkadot relay chains and the
+// system parachains:
+//   - an AccountId32 junction without parents is the account itself
+//   - the parent is converted by ParentIsPreset, see NewParentAccountID
+//   - a parachain is converted by ChildParachainConvertsVia or SiblingParachainConvertsVia, see
+//     NewParachainSovereignAccountID and NewSiblingSovereignAccountID
+//   - any other location is converted by HashedDescription<DescribeFamily<DescribeAllTerminal>>, the terminal of the
+//     location has to be Here, a PalletInstance, an AccountId32 or an AccountKey20 junction
+func NewAccountIDFromMultiLocationV1(location MultiLocationV1) (AccountID, error) {
+	junctions := location.Interior.junctions()
+
+	switch {
+	case location.Parents == 0 && len(junctions) == 1 && junctions[0].IsAccountID32:
+		if len(junctions[0].AccountID) != len(AccountID{}) {
+			return AccountID{}, fmt.Errorf("invalid account ID length %d", len(junctions[0].AccountID))
+		}
+
+		return NewAccountID(u8sToBytes(junctions[0].AccountID)), nil
+	case location.Parents == 1 && len(junctions) == 0:
+		return NewParentAccountID(), nil
+	case location.Parents <= 1 && len(junctions) == 1 && junctions[0].IsParachain:
+		paraID := uint32(junctions[0].ParachainID.Int64())
+		if location.Parents == 0 {
+			return NewParachainSovereignAccountID(paraID), nil
+		}
+
+		return NewSiblingSovereignAccountID(paraID), nil
+	}
+
+	desc, err := describeFamily(location.Parents, junctions)
+	if err != nil {
+		return AccountID{}, err
+	}
+
+	return blake2b.Sum256(desc), nil
+}
+
+// describeFamily returns the description of a location as used by DescribeFamily of the XCM builder
+func describeFamily(parents U8, junctions []JunctionV1) ([]byte, error) {
+	switch {
+	case parents == 0 && len(junctions) > 0 && junctions[0].IsParachain:
+		terminal, err := describeTerminal(junctions[1:])
+		if err != nil {
+			return nil, err
+		}
+
+		return encodeAll(rawBytes("ChildChain"), junctions[0].ParachainID, Bytes(terminal)), nil
+	case parents == 1 && len(junctions) > 0 && junctions[0].IsParachain:
+		terminal, err := describeTerminal(junctions[1:])
+		if err != nil {
+			return nil, err
+		}
+
+		return encodeAll(rawBytes("SiblingChain"), junctions[0].ParachainID, Bytes(terminal)), nil
+	case parents == 1:
+		terminal, err := describeTerminal(junctions)
+		if err != nil {
+			return nil, err
+		}
+
+		return encodeAll(rawBytes("ParentChain"), Bytes(terminal)), nil
+	default:
+		return nil, fmt.Errorf("unable to convert location with %d parents and %d junctions to an account ID", parents,
+			len(junctions))
+	}
+}
+
+// describeTerminal returns the description of the junctions as used by DescribeAllTerminal of the XCM builder
+func describeTerminal(junctions []JunctionV1) ([]byte, error) {
+	if len(junctions) == 0 {
+		return []byte{}, nil
+	}
+
+	if len(junctions) > 1 {
+		return nil, fmt.Errorf("unable to describe %d junctions, only a single terminal junction is supported",
+			len(junctions))
+	}
+
+	j := junctions[0]
+
+	switch {
+	case j.IsPalletInstance:
+		return encodeAll(rawBytes("Pallet"), NewUCompactFromUInt(uint64(j.PalletIndex))), nil
+	case j.IsAccountID32 && len(j.AccountID) == 32:
+		return encodeAll(rawBytes("AccountId32"), rawBytes(u8sToBytes(j.AccountID))), nil
+	case j.IsAccountKey20 && len(j.AccountKey) == 20:
+		return encodeAll(rawBytes("AccountKey20"), rawBytes(u8sToBytes(j.AccountKey))), nil
+	default:
+		return nil, fmt.Errorf("unsupported terminal junction %+v", j)
+	}
+}
+
+// junctions returns the junctions as a slice
+func (j JunctionsV1) junctions() []JunctionV1 {
+	switch {
+	case j.IsX1:
+		return []JunctionV1{j.X1}
+	case j.IsX2:
+		return j.X2[:]
+	case j.IsX3:
+		return j.X3[:]
+	case j.IsX4:
+		return j.X4[:]
+	case j.IsX5:
+		return j.X5[:]
+	case j.IsX6:
+		return j.X6[:]
+	case j.IsX7:
+		return j.X7[:]
+	case j.IsX8:
+		return j.X8[:]
+	default:
+		return nil
+	}
+}
+
+// hashedAccountID returns the blake2b-256 hash of the prefix followed by the encoded values
+func hashedAccountID(prefix string, values ...interface{}) AccountID {
+	return blake2b.Sum256(encodeAll(append([]interface{}{rawBytes(prefix)}, values...)...))
+}
+
+// typedAccountID returns the account ID of a type ID followed by the encoded values, padded with zeros. This is the
+// account ID returned by into_account_truncating of Substrate.
+func typedAccountID(typeID string, values ...interface{}) AccountID {
+	return NewAccountID(encodeAll(append([]interface{}{rawBytes(typeID)}, values...)...))
+}
+
+// encodeAll returns the concatenation of the encoded values
+func encodeAll(values ...interface{}) []byte {
+	var res []byte
+
+	for _, value := range values {
+		enc, err := Encode(value)
+		if err != nil {
+			// the values used to derive account IDs are fixed size values and slices of them, encoding them can not fail
+			panic(err)
+		}
+
+		res = append(res, enc...)
+	}
+
+	return res
+}
+
+// rawBytes are bytes that are encoded without a length prefix, like fixed size byte arrays in Rust
+type rawBytes []byte
+
+func (b rawBytes) Encode(encoder scale.Encoder) error {
+	return encoder.Write(b)
+}
+
+func u8sToBytes(u8s []U8) []byte {
+	res := make([]byte, len(u8s))
+	for i, u := range u8s {
+		res[i] = byte(u)
+	}
+
+	return res
+}
@@ -0,0 +1,131 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/blake2b"
+)
+
+var (
+	testAlice   = NewAccountID(signature.TestKeyringPairAlice.PublicKey)
+	testBob     = NewAccountID(signature.TestKeyringPairBob.PublicKey)
+	testCharlie = NewAccountID(signature.TestKeyringPairCharlie.PublicKey)
+)
+
+func TestNewMultisigAccountID(t *testing.T) {
+	expected := "5DjYJStmdZ2rcqXbXGX7TW85JsrW6uG4y9MUcLq2BoPMpRA7"
+
+	assert.Equal(t, expected, NewMultisigAccountID([]AccountID{testAlice, testBob, testCharlie}, 2).String())
+	assert.Equal(t, expected, NewMultisigAccountID([]AccountID{testCharlie, testAlice, testBob}, 2).String())
+	assert.NotEqual(t, expected, NewMultisigAccountID([]AccountID{testAlice, testBob, testCharlie}, 3).String())
+}
+
+func TestNewDerivativeAccountID(t *testing.T) {
+	assert.Equal(t, "5Ep769A4Ka6QrHYoPfzA1fTWRSXpf28vhdbWHWmkWmi4SNHi", NewDerivativeAccountID(testAlice, 0).String())
+	assert.Equal(t, "5HfyUeY7jWfArT21FcynErXqZUDBgHirZsSkZsQVje9Ner6m", NewDerivativeAccountID(testAlice, 1).String())
+}
+
+func TestNewPureProxyAccountID(t *testing.T) {
+	// blake2b-256 of the prefix, the spawner, the height, the extrinsic index, the proxy type and the index
+	enc := append([]byte("modlpy/proxy____"), testAlice[:]...)
+	enc = append(enc, 0x0a, 0, 0, 0, 0x02, 0, 0, 0, 0x01, 0x05, 0)
+
+	assert.Equal(t, AccountID(blake2b.Sum256(enc)), NewPureProxyAccountID(testAlice, 1, 5, 10, 2))
+}
+
+func TestNewSovereignAccountID(t *testing.T) {
+	assert.Equal(t, "5Ec4AhPZk8STuex8Wsi9TwDtJQxKqzPJRCH7348Xtcs9vZLJ", NewParachainSovereignAccountID(1000).String())
+	assert.Equal(t, "5Eg2fntNprdN3FgH4sfEaaZhYtddZQSQUqvYJ1f2mLtinVhV", NewSiblingSovereignAccountID(1000).String())
+	assert.Equal(t, "5Dt6dpkWPwLaH4BBCKJwjiWrFVAGyYk3tLUabvyn4v7KtESG", NewParentAccountID().String())
+}
+
+func newTestAccountID32Junction(accountID AccountID) JunctionV1 {
+	u8s := make([]U8, len(accountID))
+	for i, b := range accountID {
+		u8s[i] = U8(b)
+	}
+
+	return JunctionV1{IsAccountID32: true, AccountID: u8s}
+}
+
+func TestNewAccountIDFromMultiLocationV1(t *testing.T) {
+	parachain := JunctionV1{IsParachain: true, ParachainID: NewUCompactFromUInt(1000)}
+	alice := newTestAccountID32Junction(testAlice)
+
+	for _, test := range []struct {
+		location MultiLocationV1
+		expected AccountID
+	}{
+		{
+			location: MultiLocationV1{Interior: JunctionsV1{IsX1: true, X1: alice}},
+			expected: testAlice,
+		},
+		{
+			location: MultiLocationV1{Parents: 1, Interior: JunctionsV1{IsHere: true}},
+			expected: NewParentAccountID(),
+		},
+		{
+			location: MultiLocationV1{Interior: JunctionsV1{IsX1: true, X1: parachain}},
+			expected: NewParachainSovereignAccountID(1000),
+		},
+		{
+			location: MultiLocationV1{Parents: 1, Interior: JunctionsV1{IsX1: true, X1: parachain}},
+			expected: NewSiblingSovereignAccountID(1000),
+		},
+		{
+			location: MultiLocationV1{Parents: 1, Interior: JunctionsV1{IsX2: true, X2: [2]JunctionV1{parachain, alice}}},
+			expected: blake2b.Sum256(append(append([]byte("SiblingChain\xa1\x0f\xac"), "AccountId32"...),
+				testAlice[:]...)),
+		},
+		{
+			location: MultiLocationV1{Interior: JunctionsV1{IsX2: true, X2: [2]JunctionV1{parachain,
+				{IsPalletInstance: true, PalletIndex: 50}}}},
+			expected: blake2b.Sum256([]byte("ChildChain\xa1\x0f\x1cPallet\xc8")),
+		},
+		{
+			location: MultiLocationV1{Parents: 1, Interior: JunctionsV1{IsX1: true, X1: alice}},
+			expected: blake2b.Sum256(append([]byte("ParentChain\xacAccountId32"), testAlice[:]...)),
+		},
+		{
+			location: MultiLocationV1{Parents: 1, Interior: JunctionsV1{IsX1: true, X1: JunctionV1{IsAccountKey20: true,
+				AccountKey: make([]U8, 20)}}},
+			expected: blake2b.Sum256(append([]byte("ParentChain\x80AccountKey20"), make([]byte, 20)...)),
+		},
+	} {
+		accountID, err := NewAccountIDFromMultiLocationV1(test.location)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, accountID)
+	}
+}
+
+func TestNewAccountIDFromMultiLocationV1_Errors(t *testing.T) {
+	_, err := NewAccountIDFromMultiLocationV1(MultiLocationV1{Parents: 2, Interior: JunctionsV1{IsHere: true}})
+	assert.EqualError(t, err, "unable to convert location with 2 parents and 0 junctions to an account ID")
+
+	_, err = NewAccountIDFromMultiLocationV1(MultiLocationV1{Parents: 1, Interior: JunctionsV1{IsX1: true,
+		X1: JunctionV1{IsOnlyChild: true}}})
+	assert.Error(t, err)
+
+	_, err = NewAccountIDFromMultiLocationV1(MultiLocationV1{Parents: 1, Interior: JunctionsV1{IsX2: true,
+		X2: [2]JunctionV1{{IsPalletInstance: true}, {IsGeneralIndex: true}}}})
+	assert.EqualError(t, err, "unable to describe 2 junctions, only a single terminal junction is supported")
+}
@@ -0,0 +1,145 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// AccountID20 is a 20 byte account ID as used by Ethereum compatible chains that are based on Frontier, like Moonbeam.
+// It is the Ethereum address of the account.
+type AccountID20 [20]byte
+
+// NewAccountID20 creates a new AccountID20 type
+func NewAccountID20(b []byte) AccountID20 {
+	a := AccountID20{}
+	copy(a[:], b)
+	return a
+}
+
+// NewAccountID20FromHex creates an AccountID20 from a hex encoded Ethereum address, the checksum of mixed case
+// addresses is not verified
+func NewAccountID20FromHex(s string) (AccountID20, error) {
+	b, err := HexDecodeString(s)
+	if err != nil {
+		return AccountID20{}, err
+	}
+
+	if len(b) != len(AccountID20{}) {
+		return AccountID20{}, fmt.Errorf("expected 20 bytes, got %d", len(b))
+	}
+
+	return NewAccountID20(b), nil
+}
+
+// String returns the EIP-55 checksum encoded Ethereum address
+func (a AccountID20) String() string {
+	lower := hex.EncodeToString(a[:])
+
+	h := sha3.NewLegacyKeccak256()
+	h.Write([]byte(lower))
+	hash := h.Sum(nil)
+
+	var sb strings.Builder
+	sb.WriteString("0x")
+
+	for i, c := range lower {
+		// letters are upper case if the corresponding nibble of the hash is 8 or larger
+		nibble := hash[i/2] >> 4
+		if i%2 == 1 {
+			nibble = hash[i/2] & 0x0f
+		}
+
+		if c >= 'a' && nibble >= 8 {
+			c -= 'a' - 'A'
+		}
+
+		sb.WriteRune(c)
+	}
+
+	return sb.String()
+}
+
+// UsesAccountID20 returns true if the runtime uses 20 byte account IDs as addresses of extrinsics, like the Ethereum
+// compatible chains that are based on Frontier. Such runtimes expect an AccountID20 as signer, see
+// MultiAddress.IsAccountID20, and are detected from the Address parameter of the extrinsic type.
+func (m *Metadata) UsesAccountID20() bool {
+	if m.Version != 14 {
+		return false
+	}
+
+	return m.AsMetadataV14.isAccountID20Address()
+}
+
+func (m *MetadataV14) isAccountID20Address() bool {
+	typ, ok := m.extrinsicParamType("Address")
+	if !ok {
+		return false
+	}
+
+	return m.isAccountID20Type(typ, 0)
+}
+
+// usesEthereumSignature returns true if the signature type of extrinsics is the EthereumSignature of Frontier, which
+// is a plain 65 byte signature instead of a MultiSignature
+func (m *MetadataV14) usesEthereumSignature() bool {
+	typ, ok := m.extrinsicParamType("Signature")
+	if !ok {
+		return false
+	}
+
+	return len(typ.Path) > 0 && typ.Path[len(typ.Path)-1] == "EthereumSignature"
+}
+
+// extrinsicParamType returns the type of the parameter of the extrinsic type with the given name, e.g. Address
+func (m *MetadataV14) extrinsicParamType(name string) (*Si1Type, bool) {
+	xt, ok := m.EfficientLookup[m.Extrinsic.Type.Int64()]
+	if !ok {
+		return nil, false
+	}
+
+	for _, param := range xt.Params {
+		if string(param.Name) == name && param.HasType {
+			typ, ok := m.EfficientLookup[param.Type.Int64()]
+			return typ, ok
+		}
+	}
+
+	return nil, false
+}
+
+// isAccountID20Type returns true if the type is a 20 byte array or a composite wrapping one, like AccountId20
+func (m *MetadataV14) isAccountID20Type(typ *Si1Type, depth int) bool {
+	if depth > maxDynamicValueDepth {
+		return false
+	}
+
+	switch {
+	case typ.Def.IsArray:
+		elem, ok := m.EfficientLookup[typ.Def.Array.Type.Int64()]
+		return ok && typ.Def.Array.Len == 20 && elem.Def.IsPrimitive && elem.Def.Primitive.Si0TypeDefPrimitive == IsU8
+	case typ.Def.IsComposite && len(typ.Def.Composite.Fields) == 1:
+		field, ok := m.EfficientLookup[typ.Def.Composite.Fields[0].Type.Int64()]
+		return ok && m.isAccountID20Type(field, depth+1)
+	default:
+		return false
+	}
+}
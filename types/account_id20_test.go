@@ -0,0 +1,166 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+// alithPrivateKey is the private key of Alith, a development account of Moonbeam
+var alithPrivateKey = MustHexDecodeString("0x5fb92d6e98884f76de468fa3f6278f8807c48bebc13595d45af5bdc4da702133")
+
+func newAlith(t *testing.T) *signature.EthereumSigner {
+	s, err := signature.NewEthereumSigner(alithPrivateKey)
+	assert.NoError(t, err)
+	return s
+}
+
+// decodeFrontierMetadata returns the test metadata with the address and the signature types of the extrinsic replaced
+// by the AccountId20 and the EthereumSignature of Frontier
+func decodeFrontierMetadata(t *testing.T) *Metadata {
+	meta := decodeMetadataV14(t)
+	m := &meta.AsMetadataV14
+
+	var u8, next int64
+	for id, typ := range m.EfficientLookup {
+		if typ.Def.IsPrimitive && typ.Def.Primitive.Si0TypeDefPrimitive == IsU8 {
+			u8 = id
+		}
+		if id >= next {
+			next = id + 1
+		}
+	}
+
+	addType := func(path string, length uint32) int64 {
+		array, composite := next, next+1
+		next += 2
+
+		m.EfficientLookup[array] = &Si1Type{Def: Si1TypeDef{
+			IsArray: true,
+			Array:   Si1TypeDefArray{Len: U32(length), Type: NewSi1LookupTypeIDFromUInt(uint64(u8))},
+		}}
+		m.EfficientLookup[composite] = &Si1Type{
+			Path: Si1Path{"fp_account", Text(path)},
+			Def: Si1TypeDef{
+				IsComposite: true,
+				Composite: Si1TypeDefComposite{Fields: []Si1Field{
+					{Type: NewSi1LookupTypeIDFromUInt(uint64(array))},
+				}},
+			},
+		}
+
+		return composite
+	}
+
+	address, sig := addType("AccountId20", 20), addType("EthereumSignature", 65)
+
+	xt := m.EfficientLookup[m.Extrinsic.Type.Int64()]
+	for i, param := range xt.Params {
+		switch param.Name {
+		case "Address":
+			xt.Params[i].Type = NewSi1LookupTypeIDFromUInt(uint64(address))
+		case "Signature":
+			xt.Params[i].Type = NewSi1LookupTypeIDFromUInt(uint64(sig))
+		}
+	}
+
+	return meta
+}
+
+func TestAccountID20_String(t *testing.T) {
+	a := NewAccountID20(newAlith(t).Address())
+	assert.Equal(t, "0xf24FF3a9CF04c71Dbc94D0b566f7A27B94566cac", a.String())
+
+	b, err := NewAccountID20FromHex("0xf24ff3a9cf04c71dbc94d0b566f7a27b94566cac")
+	assert.NoError(t, err)
+	assert.Equal(t, a, b)
+
+	_, err = NewAccountID20FromHex("0x0102")
+	assert.EqualError(t, err, "expected 20 bytes, got 2")
+
+	_, err = NewAccountID20FromHex("0xzz")
+	assert.Error(t, err)
+}
+
+func TestAccountID20_EncodeDecode(t *testing.T) {
+	assertRoundtrip(t, NewAccountID20(newAlith(t).Address()))
+}
+
+func TestMetadata_UsesAccountID20(t *testing.T) {
+	assert.False(t, decodeMetadataV14(t).UsesAccountID20())
+	assert.True(t, decodeFrontierMetadata(t).UsesAccountID20())
+}
+
+func TestExtrinsic_SignWithMetadata_Ethereum(t *testing.T) {
+	meta := decodeFrontierMetadata(t)
+	alith := newAlith(t)
+
+	xt := NewExtrinsic(newTestTransferCall(t, meta))
+	err := xt.SignWithMetadata(alith, meta, exampleSignatureOptions)
+	assert.NoError(t, err)
+	assert.Equal(t, NewMultiAddressFromAccountID20(NewAccountID20(alith.Address())), xt.Signature.Signer)
+	assert.True(t, xt.Signature.Signature.IsEthereum)
+
+	payload, err := xt.SigningPayload(meta, exampleSignatureOptions)
+	assert.NoError(t, err)
+
+	enc, err := Encode(xt)
+	assert.NoError(t, err)
+
+	// the address and the signature follow the version byte without variant indices
+	r := bytes.NewReader(enc)
+	var length UCompact
+	assert.NoError(t, scale.NewDecoder(r).Decode(&length))
+	n := len(enc) - r.Len()
+
+	assert.Equal(t, byte(ExtrinsicVersion4|ExtrinsicBitSigned), enc[n])
+	assert.Equal(t, alith.Address(), enc[n+1:n+21])
+	assert.Equal(t, xt.Signature.Signature.AsEthereum[:], enc[n+21:n+86])
+
+	decoded, err := DecodeExtrinsic(meta, xt)
+	assert.NoError(t, err)
+	assertTransferCall(t, decoded.Call)
+	assert.Equal(t, xt.Signature.Signer, decoded.Signature.Signer)
+	assert.Equal(t, xt.Signature.Signature, decoded.Signature.Signature)
+
+	pub, err := crypto.SigToPub(crypto.Keccak256(signature.PreparePayload(payload)),
+		xt.Signature.Signature.AsEthereum[:])
+	assert.NoError(t, err)
+	assert.Equal(t, alith.Address(), crypto.PubkeyToAddress(*pub).Bytes())
+}
+
+func TestExtrinsic_SignWithMetadata_SignerMismatch(t *testing.T) {
+	xt := NewExtrinsic(newTestTransferCall(t, decodeMetadataV14(t)))
+	err := xt.SignWithMetadata(newAlith(t), decodeMetadataV14(t), exampleSignatureOptions)
+	assert.EqualError(t, err, "the runtime does not use 20 byte account IDs, ethereum signers are not supported")
+
+	meta := decodeFrontierMetadata(t)
+	xt = NewExtrinsic(newTestTransferCall(t, meta))
+	err = xt.SignWithMetadata(signature.TestKeyringPairAlice, meta, exampleSignatureOptions)
+	assert.EqualError(t, err, "the runtime uses 20 byte account IDs, an ethereum signer is required")
+
+	signer := NewMultiAddressFromAccountID20(NewAccountID20(newAlith(t).Address()))
+	err = xt.AttachSignature(meta, signer, MultiSignature{IsEcdsa: true}, exampleSignatureOptions)
+	assert.EqualError(t, err, "the runtime requires ethereum signatures")
+}
@@ -0,0 +1,459 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+)
+
+// ApplyExtrinsicResult is the result of applying an extrinsic, as returned by a dry run. The extrinsic is either
+// rejected by the transaction validity checks (AsErr), or it is dispatched (AsOk) and the dispatch itself may fail.
+type ApplyExtrinsicResult struct {
+	IsOk bool
+	AsOk DispatchOutcome
+
+	IsErr bool
+	AsErr TransactionValidityError
+}
+
+func (a *ApplyExtrinsicResult) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0:
+		a.IsOk = true
+
+		return decoder.Decode(&a.AsOk)
+	case 1:
+		a.IsErr = true
+
+		return decoder.Decode(&a.AsErr)
+	}
+
+	return fmt.Errorf("invalid apply extrinsic result variant %d", b)
+}
+
+func (a ApplyExtrinsicResult) Encode(encoder scale.Encoder) error {
+	switch {
+	case a.IsOk:
+		if err := encoder.PushByte(0); err != nil {
+			return err
+		}
+
+		return encoder.Encode(a.AsOk)
+	case a.IsErr:
+		if err := encoder.PushByte(1); err != nil {
+			return err
+		}
+
+		return encoder.Encode(a.AsErr)
+	}
+
+	return nil
+}
+
+// IsSuccess returns true if the extrinsic is valid and was dispatched successfully
+func (a ApplyExtrinsicResult) IsSuccess() bool {
+	return a.IsOk && a.AsOk.IsOk
+}
+
+// Err returns nil if the extrinsic is valid and was dispatched successfully, an *ApplyExtrinsicError otherwise. Module
+// errors are resolved to their name and documentation using the metadata, meta may be nil to skip that step.
+func (a ApplyExtrinsicResult) Err(meta *Metadata) error {
+	switch {
+	case a.IsErr:
+		validityErr := a.AsErr
+
+		return &ApplyExtrinsicError{ValidityError: &validityErr}
+	case a.IsOk && a.AsOk.IsErr:
+		dispatchErr := a.AsOk.AsErr
+
+		res := &ApplyExtrinsicError{DispatchError: &dispatchErr}
+
+		if dispatchErr.IsModule && meta != nil && meta.Version == 14 {
+			metaErr, err := meta.FindError(dispatchErr.ModuleError.Index, dispatchErr.ModuleError.Error)
+			if err == nil {
+				res.ModuleError = metaErr
+			}
+		}
+
+		return res
+	}
+
+	return nil
+}
+
+// DispatchOutcome is the outcome of dispatching a valid extrinsic
+type DispatchOutcome struct {
+	IsOk bool
+
+	IsErr bool
+	AsErr DispatchError
+}
+
+func (d *DispatchOutcome) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0:
+		d.IsOk = true
+
+		return nil
+	case 1:
+		d.IsErr = true
+
+		return decoder.Decode(&d.AsErr)
+	}
+
+	return fmt.Errorf("invalid dispatch outcome variant %d", b)
+}
+
+func (d DispatchOutcome) Encode(encoder scale.Encoder) error {
+	switch {
+	case d.IsOk:
+		return encoder.PushByte(0)
+	case d.IsErr:
+		if err := encoder.PushByte(1); err != nil {
+			return err
+		}
+
+		return encoder.Encode(d.AsErr)
+	}
+
+	return nil
+}
+
+// TransactionValidityError is the reason why an extrinsic was rejected before being dispatched
+type TransactionValidityError struct {
+	IsInvalid bool
+	AsInvalid InvalidTransaction
+
+	IsUnknown bool
+	AsUnknown UnknownTransaction
+}
+
+func (t *TransactionValidityError) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0:
+		t.IsInvalid = true
+
+		return decoder.Decode(&t.AsInvalid)
+	case 1:
+		t.IsUnknown = true
+
+		return decoder.Decode(&t.AsUnknown)
+	}
+
+	return fmt.Errorf("invalid transaction validity error variant %d", b)
+}
+
+func (t TransactionValidityError) Encode(encoder scale.Encoder) error {
+	switch {
+	case t.IsInvalid:
+		if err := encoder.PushByte(0); err != nil {
+			return err
+		}
+
+		return encoder.Encode(t.AsInvalid)
+	case t.IsUnknown:
+		if err := encoder.PushByte(1); err != nil {
+			return err
+		}
+
+		return encoder.Encode(t.AsUnknown)
+	}
+
+	return nil
+}
+
+func (t TransactionValidityError) String() string {
+	switch {
+	case t.IsInvalid:
+		return fmt.Sprintf("invalid transaction: %v", t.AsInvalid)
+	case t.IsUnknown:
+		return fmt.Sprintf("unknown transaction validity: %v", t.AsUnknown)
+	}
+
+	return "unknown transaction validity error"
+}
+
+// InvalidTransaction is the reason why a transaction is invalid
+type InvalidTransaction struct {
+	IsCall bool
+
+	IsPayment bool
+
+	IsFuture bool
+
+	IsStale bool
+
+	IsBadProof bool
+
+	IsAncientBirthBlock bool
+
+	IsExhaustsResources bool
+
+	IsCustom bool
+	AsCustom U8
+
+	IsBadMandatory bool
+
+	IsMandatoryValidation bool
+
+	IsBadSigner bool
+
+	IsIndeterminateImplicit bool
+
+	IsUnknownOrigin bool
+}
+
+func (i *InvalidTransaction) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0:
+		i.IsCall = true
+	case 1:
+		i.IsPayment = true
+	case 2:
+		i.IsFuture = true
+	case 3:
+		i.IsStale = true
+	case 4:
+		i.IsBadProof = true
+	case 5:
+		i.IsAncientBirthBlock = true
+	case 6:
+		i.IsExhaustsResources = true
+	case 7:
+		i.IsCustom = true
+
+		return decoder.Decode(&i.AsCustom)
+	case 8:
+		i.IsBadMandatory = true
+	case 9:
+		i.IsMandatoryValidation = true
+	case 10:
+		i.IsBadSigner = true
+	case 11:
+		i.IsIndeterminateImplicit = true
+	case 12:
+		i.IsUnknownOrigin = true
+	default:
+		return fmt.Errorf("invalid invalid transaction variant %d", b)
+	}
+
+	return nil
+}
+
+func (i InvalidTransaction) Encode(encoder scale.Encoder) error {
+	switch {
+	case i.IsCall:
+		return encoder.PushByte(0)
+	case i.IsPayment:
+		return encoder.PushByte(1)
+	case i.IsFuture:
+		return encoder.PushByte(2)
+	case i.IsStale:
+		return encoder.PushByte(3)
+	case i.IsBadProof:
+		return encoder.PushByte(4)
+	case i.IsAncientBirthBlock:
+		return encoder.PushByte(5)
+	case i.IsExhaustsResources:
+		return encoder.PushByte(6)
+	case i.IsCustom:
+		if err := encoder.PushByte(7); err != nil {
+			return err
+		}
+
+		return encoder.Encode(i.AsCustom)
+	case i.IsBadMandatory:
+		return encoder.PushByte(8)
+	case i.IsMandatoryValidation:
+		return encoder.PushByte(9)
+	case i.IsBadSigner:
+		return encoder.PushByte(10)
+	case i.IsIndeterminateImplicit:
+		return encoder.PushByte(11)
+	case i.IsUnknownOrigin:
+		return encoder.PushByte(12)
+	}
+
+	return nil
+}
+
+func (i InvalidTransaction) String() string {
+	switch {
+	case i.IsCall:
+		return "Call"
+	case i.IsPayment:
+		return "Payment"
+	case i.IsFuture:
+		return "Future"
+	case i.IsStale:
+		return "Stale"
+	case i.IsBadProof:
+		return "BadProof"
+	case i.IsAncientBirthBlock:
+		return "AncientBirthBlock"
+	case i.IsExhaustsResources:
+		return "ExhaustsResources"
+	case i.IsCustom:
+		return fmt.Sprintf("Custom(%d)", i.AsCustom)
+	case i.IsBadMandatory:
+		return "BadMandatory"
+	case i.IsMandatoryValidation:
+		return "MandatoryValidation"
+	case i.IsBadSigner:
+		return "BadSigner"
+	case i.IsIndeterminateImplicit:
+		return "IndeterminateImplicit"
+	case i.IsUnknownOrigin:
+		return "UnknownOrigin"
+	}
+
+	return "Unknown"
+}
+
+// UnknownTransaction is the reason why the validity of a transaction could not be determined
+type UnknownTransaction struct {
+	IsCannotLookup bool
+
+	IsNoUnsignedValidator bool
+
+	IsCustom bool
+	AsCustom U8
+}
+
+func (u *UnknownTransaction) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0:
+		u.IsCannotLookup = true
+	case 1:
+		u.IsNoUnsignedValidator = true
+	case 2:
+		u.IsCustom = true
+
+		return decoder.Decode(&u.AsCustom)
+	default:
+		return fmt.Errorf("invalid unknown transaction variant %d", b)
+	}
+
+	return nil
+}
+
+func (u UnknownTransaction) Encode(encoder scale.Encoder) error {
+	switch {
+	case u.IsCannotLookup:
+		return encoder.PushByte(0)
+	case u.IsNoUnsignedValidator:
+		return encoder.PushByte(1)
+	case u.IsCustom:
+		if err := encoder.PushByte(2); err != nil {
+			return err
+		}
+
+		return encoder.Encode(u.AsCustom)
+	}
+
+	return nil
+}
+
+func (u UnknownTransaction) String() string {
+	switch {
+	case u.IsCannotLookup:
+		return "CannotLookup"
+	case u.IsNoUnsignedValidator:
+		return "NoUnsignedValidator"
+	case u.IsCustom:
+		return fmt.Sprintf("Custom(%d)", u.AsCustom)
+	}
+
+	return "Unknown"
+}
+
+// ApplyExtrinsicError is the error of an ApplyExtrinsicResult, either ValidityError or DispatchError is set.
+// ModuleError holds the resolved module error if DispatchError is a module error that was found in the metadata.
+type ApplyExtrinsicError struct {
+	ValidityError *TransactionValidityError
+	DispatchError *DispatchError
+	ModuleError   *MetadataError
+}
+
+func (a *ApplyExtrinsicError) Error() string {
+	switch {
+	case a.ValidityError != nil:
+		return a.ValidityError.String()
+	case a.ModuleError != nil:
+		return fmt.Sprintf("dispatch error: module %d error %d: %v: %v", a.DispatchError.ModuleError.Index,
+			a.DispatchError.ModuleError.Error, a.ModuleError.Name, a.ModuleError.Value)
+	case a.DispatchError != nil:
+		return fmt.Sprintf("dispatch error: %v", dispatchErrorName(*a.DispatchError))
+	}
+
+	return "apply extrinsic error"
+}
+
+// dispatchErrorName returns the name of the variant of a DispatchError, including the inner error if any
+func dispatchErrorName(d DispatchError) string {
+	switch {
+	case d.IsOther:
+		return "Other"
+	case d.IsCannotLookup:
+		return "CannotLookup"
+	case d.IsBadOrigin:
+		return "BadOrigin"
+	case d.IsModule:
+		return fmt.Sprintf("Module(%d, %d)", d.ModuleError.Index, d.ModuleError.Error)
+	case d.IsConsumerRemaining:
+		return "ConsumerRemaining"
+	case d.IsNoProviders:
+		return "NoProviders"
+	case d.IsTooManyConsumers:
+		return "TooManyConsumers"
+	case d.IsToken:
+		return fmt.Sprintf("Token(%+v)", d.TokenError)
+	case d.IsArithmetic:
+		return fmt.Sprintf("Arithmetic(%+v)", d.ArithmeticError)
+	case d.IsTransactional:
+		return fmt.Sprintf("Transactional(%+v)", d.TransactionalError)
+	}
+
+	return "Unknown"
+}
@@ -0,0 +1,161 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+var (
+	testApplyExtrinsicResultOk = ApplyExtrinsicResult{
+		IsOk: true,
+		AsOk: DispatchOutcome{IsOk: true},
+	}
+	testApplyExtrinsicResultDispatchErr = ApplyExtrinsicResult{
+		IsOk: true,
+		AsOk: DispatchOutcome{IsErr: true, AsErr: DispatchError{IsModule: true, ModuleError: ModuleError{Index: 0,
+			Error: 1}}},
+	}
+	testApplyExtrinsicResultInvalid = ApplyExtrinsicResult{
+		IsErr: true,
+		AsErr: TransactionValidityError{IsInvalid: true, AsInvalid: InvalidTransaction{IsPayment: true}},
+	}
+	testApplyExtrinsicResultInvalidCustom = ApplyExtrinsicResult{
+		IsErr: true,
+		AsErr: TransactionValidityError{IsInvalid: true, AsInvalid: InvalidTransaction{IsCustom: true, AsCustom: 3}},
+	}
+	testApplyExtrinsicResultUnknown = ApplyExtrinsicResult{
+		IsErr: true,
+		AsErr: TransactionValidityError{IsUnknown: true, AsUnknown: UnknownTransaction{IsCannotLookup: true}},
+	}
+)
+
+func TestApplyExtrinsicResult_EncodeDecode(t *testing.T) {
+	assertRoundtrip(t, testApplyExtrinsicResultOk)
+	assertRoundtrip(t, testApplyExtrinsicResultDispatchErr)
+	assertRoundtrip(t, testApplyExtrinsicResultInvalid)
+	assertRoundtrip(t, testApplyExtrinsicResultInvalidCustom)
+	assertRoundtrip(t, testApplyExtrinsicResultUnknown)
+	assertRoundtrip(t, ApplyExtrinsicResult{IsErr: true, AsErr: TransactionValidityError{IsUnknown: true,
+		AsUnknown: UnknownTransaction{IsCustom: true, AsCustom: 9}}})
+}
+
+func TestApplyExtrinsicResult_Encode(t *testing.T) {
+	assertEncode(t, []encodingAssert{
+		{testApplyExtrinsicResultOk, MustHexDecodeString("0x0000")},
+		{testApplyExtrinsicResultDispatchErr, MustHexDecodeString("0x0001030001")},
+		{testApplyExtrinsicResultInvalid, MustHexDecodeString("0x010001")},
+		{testApplyExtrinsicResultInvalidCustom, MustHexDecodeString("0x01000703")},
+		{testApplyExtrinsicResultUnknown, MustHexDecodeString("0x010100")},
+	})
+}
+
+func TestApplyExtrinsicResult_Decode(t *testing.T) {
+	assertDecode(t, []decodingAssert{
+		{MustHexDecodeString("0x0000"), testApplyExtrinsicResultOk},
+		{MustHexDecodeString("0x0001030001"), testApplyExtrinsicResultDispatchErr},
+		{MustHexDecodeString("0x010001"), testApplyExtrinsicResultInvalid},
+		{MustHexDecodeString("0x01000703"), testApplyExtrinsicResultInvalidCustom},
+		{MustHexDecodeString("0x010100"), testApplyExtrinsicResultUnknown},
+	})
+}
+
+func TestApplyExtrinsicResult_Decode_InvalidVariant(t *testing.T) {
+	var res ApplyExtrinsicResult
+	assert.Error(t, Decode(MustHexDecodeString("0x02"), &res))
+
+	var outcome DispatchOutcome
+	assert.Error(t, Decode(MustHexDecodeString("0x05"), &outcome))
+
+	var validityErr TransactionValidityError
+	assert.Error(t, Decode(MustHexDecodeString("0x03"), &validityErr))
+}
+
+func TestInvalidTransaction_Decode_InvalidVariant(t *testing.T) {
+	assertRoundtrip(t, InvalidTransaction{IsIndeterminateImplicit: true})
+	assertRoundtrip(t, InvalidTransaction{IsUnknownOrigin: true})
+	assertDecode(t, []decodingAssert{
+		{MustHexDecodeString("0x0b"), InvalidTransaction{IsIndeterminateImplicit: true}},
+		{MustHexDecodeString("0x0c"), InvalidTransaction{IsUnknownOrigin: true}},
+		{MustHexDecodeString("0x01000c"), ApplyExtrinsicResult{IsErr: true, AsErr: TransactionValidityError{
+			IsInvalid: true, AsInvalid: InvalidTransaction{IsUnknownOrigin: true}}}},
+	})
+	assert.Equal(t, "UnknownOrigin", InvalidTransaction{IsUnknownOrigin: true}.String())
+
+	var invalid InvalidTransaction
+	assert.EqualError(t, Decode(MustHexDecodeString("0x0d"), &invalid), "invalid invalid transaction variant 13")
+	assert.Equal(t, InvalidTransaction{}, invalid)
+
+	var res ApplyExtrinsicResult
+	assert.EqualError(t, Decode(MustHexDecodeString("0x01000d"), &res), "invalid invalid transaction variant 13")
+}
+
+func TestUnknownTransaction_Decode_InvalidVariant(t *testing.T) {
+	var unknown UnknownTransaction
+	assert.EqualError(t, Decode(MustHexDecodeString("0x03"), &unknown), "invalid unknown transaction variant 3")
+	assert.Equal(t, UnknownTransaction{}, unknown)
+
+	var res ApplyExtrinsicResult
+	assert.EqualError(t, Decode(MustHexDecodeString("0x010103"), &res), "invalid unknown transaction variant 3")
+}
+
+func TestApplyExtrinsicResult_Err(t *testing.T) {
+	var meta Metadata
+	err := DecodeFromHex(MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	assert.True(t, testApplyExtrinsicResultOk.IsSuccess())
+	assert.NoError(t, testApplyExtrinsicResultOk.Err(&meta))
+
+	assert.False(t, testApplyExtrinsicResultInvalid.IsSuccess())
+	err = testApplyExtrinsicResultInvalid.Err(&meta)
+	assert.EqualError(t, err, "invalid transaction: Payment")
+
+	applyErr, ok := err.(*ApplyExtrinsicError)
+	assert.True(t, ok)
+	assert.True(t, applyErr.ValidityError.AsInvalid.IsPayment)
+	assert.Nil(t, applyErr.DispatchError)
+
+	err = testApplyExtrinsicResultInvalidCustom.Err(nil)
+	assert.EqualError(t, err, "invalid transaction: Custom(3)")
+
+	err = testApplyExtrinsicResultUnknown.Err(nil)
+	assert.EqualError(t, err, "unknown transaction validity: CannotLookup")
+
+	// System - SpecVersionNeedsToIncrease
+	assert.False(t, testApplyExtrinsicResultDispatchErr.IsSuccess())
+	err = testApplyExtrinsicResultDispatchErr.Err(&meta)
+	assert.Error(t, err)
+
+	applyErr, ok = err.(*ApplyExtrinsicError)
+	assert.True(t, ok)
+	assert.Nil(t, applyErr.ValidityError)
+	assert.True(t, applyErr.DispatchError.IsModule)
+	assert.Equal(t, "SpecVersionNeedsToIncrease", applyErr.ModuleError.Name)
+	assert.Contains(t, err.Error(), "SpecVersionNeedsToIncrease")
+
+	err = testApplyExtrinsicResultDispatchErr.Err(nil)
+	assert.EqualError(t, err, "dispatch error: Module(0, 1)")
+	assert.Nil(t, err.(*ApplyExtrinsicError).ModuleError)
+
+	res := ApplyExtrinsicResult{IsOk: true, AsOk: DispatchOutcome{IsErr: true, AsErr: DispatchError{IsBadOrigin: true}}}
+	assert.EqualError(t, res.Err(&meta), "dispatch error: BadOrigin")
+}
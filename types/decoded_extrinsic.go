@@ -0,0 +1,429 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+)
+
+// DecodedCall is a call that is decoded using the metadata. Args holds the arguments of the call with the names found
+// in the metadata, their values are decoded as described in MetadataV14.DecodeValue. Arguments that are calls
+// themselves, such as the calls of utility.batch, sudo.sudo or proxy.proxy, are decoded recursively into a
+// DecodedCall.
+type DecodedCall struct {
+	CallIndex CallIndex
+	Pallet    string
+	Name      string
+	Args      DynamicComposite
+}
+
+// Arg returns the value of the argument with the given name
+func (c DecodedCall) Arg(name string) (interface{}, bool) {
+	for _, arg := range c.Args {
+		if arg.Name == name {
+			return arg.Value, true
+		}
+	}
+
+	return nil, false
+}
+
+// DecodedExtrinsic is an extrinsic that is decoded using the metadata. Signature is set for signed extrinsics,
+// GeneralExtensions for general transactions (extrinsic v5), both are nil for bare (unsigned) extrinsics.
+type DecodedExtrinsic struct {
+	Version           byte
+	Signature         *DecodedExtrinsicSignature
+	ExtensionVersion  byte
+	GeneralExtensions *DecodedExtensions
+	Call              DecodedCall
+}
+
+// IsSigned returns true if the extrinsic is signed
+func (e DecodedExtrinsic) IsSigned() bool {
+	return e.Signature != nil
+}
+
+// IsGeneral returns true if the extrinsic is a general transaction
+func (e DecodedExtrinsic) IsGeneral() bool {
+	return e.GeneralExtensions != nil
+}
+
+// DecodedExtrinsicSignature is the signature of an extrinsic together with the extra data of all signed extensions.
+type DecodedExtrinsicSignature struct {
+	Signer    MultiAddress
+	Signature MultiSignature
+	DecodedExtensions
+}
+
+// DecodedExtensions is the extra data of all signed (or transaction) extensions. Era, Nonce and Tip are taken from the
+// CheckMortality, CheckNonce and ChargeTransactionPayment (or ChargeAssetTxPayment) extensions, they are left empty if
+// the runtime does not use them. Extensions holds the decoded extra data of every extension, named by its identifier.
+type DecodedExtensions struct {
+	Era        ExtrinsicEra
+	Nonce      UCompact
+	Tip        UCompact
+	Extensions DynamicComposite
+}
+
+// DecodeCall decodes the call using the metadata into the pallet name, the call name and the named arguments
+func DecodeCall(meta *Metadata, call Call) (DecodedCall, error) {
+	d, err := newCallDecoder(meta)
+	if err != nil {
+		return DecodedCall{}, err
+	}
+
+	b, err := Encode(call)
+	if err != nil {
+		return DecodedCall{}, err
+	}
+
+	r := bytes.NewReader(b)
+
+	res, err := d.decodeCall(*scale.NewDecoder(r), 0)
+	if err != nil {
+		return DecodedCall{}, err
+	}
+
+	if r.Len() > 0 {
+		return DecodedCall{}, fmt.Errorf("%d bytes left after decoding call %v.%v", r.Len(), res.Pallet, res.Name)
+	}
+
+	return res, nil
+}
+
+// DecodeExtrinsic decodes the extrinsic using the metadata. Unlike Extrinsic.Decode, the extra data of the signature
+// and the extensions of general transactions are decoded as defined by the signed extensions of the metadata.
+func DecodeExtrinsic(meta *Metadata, xt Extrinsic) (DecodedExtrinsic, error) {
+	d, err := newCallDecoder(meta)
+	if err != nil {
+		return DecodedExtrinsic{}, err
+	}
+
+	return d.decodeExtrinsic(xt)
+}
+
+// DecodeExtrinsics decodes all extrinsics of the block using the metadata, see DecodeExtrinsic
+func (b Block) DecodeExtrinsics(meta *Metadata) ([]DecodedExtrinsic, error) {
+	d, err := newCallDecoder(meta)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]DecodedExtrinsic, 0, len(b.Extrinsics))
+
+	for i, xt := range b.Extrinsics {
+		decoded, err := d.decodeExtrinsic(xt)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode extrinsic %d: %w", i, err)
+		}
+
+		res = append(res, decoded)
+	}
+
+	return res, nil
+}
+
+// DecodeExtrinsics decodes all extrinsics of the block using the metadata, see DecodeExtrinsic
+func (b SignedBlock) DecodeExtrinsics(meta *Metadata) ([]DecodedExtrinsic, error) {
+	return b.Block.DecodeExtrinsics(meta)
+}
+
+// callDecoder decodes calls, nested calls are detected by the type ID of the runtime call type.
+type callDecoder struct {
+	meta       *MetadataV14
+	callTypeID int64
+}
+
+func newCallDecoder(meta *Metadata) (*callDecoder, error) {
+	if meta.Version != 14 {
+		return nil, fmt.Errorf("decoding calls is only supported from metadata v14, got v%d", meta.Version)
+	}
+
+	m := &meta.AsMetadataV14
+
+	callTypeID, err := m.runtimeCallTypeID()
+	if err != nil {
+		return nil, err
+	}
+
+	return &callDecoder{meta: m, callTypeID: callTypeID}, nil
+}
+
+// runtimeCallTypeID returns the type ID of the runtime call, the enum of the calls of all pallets. It is taken from
+// the Call parameter of the extrinsic type if present, otherwise it is the variant type that has a variant holding
+// the calls of each pallet.
+func (m *MetadataV14) runtimeCallTypeID() (int64, error) {
+	if xt, ok := m.EfficientLookup[m.Extrinsic.Type.Int64()]; ok {
+		for _, param := range xt.Params {
+			if string(param.Name) == "Call" && param.HasType {
+				return param.Type.Int64(), nil
+			}
+		}
+	}
+
+	// the IDs are sorted, so that the same type is found on every run if several types qualify
+	ids := make([]int64, 0, len(m.EfficientLookup))
+	for id := range m.EfficientLookup {
+		ids = append(ids, id)
+	}
+
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for _, id := range ids {
+		typ := m.EfficientLookup[id]
+		if typ.Def.IsVariant && m.isRuntimeCallVariant(typ.Def.Variant) {
+			return id, nil
+		}
+	}
+
+	return 0, errors.New("runtime call type not found in metadata")
+}
+
+func (m *MetadataV14) isRuntimeCallVariant(def Si1TypeDefVariant) bool {
+	found := false
+
+	for _, pallet := range m.Pallets {
+		if !pallet.HasCalls {
+			continue
+		}
+
+		ok := false
+
+		for _, variant := range def.Variants {
+			if variant.Index == pallet.Index && len(variant.Fields) == 1 &&
+				variant.Fields[0].Type.Int64() == pallet.Calls.Type.Int64() {
+				ok = true
+				break
+			}
+		}
+
+		if !ok {
+			return false
+		}
+
+		found = true
+	}
+
+	return found
+}
+
+func (d *callDecoder) decodeExtrinsic(xt Extrinsic) (DecodedExtrinsic, error) {
+	// the extrinsic is encoded again, since Extrinsic.Decode assumes a fixed layout of the extra data
+	b, err := Encode(xt)
+	if err != nil {
+		return DecodedExtrinsic{}, err
+	}
+
+	r := bytes.NewReader(b)
+	decoder := scale.NewDecoder(r)
+
+	_, err = decoder.DecodeUintCompact()
+	if err != nil {
+		return DecodedExtrinsic{}, err
+	}
+
+	res := DecodedExtrinsic{}
+
+	err = decoder.Decode(&res.Version)
+	if err != nil {
+		return DecodedExtrinsic{}, err
+	}
+
+	switch {
+	case xt.IsSigned():
+		res.Signature, err = d.decodeSignature(b, r)
+		if err != nil {
+			return DecodedExtrinsic{}, err
+		}
+	case xt.IsGeneral():
+		err = decoder.Decode(&res.ExtensionVersion)
+		if err != nil {
+			return DecodedExtrinsic{}, err
+		}
+
+		res.GeneralExtensions, err = d.decodeExtensions(b, r)
+		if err != nil {
+			return DecodedExtrinsic{}, err
+		}
+	}
+
+	res.Call, err = d.decodeCall(*decoder, 0)
+	if err != nil {
+		return DecodedExtrinsic{}, err
+	}
+
+	if r.Len() > 0 {
+		return DecodedExtrinsic{}, fmt.Errorf("%d bytes left after decoding extrinsic", r.Len())
+	}
+
+	return res, nil
+}
+
+// decodeSignature decodes the signature and the extra data of the signed extensions from r, b holds all the bytes of
+// r.
+func (d *callDecoder) decodeSignature(b []byte, r *bytes.Reader) (*DecodedExtrinsicSignature, error) {
+	decoder := scale.NewDecoder(r)
+
+	sig := &DecodedExtrinsicSignature{}
+
+	var err error
+
+	if d.meta.isAccountID20Address() {
+		sig.Signer.IsAccountID20 = true
+		err = decoder.Decode(&sig.Signer.AsAccountID20)
+	} else {
+		err = decoder.Decode(&sig.Signer)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if d.meta.usesEthereumSignature() {
+		sig.Signature.IsEthereum = true
+		err = decoder.Decode(&sig.Signature.AsEthereum)
+	} else {
+		err = decoder.Decode(&sig.Signature)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	extensions, err := d.decodeExtensions(b, r)
+	if err != nil {
+		return nil, err
+	}
+
+	sig.DecodedExtensions = *extensions
+
+	return sig, nil
+}
+
+// decodeExtensions decodes the extra data of the signed extensions from r, b holds all the bytes of r. The extra data
+// of each extension is decoded a second time from b for the typed Era, Nonce and Tip.
+func (d *callDecoder) decodeExtensions(b []byte, r *bytes.Reader) (*DecodedExtensions, error) {
+	decoder := scale.NewDecoder(r)
+
+	res := &DecodedExtensions{Extensions: make(DynamicComposite, 0, len(d.meta.Extrinsic.SignedExtensions))}
+
+	for _, ext := range d.meta.Extrinsic.SignedExtensions {
+		identifier := string(ext.Identifier)
+		start := len(b) - r.Len()
+
+		v, err := d.meta.DecodeValue(*decoder, ext.Type)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode extra of signed extension %v: %w", identifier, err)
+		}
+
+		res.Extensions = append(res.Extensions, DynamicField{Name: identifier, Value: v})
+
+		extra := b[start : len(b)-r.Len()]
+
+		switch identifier {
+		case "CheckMortality", "CheckEra":
+			err = Decode(extra, &res.Era)
+		case "CheckNonce":
+			err = Decode(extra, &res.Nonce)
+		case "ChargeTransactionPayment", "ChargeAssetTxPayment":
+			// the tip is the first field of both extensions
+			err = Decode(extra, &res.Tip)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode extra of signed extension %v: %w", identifier, err)
+		}
+	}
+
+	return res, nil
+}
+
+func (d *callDecoder) decodeCall(decoder scale.Decoder, depth int) (DecodedCall, error) {
+	if depth > maxDynamicValueDepth {
+		return DecodedCall{}, errors.New("max depth reached while decoding call")
+	}
+
+	var callIndex CallIndex
+
+	err := decoder.Decode(&callIndex)
+	if err != nil {
+		return DecodedCall{}, err
+	}
+
+	for _, pallet := range d.meta.Pallets {
+		if !pallet.HasCalls || uint8(pallet.Index) != callIndex.SectionIndex {
+			continue
+		}
+
+		typ, ok := d.meta.EfficientLookup[pallet.Calls.Type.Int64()]
+		if !ok || !typ.Def.IsVariant {
+			return DecodedCall{}, fmt.Errorf("call type of pallet %v not found in metadata", pallet.Name)
+		}
+
+		for _, variant := range typ.Def.Variant.Variants {
+			if uint8(variant.Index) != callIndex.MethodIndex {
+				continue
+			}
+
+			res := DecodedCall{
+				CallIndex: callIndex,
+				Pallet:    string(pallet.Name),
+				Name:      string(variant.Name),
+				Args:      make(DynamicComposite, 0, len(variant.Fields)),
+			}
+
+			for _, field := range variant.Fields {
+				v, err := d.decodeValue(decoder, field.Type.Int64(), depth+1)
+				if err != nil {
+					return DecodedCall{}, fmt.Errorf("unable to decode argument %v of call %v.%v: %w", field.Name,
+						res.Pallet, res.Name, err)
+				}
+
+				res.Args = append(res.Args, DynamicField{Name: string(field.Name), Value: v})
+			}
+
+			return res, nil
+		}
+
+		return DecodedCall{}, fmt.Errorf("call %d of pallet %v not found in metadata", callIndex.MethodIndex,
+			pallet.Name)
+	}
+
+	return DecodedCall{}, fmt.Errorf("pallet %d with calls not found in metadata", callIndex.SectionIndex)
+}
+
+// decodeValue decodes a value like MetadataV14.DecodeValue, values of the runtime call type are decoded into a
+// DecodedCall.
+func (d *callDecoder) decodeValue(decoder scale.Decoder, typeID int64, depth int) (interface{}, error) {
+	return d.meta.decodeValue(decoder, typeID, depth, d.decodeRuntimeCall)
+}
+
+// decodeRuntimeCall is the decodeHook of the callDecoder, it decodes values of the runtime call type
+func (d *callDecoder) decodeRuntimeCall(decoder scale.Decoder, typeID int64, depth int) (interface{}, bool, error) {
+	if typeID != d.callTypeID {
+		return nil, false, nil
+	}
+
+	call, err := d.decodeCall(decoder, depth+1)
+
+	return call, true, err
+}
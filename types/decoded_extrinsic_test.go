@@ -0,0 +1,329 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestTransferCall(t *testing.T, meta *Metadata) Call {
+	c, err := NewCall(meta, "Balances.transfer", NewMultiAddressFromAccountID(signature.TestKeyringPairBob.PublicKey),
+		NewUCompactFromUInt(6969))
+	assert.NoError(t, err)
+	return c
+}
+
+func assertTransferCall(t *testing.T, c interface{}) {
+	call, ok := c.(DecodedCall)
+	assert.True(t, ok)
+	assert.Equal(t, "Balances", call.Pallet)
+	assert.Equal(t, "transfer", call.Name)
+	assert.Equal(t, CallIndex{SectionIndex: 6, MethodIndex: 0}, call.CallIndex)
+
+	dest, ok := call.Arg("dest")
+	assert.True(t, ok)
+	assert.Equal(t, DynamicVariant{Name: "Id", Fields: DynamicComposite{
+		{Value: DynamicComposite{{Value: signature.TestKeyringPairBob.PublicKey}}}}}, dest)
+
+	value, ok := call.Arg("value")
+	assert.True(t, ok)
+	assert.Equal(t, NewUCompactFromUInt(6969), value)
+}
+
+func TestDecodeCall(t *testing.T) {
+	meta := decodeMetadataV14(t)
+
+	call, err := DecodeCall(meta, newTestTransferCall(t, meta))
+	assert.NoError(t, err)
+	assertTransferCall(t, call)
+
+	_, ok := call.Arg("unknown")
+	assert.False(t, ok)
+}
+
+func TestDecodeCall_Nested(t *testing.T) {
+	meta := decodeMetadataV14(t)
+
+	transfer := newTestTransferCall(t, meta)
+
+	remark, err := NewCall(meta, "System.remark", []byte{1, 2, 3})
+	assert.NoError(t, err)
+
+	batch, err := NewCall(meta, "Utility.batch", []Call{transfer, remark})
+	assert.NoError(t, err)
+
+	sudo, err := NewCall(meta, "Sudo.sudo", batch)
+	assert.NoError(t, err)
+
+	// the real account is an AccountId in this runtime
+	proxy, err := NewCall(meta, "Proxy.proxy", NewAccountID(signature.TestKeyringPairAlice.PublicKey),
+		NewOptionBytesEmpty(), sudo)
+	assert.NoError(t, err)
+
+	call, err := DecodeCall(meta, proxy)
+	assert.NoError(t, err)
+	assert.Equal(t, "Proxy", call.Pallet)
+	assert.Equal(t, "proxy", call.Name)
+
+	forceProxyType, ok := call.Arg("force_proxy_type")
+	assert.True(t, ok)
+	assert.Equal(t, DynamicVariant{Name: "None", Fields: DynamicComposite{}}, forceProxyType)
+
+	inner, ok := call.Arg("call")
+	assert.True(t, ok)
+
+	sudoCall, ok := inner.(DecodedCall)
+	assert.True(t, ok)
+	assert.Equal(t, "Sudo", sudoCall.Pallet)
+	assert.Equal(t, "sudo", sudoCall.Name)
+
+	inner, ok = sudoCall.Arg("call")
+	assert.True(t, ok)
+
+	batchCall, ok := inner.(DecodedCall)
+	assert.True(t, ok)
+	assert.Equal(t, "Utility", batchCall.Pallet)
+	assert.Equal(t, "batch", batchCall.Name)
+
+	calls, ok := batchCall.Arg("calls")
+	assert.True(t, ok)
+	assert.Len(t, calls, 2)
+
+	assertTransferCall(t, calls.([]interface{})[0])
+
+	remarkCall := calls.([]interface{})[1].(DecodedCall)
+	assert.Equal(t, "System", remarkCall.Pallet)
+	assert.Equal(t, "remark", remarkCall.Name)
+	assert.Equal(t, DynamicComposite{{Name: "remark", Value: []byte{1, 2, 3}}}, remarkCall.Args)
+}
+
+func TestDecodeCall_WithoutCallParam(t *testing.T) {
+	meta := decodeMetadataV14(t)
+	m := &meta.AsMetadataV14
+
+	// the runtime call type is looked up by its variants if the extrinsic type has no Call parameter
+	xt := m.EfficientLookup[m.Extrinsic.Type.Int64()]
+	callTypeID := int64(-1)
+	for i, param := range xt.Params {
+		if string(param.Name) == "Call" {
+			callTypeID = param.Type.Int64()
+			xt.Params[i].Name = "RuntimeCall"
+		}
+	}
+	assert.NotEqual(t, int64(-1), callTypeID)
+
+	// a copy of the runtime call type with a higher ID must not be picked up instead of it
+	maxID := callTypeID
+	for id := range m.EfficientLookup {
+		if id > maxID {
+			maxID = id
+		}
+	}
+	m.EfficientLookup[maxID+1] = m.EfficientLookup[callTypeID]
+
+	remark, err := NewCall(meta, "System.remark", []byte{1, 2, 3})
+	assert.NoError(t, err)
+
+	sudo, err := NewCall(meta, "Sudo.sudo", remark)
+	assert.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		call, err := DecodeCall(meta, sudo)
+		assert.NoError(t, err)
+
+		inner, ok := call.Arg("call")
+		assert.True(t, ok)
+
+		remarkCall, ok := inner.(DecodedCall)
+		assert.True(t, ok)
+		assert.Equal(t, "remark", remarkCall.Name)
+	}
+}
+
+func TestDecodeCall_Errors(t *testing.T) {
+	meta := decodeMetadataV14(t)
+
+	_, err := DecodeCall(meta, Call{CallIndex: CallIndex{SectionIndex: 250}})
+	assert.EqualError(t, err, "pallet 250 with calls not found in metadata")
+
+	_, err = DecodeCall(meta, Call{CallIndex: CallIndex{SectionIndex: 6, MethodIndex: 250}})
+	assert.EqualError(t, err, "call 250 of pallet Balances not found in metadata")
+
+	// missing arguments
+	_, err = DecodeCall(meta, Call{CallIndex: CallIndex{SectionIndex: 6, MethodIndex: 0}})
+	assert.Error(t, err)
+
+	// trailing bytes
+	c := newTestTransferCall(t, meta)
+	c.Args = append(c.Args, 0x01)
+	_, err = DecodeCall(meta, c)
+	assert.EqualError(t, err, "1 bytes left after decoding call Balances.transfer")
+
+	_, err = DecodeCall(&Metadata{Version: 13}, c)
+	assert.EqualError(t, err, "decoding calls is only supported from metadata v14, got v13")
+}
+
+func TestDecodeExtrinsic(t *testing.T) {
+	meta := decodeMetadataV14(t)
+
+	xt := NewExtrinsic(newTestTransferCall(t, meta))
+
+	decoded, err := DecodeExtrinsic(meta, xt)
+	assert.NoError(t, err)
+	assert.False(t, decoded.IsSigned())
+	assert.Equal(t, byte(ExtrinsicVersion4), decoded.Version)
+	assertTransferCall(t, decoded.Call)
+
+	o := exampleSignatureOptions
+	o.Era = NewMortalEra(64, 1000)
+
+	err = xt.SignWithMetadata(signature.TestKeyringPairAlice, meta, o)
+	assert.NoError(t, err)
+
+	// the extrinsic is decoded statically first, like the extrinsics of a block
+	enc, err := EncodeToHex(xt)
+	assert.NoError(t, err)
+
+	var static Extrinsic
+	err = DecodeFromHex(enc, &static)
+	assert.NoError(t, err)
+
+	decoded, err = DecodeExtrinsic(meta, static)
+	assert.NoError(t, err)
+	assert.True(t, decoded.IsSigned())
+	assert.Equal(t, byte(ExtrinsicVersion4|ExtrinsicBitSigned), decoded.Version)
+	assertTransferCall(t, decoded.Call)
+
+	sig := decoded.Signature
+	assert.Equal(t, NewMultiAddressFromAccountID(signature.TestKeyringPairAlice.PublicKey), sig.Signer)
+	assert.Equal(t, xt.Signature.Signature, sig.Signature)
+	assert.Equal(t, o.Era, sig.Era)
+	assert.Equal(t, o.Nonce, sig.Nonce)
+	assert.Equal(t, o.Tip, sig.Tip)
+	assert.Len(t, sig.Extensions, len(meta.AsMetadataV14.Extrinsic.SignedExtensions))
+	assert.Equal(t, "ChargeAssetTxPayment", sig.Extensions[len(sig.Extensions)-1].Name)
+	assert.Equal(t, DynamicComposite{
+		{Name: "tip", Value: o.Tip},
+		{Name: "asset_id", Value: DynamicVariant{Name: "None", Fields: DynamicComposite{}}},
+	}, sig.Extensions[len(sig.Extensions)-1].Value)
+}
+
+// newTestGeneralExtrinsic creates a general transaction of a transfer with the extensions of exampleSignatureOptions
+func newTestGeneralExtrinsic(t *testing.T, meta *Metadata) Extrinsic {
+	extensions, _, err := EncodeSignedExtensions(meta, exampleSignatureOptions)
+	assert.NoError(t, err)
+
+	return Extrinsic{
+		Version:    ExtrinsicVersion5 | ExtrinsicBitGeneral,
+		Extensions: extensions,
+		Method:     newTestTransferCall(t, meta),
+	}
+}
+
+func TestDecodeExtrinsic_General(t *testing.T) {
+	meta := decodeMetadataV14(t)
+
+	xt := newTestGeneralExtrinsic(t, meta)
+	assert.True(t, xt.IsGeneral())
+
+	// the general transaction is decoded statically first, like the extrinsics of a block
+	enc, err := EncodeToHex(xt)
+	assert.NoError(t, err)
+
+	var static Extrinsic
+	err = DecodeFromHex(enc, &static)
+	assert.NoError(t, err)
+
+	decoded, err := DecodeExtrinsic(meta, static)
+	assert.NoError(t, err)
+	assert.True(t, decoded.IsGeneral())
+	assert.False(t, decoded.IsSigned())
+	assert.Equal(t, byte(ExtrinsicVersion5|ExtrinsicBitGeneral), decoded.Version)
+	assert.Equal(t, byte(0), decoded.ExtensionVersion)
+	assert.Equal(t, exampleSignatureOptions.Nonce, decoded.GeneralExtensions.Nonce)
+	assert.Equal(t, exampleSignatureOptions.Tip, decoded.GeneralExtensions.Tip)
+	assert.Equal(t, ExtrinsicEra{IsImmortalEra: true}, decoded.GeneralExtensions.Era)
+	assertTransferCall(t, decoded.Call)
+
+	bare := Extrinsic{Version: ExtrinsicVersion5, Method: newTestTransferCall(t, meta)}
+
+	decoded, err = DecodeExtrinsic(meta, bare)
+	assert.NoError(t, err)
+	assert.False(t, decoded.IsGeneral())
+	assert.False(t, decoded.IsSigned())
+	assertTransferCall(t, decoded.Call)
+}
+
+func TestExtrinsic_Decode_GeneralInStream(t *testing.T) {
+	meta := decodeMetadataV14(t)
+	general := newTestGeneralExtrinsic(t, meta)
+
+	timestamp, err := NewCall(meta, "Timestamp.set", NewUCompactFromUInt(1650000000000))
+	assert.NoError(t, err)
+
+	enc, err := Encode([]Extrinsic{general, NewExtrinsic(timestamp)})
+	assert.NoError(t, err)
+
+	var xts []Extrinsic
+	err = Decode(enc, &xts)
+	assert.NoError(t, err)
+	assert.Len(t, xts, 2)
+
+	decoded, err := DecodeExtrinsic(meta, xts[0])
+	assert.NoError(t, err)
+	assert.True(t, decoded.IsGeneral())
+	assertTransferCall(t, decoded.Call)
+
+	assert.Equal(t, NewExtrinsic(timestamp), xts[1])
+
+	// the length prefix does not cover the extension version
+	err = DecodeFromHex("0x04450000", &Extrinsic{})
+	assert.EqualError(t, err, "invalid extrinsic length 1")
+}
+
+func TestSignedBlock_DecodeExtrinsics(t *testing.T) {
+	meta := decodeMetadataV14(t)
+
+	timestamp, err := NewCall(meta, "Timestamp.set", NewUCompactFromUInt(1650000000000))
+	assert.NoError(t, err)
+
+	transfer := NewExtrinsic(newTestTransferCall(t, meta))
+	err = transfer.SignWithMetadata(signature.TestKeyringPairAlice, meta, exampleSignatureOptions)
+	assert.NoError(t, err)
+
+	block := SignedBlock{Block: Block{Extrinsics: []Extrinsic{NewExtrinsic(timestamp), transfer}}}
+
+	decoded, err := block.DecodeExtrinsics(meta)
+	assert.NoError(t, err)
+	assert.Len(t, decoded, 2)
+
+	assert.False(t, decoded[0].IsSigned())
+	assert.Equal(t, "Timestamp", decoded[0].Call.Pallet)
+	assert.Equal(t, "set", decoded[0].Call.Name)
+
+	assert.True(t, decoded[1].IsSigned())
+	assert.Equal(t, ExtrinsicEra{IsImmortalEra: true}, decoded[1].Signature.Era)
+	assertTransferCall(t, decoded[1].Call)
+
+	block.Block.Extrinsics = append(block.Block.Extrinsics, NewExtrinsic(Call{CallIndex: CallIndex{SectionIndex: 250}}))
+	_, err = block.DecodeExtrinsics(meta)
+	assert.EqualError(t, err, "unable to decode extrinsic 2: pallet 250 with calls not found in metadata")
+}
@@ -0,0 +1,732 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+)
+
+const (
+	maxDynamicValueDepth = 64
+	// maxDynamicSequenceLen limits the allocation for sequences of malformed input
+	maxDynamicSequenceLen = 1 << 24
+)
+
+// DynamicField is a single, optionally named, field of a DynamicComposite.
+type DynamicField struct {
+	Name  string
+	Value interface{}
+}
+
+// DynamicComposite is a value for a composite type of the metadata. The fields are matched to the fields of the type
+// by position, if a name is set it must be equal to the name of the field found in the metadata.
+type DynamicComposite []DynamicField
+
+// DynamicVariant is a value for a variant (enum) type of the metadata, the variant is selected by its name.
+type DynamicVariant struct {
+	Name   string
+	Fields DynamicComposite
+}
+
+// EncodeValue encodes a value as the type with the given ID of the portable type registry.
+//
+// Besides DynamicComposite and DynamicVariant, the following values are supported:
+//   - any Go integer, *big.Int, U128, U256, I128, I256 and UCompact for integer primitives and compact types, the
+//     value is converted to the width found in the metadata
+//   - bool, string (or Text) and rune for the remaining primitives
+//   - slices and arrays for sequences, arrays and tuples, []bool for bit sequences
+//   - Go structs for composites and tuples, the fields are matched by position
+//   - a string for a variant without fields, nil for the None variant of an Option
+//   - a value that is not a DynamicComposite for a composite with a single field, e.g. an AccountID for AccountId32
+//
+// Values implementing scale.Encodeable are encoded as they are, unless the type is a primitive or compact.
+func (m *MetadataV14) EncodeValue(encoder scale.Encoder, typeID Si1LookupTypeID, value interface{}) error {
+	return m.encodeValue(encoder, typeID.Int64(), value, 0)
+}
+
+func (m *MetadataV14) encodeValue(encoder scale.Encoder, typeID int64, value interface{}, depth int) error { //nolint:funlen,gocyclo,lll
+	if depth > maxDynamicValueDepth {
+		return fmt.Errorf("max depth reached while encoding type %d", typeID)
+	}
+
+	typ, ok := m.EfficientLookup[typeID]
+	if !ok {
+		return fmt.Errorf("type %d not found in metadata", typeID)
+	}
+
+	def := typ.Def
+
+	if enc, ok := value.(scale.Encodeable); ok && !def.IsPrimitive && !def.IsCompact {
+		return enc.Encode(encoder)
+	}
+
+	switch {
+	case def.IsComposite:
+		return m.encodeComposite(encoder, typeID, def.Composite.Fields, value, depth)
+	case def.IsVariant:
+		return m.encodeVariant(encoder, typeID, def.Variant.Variants, value, depth)
+	case def.IsSequence:
+		rv := reflect.ValueOf(value)
+		if value == nil || (rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array) {
+			return fmt.Errorf("type %d is a sequence, but got %T", typeID, value)
+		}
+
+		if err := encoder.EncodeUintCompact(*big.NewInt(int64(rv.Len()))); err != nil {
+			return err
+		}
+
+		for i := 0; i < rv.Len(); i++ {
+			if err := m.encodeValue(encoder, def.Sequence.Type.Int64(), rv.Index(i).Interface(), depth+1); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case def.IsArray:
+		rv := reflect.ValueOf(value)
+		if value == nil || (rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array) {
+			return fmt.Errorf("type %d is an array, but got %T", typeID, value)
+		}
+
+		if rv.Len() != int(def.Array.Len) {
+			return fmt.Errorf("type %d is an array of length %d, but got %d elements", typeID, def.Array.Len, rv.Len())
+		}
+
+		for i := 0; i < rv.Len(); i++ {
+			if err := m.encodeValue(encoder, def.Array.Type.Int64(), rv.Index(i).Interface(), depth+1); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case def.IsTuple:
+		elems, err := dynamicValueElems(value)
+		if err != nil {
+			return fmt.Errorf("type %d is a tuple: %w", typeID, err)
+		}
+
+		if len(elems) != len(def.Tuple) {
+			return fmt.Errorf("type %d is a tuple of %d elements, but got %d", typeID, len(def.Tuple), len(elems))
+		}
+
+		for i, elem := range def.Tuple {
+			if err := m.encodeValue(encoder, elem.Int64(), elems[i], depth+1); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case def.IsPrimitive:
+		return encodeDynamicPrimitive(encoder, def.Primitive.Si0TypeDefPrimitive, value)
+	case def.IsCompact:
+		i, err := dynamicValueToBigInt(value)
+		if err != nil {
+			return fmt.Errorf("type %d is compact: %w", typeID, err)
+		}
+
+		if i.Sign() < 0 {
+			return fmt.Errorf("type %d is compact, but got negative value %v", typeID, i)
+		}
+
+		return encoder.EncodeUintCompact(*i)
+	case def.IsBitSequence:
+		return m.encodeBitSequence(encoder, typeID, def.BitSequence, value)
+	default:
+		return fmt.Errorf("type %d has an unsupported type definition", typeID)
+	}
+}
+
+// DecodeValue decodes a value of the type with the given ID of the portable type registry. The decoded values can be
+// encoded again with EncodeValue:
+//   - composites are decoded into a DynamicComposite, variants into a DynamicVariant
+//   - sequences and arrays are decoded into a []byte if their elements are u8, otherwise into an []interface{}
+//   - tuples are decoded into an []interface{}, bit sequences into a []bool
+//   - primitives are decoded into bool, rune, string, uint8 - uint64, U128, U256, int8 - int64, I128 and I256
+//   - compact types are decoded into a UCompact
+func (m *MetadataV14) DecodeValue(decoder scale.Decoder, typeID Si1LookupTypeID) (interface{}, error) {
+	return m.decodeValue(decoder, typeID.Int64(), 0, nil)
+}
+
+// decodeHook decodes values of types that need special handling, it returns false for all other types. See
+// callDecoder.
+type decodeHook func(decoder scale.Decoder, typeID int64, depth int) (interface{}, bool, error)
+
+// decodeValue decodes a value as described in DecodeValue, values of types that are handled by the hook are decoded
+// by the hook, the hook may be nil.
+func (m *MetadataV14) decodeValue(decoder scale.Decoder, typeID int64, depth int, hook decodeHook) (interface{}, error) { //nolint:funlen,gocyclo,lll
+	if depth > maxDynamicValueDepth {
+		return nil, fmt.Errorf("max depth reached while decoding type %d", typeID)
+	}
+
+	if hook != nil {
+		v, ok, err := hook(decoder, typeID, depth)
+		if ok || err != nil {
+			return v, err
+		}
+	}
+
+	typ, ok := m.EfficientLookup[typeID]
+	if !ok {
+		return nil, fmt.Errorf("type %d not found in metadata", typeID)
+	}
+
+	def := typ.Def
+
+	switch {
+	case def.IsComposite:
+		return m.decodeFields(decoder, typeID, def.Composite.Fields, depth, hook)
+	case def.IsVariant:
+		b, err := decoder.ReadOneByte()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, variant := range def.Variant.Variants {
+			if byte(variant.Index) != b {
+				continue
+			}
+
+			fields, err := m.decodeFields(decoder, typeID, variant.Fields, depth, hook)
+			if err != nil {
+				return nil, err
+			}
+
+			return DynamicVariant{Name: string(variant.Name), Fields: fields}, nil
+		}
+
+		return nil, fmt.Errorf("variant with index %d not found in type %d", b, typeID)
+	case def.IsSequence:
+		n, err := decoder.DecodeUintCompact()
+		if err != nil {
+			return nil, err
+		}
+
+		if !n.IsUint64() || n.Uint64() > maxDynamicSequenceLen {
+			return nil, fmt.Errorf("type %d is a sequence of invalid length %v", typeID, n)
+		}
+
+		return m.decodeElems(decoder, def.Sequence.Type.Int64(), int(n.Uint64()), depth, hook)
+	case def.IsArray:
+		return m.decodeElems(decoder, def.Array.Type.Int64(), int(def.Array.Len), depth, hook)
+	case def.IsTuple:
+		elems := make([]interface{}, 0, len(def.Tuple))
+
+		for _, elem := range def.Tuple {
+			v, err := m.decodeValue(decoder, elem.Int64(), depth+1, hook)
+			if err != nil {
+				return nil, err
+			}
+
+			elems = append(elems, v)
+		}
+
+		return elems, nil
+	case def.IsPrimitive:
+		return decodeDynamicPrimitive(decoder, def.Primitive.Si0TypeDefPrimitive)
+	case def.IsCompact:
+		i, err := decoder.DecodeUintCompact()
+		if err != nil {
+			return nil, err
+		}
+
+		return NewUCompact(i), nil
+	case def.IsBitSequence:
+		return m.decodeBitSequence(decoder, typeID, def.BitSequence)
+	default:
+		return nil, fmt.Errorf("type %d has an unsupported type definition", typeID)
+	}
+}
+
+func (m *MetadataV14) decodeFields(decoder scale.Decoder, typeID int64, fields []Si1Field, depth int,
+	hook decodeHook) (DynamicComposite, error) {
+	values := make(DynamicComposite, 0, len(fields))
+
+	for i, field := range fields {
+		v, err := m.decodeValue(decoder, field.Type.Int64(), depth+1, hook)
+		if err != nil {
+			return nil, fmt.Errorf("field %d of type %d: %w", i, typeID, err)
+		}
+
+		df := DynamicField{Value: v}
+		if field.HasName {
+			df.Name = string(field.Name)
+		}
+
+		values = append(values, df)
+	}
+
+	return values, nil
+}
+
+// decodeElems decodes n elements of a sequence or an array, u8 elements are decoded into a []byte.
+func (m *MetadataV14) decodeElems(decoder scale.Decoder, elemTypeID int64, n int, depth int,
+	hook decodeHook) (interface{}, error) {
+	if elem, ok := m.EfficientLookup[elemTypeID]; ok && elem.Def.IsPrimitive &&
+		elem.Def.Primitive.Si0TypeDefPrimitive == IsU8 {
+		b := make([]byte, n)
+		if n == 0 {
+			return b, nil
+		}
+
+		if err := decoder.Read(b); err != nil {
+			return nil, err
+		}
+
+		return b, nil
+	}
+
+	// the capacity is limited, the length might come from malformed input
+	elems := make([]interface{}, 0, minInt(n, 1024))
+
+	for i := 0; i < n; i++ {
+		v, err := m.decodeValue(decoder, elemTypeID, depth+1, hook)
+		if err != nil {
+			return nil, err
+		}
+
+		elems = append(elems, v)
+	}
+
+	return elems, nil
+}
+
+func (m *MetadataV14) decodeBitSequence(decoder scale.Decoder, typeID int64,
+	def Si1TypeDefBitSequence) (interface{}, error) {
+	storeLen, msb0, err := m.bitSequenceLayout(typeID, def)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := decoder.DecodeUintCompact()
+	if err != nil {
+		return nil, err
+	}
+
+	if !n.IsUint64() || n.Uint64() > maxDynamicSequenceLen {
+		return nil, fmt.Errorf("type %d is a bit sequence of invalid length %v", typeID, n)
+	}
+
+	storeBits := storeLen * 8
+	bits := make([]bool, n.Uint64())
+
+	if len(bits) == 0 {
+		return bits, nil
+	}
+
+	b := make([]byte, (len(bits)+storeBits-1)/storeBits*storeLen)
+	if err := decoder.Read(b); err != nil {
+		return nil, err
+	}
+
+	for i := range bits {
+		pos := i % storeBits
+		if msb0 {
+			pos = storeBits - 1 - pos
+		}
+
+		bits[i] = b[i/storeBits*storeLen+pos/8]&(1<<(pos%8)) != 0
+	}
+
+	return bits, nil
+}
+
+func (m *MetadataV14) encodeComposite(encoder scale.Encoder, typeID int64, fields []Si1Field, value interface{},
+	depth int) error {
+	if dc, ok := value.(DynamicComposite); ok {
+		return m.encodeFields(encoder, typeID, fields, dc, depth)
+	}
+
+	if value == nil && len(fields) == 0 {
+		return nil
+	}
+
+	rv := reflect.ValueOf(value)
+
+	if value != nil && rv.Kind() == reflect.Struct && rv.NumField() == len(fields) {
+		elems, err := dynamicValueElems(value)
+		if err != nil {
+			return err
+		}
+
+		for i, field := range fields {
+			if err := m.encodeValue(encoder, field.Type.Int64(), elems[i], depth+1); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if len(fields) == 1 {
+		return m.encodeValue(encoder, fields[0].Type.Int64(), value, depth+1)
+	}
+
+	return fmt.Errorf("type %d is a composite of %d fields, but got %T", typeID, len(fields), value)
+}
+
+func (m *MetadataV14) encodeFields(encoder scale.Encoder, typeID int64, fields []Si1Field, values DynamicComposite,
+	depth int) error {
+	if len(values) != len(fields) {
+		return fmt.Errorf("type %d has %d fields, but got %d", typeID, len(fields), len(values))
+	}
+
+	for i, field := range fields {
+		if values[i].Name != "" && field.HasName && values[i].Name != string(field.Name) {
+			return fmt.Errorf("expected field %d of type %d to be %s, but got %s", i, typeID, field.Name,
+				values[i].Name)
+		}
+
+		if err := m.encodeValue(encoder, field.Type.Int64(), values[i].Value, depth+1); err != nil {
+			return fmt.Errorf("field %d of type %d: %w", i, typeID, err)
+		}
+	}
+
+	return nil
+}
+
+func (m *MetadataV14) encodeVariant(encoder scale.Encoder, typeID int64, variants []Si1Variant, value interface{},
+	depth int) error {
+	var dv DynamicVariant
+
+	switch v := value.(type) {
+	case DynamicVariant:
+		dv = v
+	case string:
+		dv = DynamicVariant{Name: v}
+	case nil:
+		dv = DynamicVariant{Name: "None"}
+	default:
+		return fmt.Errorf("type %d is a variant, but got %T", typeID, value)
+	}
+
+	for _, variant := range variants {
+		if string(variant.Name) != dv.Name {
+			continue
+		}
+
+		if err := encoder.PushByte(byte(variant.Index)); err != nil {
+			return err
+		}
+
+		return m.encodeFields(encoder, typeID, variant.Fields, dv.Fields, depth)
+	}
+
+	return fmt.Errorf("variant %s not found in type %d", dv.Name, typeID)
+}
+
+func (m *MetadataV14) encodeBitSequence(encoder scale.Encoder, typeID int64, def Si1TypeDefBitSequence,
+	value interface{}) error {
+	bits, ok := value.([]bool)
+	if !ok {
+		return fmt.Errorf("type %d is a bit sequence, but got %T", typeID, value)
+	}
+
+	storeLen, msb0, err := m.bitSequenceLayout(typeID, def)
+	if err != nil {
+		return err
+	}
+
+	if err := encoder.EncodeUintCompact(*big.NewInt(int64(len(bits)))); err != nil {
+		return err
+	}
+
+	storeBits := storeLen * 8
+	b := make([]byte, (len(bits)+storeBits-1)/storeBits*storeLen)
+
+	for i, bit := range bits {
+		if !bit {
+			continue
+		}
+
+		// the stores are little endian encoded, the order only affects the bits within a store
+		pos := i % storeBits
+		if msb0 {
+			pos = storeBits - 1 - pos
+		}
+
+		b[i/storeBits*storeLen+pos/8] |= 1 << (pos % 8)
+	}
+
+	return encoder.Write(b)
+}
+
+// bitSequenceLayout returns the length in bytes of the bit store and whether the bit order is Msb0.
+func (m *MetadataV14) bitSequenceLayout(typeID int64, def Si1TypeDefBitSequence) (storeLen int, msb0 bool,
+	err error) {
+	store, ok := m.EfficientLookup[def.BitStoreType.Int64()]
+	if !ok || !store.Def.IsPrimitive {
+		return 0, false, fmt.Errorf("bit store of type %d is not a primitive", typeID)
+	}
+
+	switch store.Def.Primitive.Si0TypeDefPrimitive {
+	case IsU8:
+		storeLen = 1
+	case IsU16:
+		storeLen = 2
+	case IsU32:
+		storeLen = 4
+	case IsU64:
+		storeLen = 8
+	default:
+		return 0, false, fmt.Errorf("unsupported bit store of type %d", typeID)
+	}
+
+	if order, ok := m.EfficientLookup[def.BitOrderType.Int64()]; ok && len(order.Path) > 0 {
+		msb0 = order.Path[len(order.Path)-1] == "Msb0"
+	}
+
+	return storeLen, msb0, nil
+}
+
+func encodeDynamicPrimitive(encoder scale.Encoder, primitive Si0TypeDefPrimitive, value interface{}) error {
+	switch primitive {
+	case IsBool:
+		b, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("expected a bool, but got %T", value)
+		}
+
+		return encoder.Encode(b)
+	case IsChar:
+		r, ok := value.(rune)
+		if !ok {
+			return fmt.Errorf("expected a rune, but got %T", value)
+		}
+
+		return encoder.Encode(uint32(r))
+	case IsStr:
+		rv := reflect.ValueOf(value)
+		if value == nil || rv.Kind() != reflect.String {
+			return fmt.Errorf("expected a string, but got %T", value)
+		}
+
+		return encoder.Encode(rv.String())
+	}
+
+	i, err := dynamicValueToBigInt(value)
+	if err != nil {
+		return err
+	}
+
+	var b []byte
+
+	switch primitive {
+	case IsU8:
+		b, err = BigIntToUintBytes(i, 1)
+	case IsU16:
+		b, err = BigIntToUintBytes(i, 2)
+	case IsU32:
+		b, err = BigIntToUintBytes(i, 4)
+	case IsU64:
+		b, err = BigIntToUintBytes(i, 8)
+	case IsU128:
+		b, err = BigIntToUintBytes(i, 16)
+	case IsU256:
+		b, err = BigIntToUintBytes(i, 32)
+	case IsI8:
+		b, err = BigIntToIntBytes(i, 1)
+	case IsI16:
+		b, err = BigIntToIntBytes(i, 2)
+	case IsI32:
+		b, err = BigIntToIntBytes(i, 4)
+	case IsI64:
+		b, err = BigIntToIntBytes(i, 8)
+	case IsI128:
+		b, err = BigIntToIntBytes(i, 16)
+	case IsI256:
+		b, err = BigIntToIntBytes(i, 32)
+	default:
+		return fmt.Errorf("unsupported primitive %d", primitive)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// reverse bytes, scale uses little-endian encoding, big.int's bytes are expected in big-endian
+	scale.Reverse(b)
+
+	return encoder.Write(b)
+}
+
+func decodeDynamicPrimitive(decoder scale.Decoder, primitive Si0TypeDefPrimitive) (interface{}, error) { //nolint:funlen,gocyclo,lll
+	var (
+		target interface{}
+		err    error
+	)
+
+	switch primitive {
+	case IsBool:
+		var v bool
+		err = decoder.Decode(&v)
+		target = v
+	case IsChar:
+		var v uint32
+		err = decoder.Decode(&v)
+		target = rune(v)
+	case IsStr:
+		var v string
+		err = decoder.Decode(&v)
+		target = v
+	case IsU8:
+		var v uint8
+		err = decoder.Decode(&v)
+		target = v
+	case IsU16:
+		var v uint16
+		err = decoder.Decode(&v)
+		target = v
+	case IsU32:
+		var v uint32
+		err = decoder.Decode(&v)
+		target = v
+	case IsU64:
+		var v uint64
+		err = decoder.Decode(&v)
+		target = v
+	case IsU128:
+		var v U128
+		err = decoder.Decode(&v)
+		target = v
+	case IsU256:
+		var v U256
+		err = decoder.Decode(&v)
+		target = v
+	case IsI8:
+		var v int8
+		err = decoder.Decode(&v)
+		target = v
+	case IsI16:
+		var v int16
+		err = decoder.Decode(&v)
+		target = v
+	case IsI32:
+		var v int32
+		err = decoder.Decode(&v)
+		target = v
+	case IsI64:
+		var v int64
+		err = decoder.Decode(&v)
+		target = v
+	case IsI128:
+		var v I128
+		err = decoder.Decode(&v)
+		target = v
+	case IsI256:
+		var v I256
+		err = decoder.Decode(&v)
+		target = v
+	default:
+		return nil, fmt.Errorf("unsupported primitive %d", primitive)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return target, nil
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// dynamicValueToBigInt converts any of the supported integer values to a big.Int.
+func dynamicValueToBigInt(value interface{}) (*big.Int, error) {
+	var i *big.Int
+
+	switch v := value.(type) {
+	case *big.Int:
+		i = v
+	case big.Int:
+		i = &v
+	case UCompact:
+		i = (*big.Int)(&v)
+	case U128:
+		i = v.Int
+	case U256:
+		i = v.Int
+	case I128:
+		i = v.Int
+	case I256:
+		i = v.Int
+	default:
+		rv := reflect.ValueOf(value)
+
+		switch {
+		case value == nil:
+			return nil, fmt.Errorf("expected an integer, but got nil")
+		case rv.CanInt():
+			return big.NewInt(rv.Int()), nil
+		case rv.CanUint():
+			return new(big.Int).SetUint64(rv.Uint()), nil
+		default:
+			return nil, fmt.Errorf("expected an integer, but got %T", value)
+		}
+	}
+
+	if i == nil {
+		return big.NewInt(0), nil
+	}
+
+	return i, nil
+}
+
+// dynamicValueElems returns the elements of a slice, an array or the fields of a struct.
+func dynamicValueElems(value interface{}) ([]interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	var elems []interface{}
+
+	if dc, ok := value.(DynamicComposite); ok {
+		for _, field := range dc {
+			elems = append(elems, field.Value)
+		}
+
+		return elems, nil
+	}
+
+	rv := reflect.ValueOf(value)
+
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			elems = append(elems, rv.Index(i).Interface())
+		}
+	case reflect.Struct:
+		for i := 0; i < rv.NumField(); i++ {
+			if !rv.Field(i).CanInterface() {
+				return nil, fmt.Errorf("field %d of %T is not exported", i, value)
+			}
+
+			elems = append(elems, rv.Field(i).Interface())
+		}
+	default:
+		return nil, fmt.Errorf("expected a slice, an array or a struct, but got %T", value)
+	}
+
+	return elems, nil
+}
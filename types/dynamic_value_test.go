@@ -0,0 +1,251 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	dynU8 = iota
+	dynU32
+	dynCompactU32
+	dynVecU8
+	dynArrayU32
+	dynTuple
+	dynComposite
+	dynOption
+	dynBool
+	dynStr
+	dynI16
+	dynBitVec
+	dynLsb0
+	dynAccount
+	dynU128
+)
+
+func dynField(name string, typeID int) Si1Field {
+	return Si1Field{HasName: name != "", Name: Text(name), Type: NewSi1LookupTypeIDFromUInt(uint64(typeID))}
+}
+
+func dynTypeID(typeID int) Si1LookupTypeID {
+	return NewSi1LookupTypeIDFromUInt(uint64(typeID))
+}
+
+func newDynamicValueMetadata() *MetadataV14 {
+	return &MetadataV14{
+		EfficientLookup: map[int64]*Si1Type{
+			dynU8:         {Def: Si1TypeDef{IsPrimitive: true, Primitive: Si1TypeDefPrimitive{IsU8}}},
+			dynU32:        {Def: Si1TypeDef{IsPrimitive: true, Primitive: Si1TypeDefPrimitive{IsU32}}},
+			dynCompactU32: {Def: Si1TypeDef{IsCompact: true, Compact: Si1TypeDefCompact{Type: dynTypeID(dynU32)}}},
+			dynVecU8:      {Def: Si1TypeDef{IsSequence: true, Sequence: Si1TypeDefSequence{Type: dynTypeID(dynU8)}}},
+			dynArrayU32:   {Def: Si1TypeDef{IsArray: true, Array: Si1TypeDefArray{Len: 2, Type: dynTypeID(dynU32)}}},
+			dynTuple: {Def: Si1TypeDef{
+				IsTuple: true,
+				Tuple:   Si1TypeDefTuple{dynTypeID(dynU8), dynTypeID(dynU32)},
+			}},
+			dynComposite: {Def: Si1TypeDef{
+				IsComposite: true,
+				Composite:   Si1TypeDefComposite{Fields: []Si1Field{dynField("a", dynU8), dynField("b", dynCompactU32)}},
+			}},
+			dynOption: {Def: Si1TypeDef{
+				IsVariant: true,
+				Variant: Si1TypeDefVariant{Variants: []Si1Variant{
+					{Name: "None", Index: 0},
+					{Name: "Some", Index: 1, Fields: []Si1Field{dynField("", dynU32)}},
+				}},
+			}},
+			dynBool: {Def: Si1TypeDef{IsPrimitive: true, Primitive: Si1TypeDefPrimitive{IsBool}}},
+			dynStr:  {Def: Si1TypeDef{IsPrimitive: true, Primitive: Si1TypeDefPrimitive{IsStr}}},
+			dynI16:  {Def: Si1TypeDef{IsPrimitive: true, Primitive: Si1TypeDefPrimitive{IsI16}}},
+			dynBitVec: {Def: Si1TypeDef{
+				IsBitSequence: true,
+				BitSequence:   Si1TypeDefBitSequence{BitStoreType: dynTypeID(dynU8), BitOrderType: dynTypeID(dynLsb0)},
+			}},
+			dynLsb0: {Path: Si1Path{"bitvec", "order", "Lsb0"}, Def: Si1TypeDef{IsComposite: true}},
+			dynAccount: {Def: Si1TypeDef{
+				IsComposite: true,
+				Composite:   Si1TypeDefComposite{Fields: []Si1Field{dynField("", dynVecU8)}},
+			}},
+			dynU128: {Def: Si1TypeDef{IsPrimitive: true, Primitive: Si1TypeDefPrimitive{IsU128}}},
+		},
+	}
+}
+
+func encodeDynamicValue(m *MetadataV14, typeID int, value interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+
+	err := m.EncodeValue(*scale.NewEncoder(&buf), dynTypeID(typeID), value)
+
+	return buf.Bytes(), err
+}
+
+func TestMetadataV14_EncodeValue(t *testing.T) {
+	m := newDynamicValueMetadata()
+
+	tests := []struct {
+		name     string
+		typeID   int
+		value    interface{}
+		expected []byte
+	}{
+		{"u8", dynU8, 1, []byte{1}},
+		{"u8 from U8", dynU8, NewU8(7), []byte{7}},
+		{"u32 from uint64", dynU32, uint64(258), []byte{2, 1, 0, 0}},
+		{"u32 from big.Int", dynU32, big.NewInt(258), []byte{2, 1, 0, 0}},
+		{"u128 from U128", dynU128, NewU128(*big.NewInt(1)), append([]byte{1}, make([]byte, 15)...)},
+		{"i16", dynI16, -2, []byte{0xfe, 0xff}},
+		{"compact", dynCompactU32, 64, []byte{0x01, 0x01}},
+		{"compact from U32", dynCompactU32, NewU32(1), []byte{0x04}},
+		{"compact from UCompact", dynCompactU32, NewUCompactFromUInt(1), []byte{0x04}},
+		{"bool", dynBool, true, []byte{1}},
+		{"str", dynStr, "ab", []byte{0x08, 'a', 'b'}},
+		{"str from Text", dynStr, NewText("ab"), []byte{0x08, 'a', 'b'}},
+		{"sequence", dynVecU8, []byte{1, 2}, []byte{0x08, 1, 2}},
+		{"sequence from Bytes", dynVecU8, NewBytes([]byte{1, 2}), []byte{0x08, 1, 2}},
+		{"array", dynArrayU32, [2]int{1, 2}, []byte{1, 0, 0, 0, 2, 0, 0, 0}},
+		{"tuple", dynTuple, []interface{}{1, 2}, []byte{1, 2, 0, 0, 0}},
+		{"tuple from struct", dynTuple, struct {
+			A uint8
+			B uint32
+		}{1, 2}, []byte{1, 2, 0, 0, 0}},
+		{"composite", dynComposite, DynamicComposite{{"a", 1}, {"b", 1}}, []byte{1, 0x04}},
+		{"composite from struct", dynComposite, struct {
+			A U8
+			B UCompact
+		}{1, NewUCompactFromUInt(1)}, []byte{1, 0x04}},
+		{"single field composite", dynAccount, []byte{1}, []byte{0x04, 1}},
+		{"variant", dynOption, DynamicVariant{"Some", DynamicComposite{{"", 2}}}, []byte{1, 2, 0, 0, 0}},
+		{"unit variant", dynOption, "None", []byte{0}},
+		{"nil as None", dynOption, nil, []byte{0}},
+		{"bit sequence", dynBitVec, []bool{true, false, true, false, false, false, false, false, true},
+			[]byte{0x24, 0x05, 0x01}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			encoded, err := encodeDynamicValue(m, test.typeID, test.value)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, encoded)
+		})
+	}
+}
+
+func TestMetadataV14_EncodeValue_Errors(t *testing.T) {
+	m := newDynamicValueMetadata()
+
+	tests := []struct {
+		name   string
+		typeID int
+		value  interface{}
+	}{
+		{"unknown type", 100, 1},
+		{"u8 overflow", dynU8, 256},
+		{"u8 negative", dynU8, -1},
+		{"u8 from string", dynU8, "1"},
+		{"compact negative", dynCompactU32, -1},
+		{"bool from int", dynBool, 1},
+		{"array length", dynArrayU32, []int{1}},
+		{"tuple length", dynTuple, []int{1}},
+		{"composite fields", dynComposite, DynamicComposite{{"a", 1}}},
+		{"composite field name", dynComposite, DynamicComposite{{"a", 1}, {"c", 1}}},
+		{"unknown variant", dynOption, "Other"},
+		{"variant fields", dynOption, DynamicVariant{Name: "Some"}},
+		{"sequence from int", dynVecU8, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := encodeDynamicValue(m, test.typeID, test.value)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestMetadataV14_DecodeValue(t *testing.T) {
+	m := newDynamicValueMetadata()
+
+	tests := []struct {
+		name     string
+		typeID   int
+		encoded  []byte
+		expected interface{}
+	}{
+		{"u8", dynU8, []byte{1}, uint8(1)},
+		{"u32", dynU32, []byte{2, 1, 0, 0}, uint32(258)},
+		{"u128", dynU128, append([]byte{1}, make([]byte, 15)...), NewU128(*big.NewInt(1))},
+		{"i16", dynI16, []byte{0xfe, 0xff}, int16(-2)},
+		{"compact", dynCompactU32, []byte{0x01, 0x01}, NewUCompactFromUInt(64)},
+		{"bool", dynBool, []byte{1}, true},
+		{"str", dynStr, []byte{0x08, 'a', 'b'}, "ab"},
+		{"sequence of u8", dynVecU8, []byte{0x08, 1, 2}, []byte{1, 2}},
+		{"empty sequence", dynVecU8, []byte{0x00}, []byte{}},
+		{"array", dynArrayU32, []byte{1, 0, 0, 0, 2, 0, 0, 0}, []interface{}{uint32(1), uint32(2)}},
+		{"tuple", dynTuple, []byte{1, 2, 0, 0, 0}, []interface{}{uint8(1), uint32(2)}},
+		{"composite", dynComposite, []byte{1, 0x04},
+			DynamicComposite{{"a", uint8(1)}, {"b", NewUCompactFromUInt(1)}}},
+		{"single field composite", dynAccount, []byte{0x04, 1}, DynamicComposite{{"", []byte{1}}}},
+		{"variant", dynOption, []byte{1, 2, 0, 0, 0}, DynamicVariant{"Some", DynamicComposite{{"", uint32(2)}}}},
+		{"unit variant", dynOption, []byte{0}, DynamicVariant{"None", DynamicComposite{}}},
+		{"bit sequence", dynBitVec, []byte{0x24, 0x05, 0x01},
+			[]bool{true, false, true, false, false, false, false, false, true}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			decoder := scale.NewDecoder(bytes.NewReader(test.encoded))
+
+			value, err := m.DecodeValue(*decoder, dynTypeID(test.typeID))
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, value)
+
+			// decoded values can be encoded again
+			encoded, err := encodeDynamicValue(m, test.typeID, value)
+			assert.NoError(t, err)
+			assert.Equal(t, test.encoded, encoded)
+		})
+	}
+}
+
+func TestMetadataV14_DecodeValue_Errors(t *testing.T) {
+	m := newDynamicValueMetadata()
+
+	tests := []struct {
+		name    string
+		typeID  int
+		encoded []byte
+	}{
+		{"unknown type", 100, []byte{1}},
+		{"eof", dynU32, []byte{1}},
+		{"unknown variant", dynOption, []byte{2}},
+		{"sequence too short", dynVecU8, []byte{0x08, 1}},
+		{"tuple element", dynTuple, []byte{1}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := m.DecodeValue(*scale.NewDecoder(bytes.NewReader(test.encoded)), dynTypeID(test.typeID))
+			assert.Error(t, err)
+		})
+	}
+}
@@ -74,13 +74,17 @@ const (
 	// Unsigned means that the result was submitted and accepted to the chain via
 	// an unsigned transaction (by an authority).
 	Unsigned ElectionCompute = 2
+	// Fallback means that the result was computed by the fallback election provider.
+	Fallback ElectionCompute = 3
+	// Emergency means that the result was submitted by a privileged origin after the election failed.
+	Emergency ElectionCompute = 4
 )
 
 func (ec *ElectionCompute) Decode(decoder scale.Decoder) error {
 	b, err := decoder.ReadOneByte()
 	vb := ElectionCompute(b)
 	switch vb {
-	case OnChain, Signed, Unsigned:
+	case OnChain, Signed, Unsigned, Fallback, Emergency:
 		*ec = vb
 	default:
 		return fmt.Errorf("unknown ElectionCompute enum: %v", vb)
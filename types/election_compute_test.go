@@ -30,7 +30,7 @@ import (
 var (
 	electionComputeFuzzOpts = []fuzzOpt{
 		withFuzzFuncs(func(e *ElectionCompute, c fuzz.Continue) {
-			*e = ElectionCompute(c.Intn(3))
+			*e = ElectionCompute(c.Intn(5))
 		}),
 	}
 
@@ -63,6 +63,7 @@ func TestOptionElectionCompute_Encode(t *testing.T) {
 		{NewOptionElectionCompute(NewElectionCompute(byte(0))), MustHexDecodeString("0x0100")},
 		{NewOptionElectionCompute(NewElectionCompute(byte(1))), MustHexDecodeString("0x0101")},
 		{NewOptionElectionCompute(NewElectionCompute(byte(2))), MustHexDecodeString("0x0102")},
+		{NewOptionElectionCompute(Emergency), MustHexDecodeString("0x0104")},
 		{NewOptionBytesEmpty(), MustHexDecodeString("0x00")},
 	})
 }
@@ -72,6 +73,8 @@ func TestOptionElectionCompute_Decode(t *testing.T) {
 		{MustHexDecodeString("0x0100"), NewOptionElectionCompute(NewElectionCompute(byte(0)))},
 		{MustHexDecodeString("0x0101"), NewOptionElectionCompute(NewElectionCompute(byte(1)))},
 		{MustHexDecodeString("0x0102"), NewOptionElectionCompute(NewElectionCompute(byte(2)))},
+		{MustHexDecodeString("0x0103"), NewOptionElectionCompute(Fallback)},
+		{MustHexDecodeString("0x0104"), NewOptionElectionCompute(Emergency)},
 		{MustHexDecodeString("0x00"), NewOptionBytesEmpty()},
 	})
 }
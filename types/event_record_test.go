@@ -20,6 +20,8 @@ import (
 	"bytes"
 	"fmt"
 	"math/big"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
@@ -344,3 +346,333 @@ func TestPhase(t *testing.T) {
 	assertDecodeNilData[Phase](t)
 	assertEncodeEmptyObj[Phase](t, 0)
 }
+
+var (
+	// eventRecordsFuzzOpts holds the fuzz options for all the types that are used by the events of EventRecords.
+	eventRecordsFuzzOpts = combineFuzzOpts(
+		addressFuzzOpts,
+		arithmeticErrorFuzzOpts,
+		assetIDFuzzOpts,
+		assetInstanceFuzzOpts,
+		balanceStatusFuzzOpts,
+		bodyIDFuzzOpts,
+		bodyPartFuzzOpts,
+		changesTrieSignalFuzzOpts,
+		currencyIDFuzzOpts,
+		democracyConvictionFuzzOpts,
+		digestItemFuzzOpts,
+		dispatchClassFuzzOpts,
+		dispatchErrorFuzzOpts,
+		dispatchInfoFuzzOpts,
+		dispatchResultFuzzOpts,
+		dispatchResultWithPostInfoFuzzOpts,
+		disputeLocationFuzzOpts,
+		disputeResultFuzzOpts,
+		electionComputeFuzzOpts,
+		executionResultFuzzOpts,
+		fungibilityFuzzOpts,
+		instanceDetailsFuzzOpts,
+		instructionFuzzOpts,
+		junctionV0FuzzOpts,
+		junctionV1FuzzOpts,
+		junctionsV1FuzzOpts,
+		migrationComputeFuzzOpts,
+		multiAddressFuzzOpts,
+		multiAssetFilterFuzzOpts,
+		multiAssetV0FuzzOpts,
+		multiAssetV1FuzzOpts,
+		multiLocationV0FuzzOpts,
+		multiLocationV1FuzzOpts,
+		multiSignatureFuzzOpts,
+		networkIDFuzzOpts,
+		optionAccountIDFuzzOpts,
+		optionElectionComputeFuzzOpts,
+		optionExecutionResultFuzzOpts,
+		optionMultiLocationV1FuzzOpts,
+		optionU128FuzzOpts,
+		optionWeightFuzzOpts,
+		originKindFuzzOpts,
+		outcomeFuzzOpts,
+		paysFuzzOpts,
+		phaseFuzzOpts,
+		proxyTypeFuzzOpts,
+		responseFuzzOpts,
+		schedulerLookupErrorFuzzOpts,
+		tokenErrorFuzzOpts,
+		transactionalErrorFuzzOpts,
+		versionedMultiAssetsFuzzOpts,
+		versionedMultiLocationFuzzOpts,
+		voteAccountVoteFuzzOpts,
+		voteThresholdFuzzOpts,
+		weightLimitFuzzOpts,
+		wildFungibilityFuzzOpts,
+		wildMultiAssetFuzzOpts,
+		xcmErrorFuzzOpts,
+	)
+)
+
+// newEventRecordsMetadata creates metadata that holds one module per pallet and one event per field of EventRecords.
+// It allows decoding synthetic event records that contain any of the events of EventRecords.
+func newEventRecordsMetadata() (*Metadata, map[string]EventID) {
+	meta := NewMetadataV13()
+	eventIDs := make(map[string]EventID)
+	moduleIndexes := make(map[string]int)
+
+	typ := reflect.TypeOf(EventRecords{})
+
+	for i := 0; i < typ.NumField(); i++ {
+		parts := strings.Split(typ.Field(i).Name, "_")
+		moduleName, eventName := parts[0], parts[1]
+
+		moduleIndex, ok := moduleIndexes[moduleName]
+
+		if !ok {
+			moduleIndex = len(meta.AsMetadataV13.Modules)
+			moduleIndexes[moduleName] = moduleIndex
+
+			meta.AsMetadataV13.Modules = append(meta.AsMetadataV13.Modules, ModuleMetadataV13{
+				Name:      NewText(moduleName),
+				HasEvents: true,
+				Index:     uint8(moduleIndex),
+			})
+		}
+
+		module := &meta.AsMetadataV13.Modules[moduleIndex]
+
+		eventIDs[typ.Field(i).Name] = EventID{module.Index, uint8(len(module.Events))}
+
+		module.Events = append(module.Events, EventMetadataV4{Name: NewText(eventName)})
+	}
+
+	return meta, eventIDs
+}
+
+func TestEventRecords_RoundTrip(t *testing.T) {
+	meta, eventIDs := newEventRecordsMetadata()
+
+	f := fuzz.New().NilChance(0)
+
+	for _, opt := range eventRecordsFuzzOpts {
+		opt(f)
+	}
+
+	typ := reflect.TypeOf(EventRecords{})
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+
+		t.Run(field.Name, func(t *testing.T) {
+			for j := 0; j < 10; j++ {
+				event := reflect.New(field.Type.Elem()).Elem()
+
+				f.Fuzz(event.Addr().Interface())
+
+				encodedEvent, err := Encode(event.Interface())
+				assert.NoError(t, err)
+
+				encodedPhase, err := Encode(event.Field(0).Interface())
+				assert.NoError(t, err)
+
+				eventID := eventIDs[field.Name]
+
+				// The encoded event holds the phase, the event fields and the topics, the event ID is placed
+				// right after the phase.
+				var raw []byte
+				raw = append(raw, 0x04)
+				raw = append(raw, encodedPhase...)
+				raw = append(raw, eventID[:]...)
+				raw = append(raw, encodedEvent[len(encodedPhase):]...)
+
+				var records EventRecords
+
+				err = EventRecordsRaw(raw).DecodeEventRecords(meta, &records)
+				assert.NoError(t, err)
+
+				decoded := reflect.ValueOf(records).FieldByName(field.Name)
+				assert.Equal(t, 1, decoded.Len())
+				assert.Equal(t, event.Interface(), decoded.Index(0).Interface())
+			}
+		})
+	}
+}
+
+func TestEncodeEventRecords(t *testing.T) {
+	var meta Metadata
+	err := DecodeFromHex(MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	events := EventRecords{
+		System_ExtrinsicSuccess: []EventSystemExtrinsicSuccess{exampleEventFin, exampleEventApp},
+		Balances_Transfer: []EventBalancesTransfer{
+			{
+				Phase:  Phase{IsInitialization: true},
+				From:   NewAccountID([]byte{1}),
+				To:     NewAccountID([]byte{2}),
+				Value:  NewU128(*big.NewInt(1000)),
+				Topics: nil,
+			},
+		},
+	}
+
+	encoded, err := EncodeEventRecords(&meta, &events)
+	assert.NoError(t, err)
+
+	var decoded EventRecords
+	err = encoded.DecodeEventRecords(&meta, &decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, events.Balances_Transfer, decoded.Balances_Transfer)
+	// the records are sorted by phase
+	assert.Equal(t, []EventSystemExtrinsicSuccess{exampleEventApp, exampleEventFin}, decoded.System_ExtrinsicSuccess)
+	assert.Equal(t, byte(0x0c), encoded[0])
+	assert.Equal(t, byte(2), encoded[1], "first record is emitted during initialization")
+}
+
+func TestEncodeEventRecords_Dynamic(t *testing.T) {
+	var meta Metadata
+	err := DecodeFromHex(MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	records := []EventRecord{
+		{
+			Phase:  examplePhaseApp,
+			Module: "Balances",
+			Event:  "Transfer",
+			Fields: []interface{}{NewAccountID([]byte{1}), []byte{2, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, 1000},
+			Topics: []Hash{{1, 2}},
+		},
+		{
+			Phase:  examplePhaseFin,
+			Module: "System",
+			Event:  "ExtrinsicSuccess",
+			Fields: []interface{}{exampleEventFin.DispatchInfo},
+			Topics: []Hash{{1, 2}},
+		},
+	}
+
+	encoded, err := EncodeEventRecords(&meta, records)
+	assert.NoError(t, err)
+
+	var decoded EventRecords
+	err = encoded.DecodeEventRecords(&meta, &decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, []EventBalancesTransfer{
+		{
+			Phase:  examplePhaseApp,
+			From:   NewAccountID([]byte{1}),
+			To:     NewAccountID([]byte{2}),
+			Value:  NewU128(*big.NewInt(1000)),
+			Topics: []Hash{{1, 2}},
+		},
+	}, decoded.Balances_Transfer)
+	assert.Equal(t, []EventSystemExtrinsicSuccess{exampleEventFin}, decoded.System_ExtrinsicSuccess)
+}
+
+func TestEncodeEventRecords_UnknownEvent(t *testing.T) {
+	var meta Metadata
+	err := DecodeFromHex(MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	_, err = EncodeEventRecords(&meta, []EventRecord{{Module: "Balances", Event: "Unknown"}})
+	assert.Error(t, err)
+
+	_, err = EncodeEventRecords(&meta, []EventRecord{{Module: "Unknown", Event: "Transfer"}})
+	assert.Error(t, err)
+
+	_, err = EncodeEventRecords(&meta, EventRecords{
+		Auctions_AuctionStarted: []EventAuctionsAuctionStarted{{}},
+	})
+	assert.EqualError(t, err, "unable to find event Auctions_AuctionStarted in metadata: module Auctions not "+
+		"found in metadata")
+}
+
+func TestEncodeEventRecords_WrongFields(t *testing.T) {
+	var meta Metadata
+	err := DecodeFromHex(MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	_, err = EncodeEventRecords(&meta, []EventRecord{
+		{Module: "Balances", Event: "Transfer", Fields: []interface{}{NewAccountID([]byte{1})}},
+	})
+	assert.EqualError(t, err, "unable to encode event #0: event Balances_Transfer has 3 fields, but got 1")
+
+	_, err = EncodeEventRecords(&meta, []EventRecord{
+		{Module: "Balances", Event: "Transfer", Fields: []interface{}{NewAccountID([]byte{1}), "bob", 1}},
+	})
+	assert.Error(t, err)
+
+	_, err = EncodeEventRecords(&meta, []EventRecord{
+		{Module: "Balances", Event: "Transfer", Fields: []interface{}{NewAccountID([]byte{1}), NewAccountID([]byte{2}), -1}},
+	})
+	assert.Error(t, err)
+}
+
+func TestEncodeEventRecords_InvalidTarget(t *testing.T) {
+	var meta Metadata
+	err := DecodeFromHex(MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	_, err = EncodeEventRecords(&meta, 1)
+	assert.EqualError(t, err, "records must be a struct or []types.EventRecord, but is int")
+
+	_, err = EncodeEventRecords(&meta, struct{ Balances_Transfer []EventBalancesTransfer }{}) //nolint:revive,stylecheck
+	assert.NoError(t, err)
+}
+
+func TestEventRecordsRaw_DecodeEventRecordsDynamic(t *testing.T) {
+	var meta Metadata
+	err := DecodeFromHex(MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	transfer := EventRecord{
+		Phase:  examplePhaseApp,
+		Module: "Balances",
+		Event:  "Transfer",
+		Fields: []interface{}{
+			DynamicComposite{{Value: make([]byte, 32)}},
+			DynamicComposite{{Value: append([]byte{2}, make([]byte, 31)...)}},
+			NewU128(*big.NewInt(1000)),
+		},
+		Topics: []Hash{{1, 2}},
+	}
+
+	encoded, err := EncodeEventRecords(&meta, []EventRecord{transfer})
+	assert.NoError(t, err)
+
+	records, err := encoded.DecodeEventRecordsDynamic(&meta)
+	assert.NoError(t, err)
+	assert.Equal(t, []EventRecord{transfer}, records)
+
+	// events with nested types can be encoded again
+	encoded, err = EncodeEventRecords(&meta, []EventRecord{transfer, {
+		Phase:  examplePhaseFin,
+		Module: "System",
+		Event:  "ExtrinsicSuccess",
+		Fields: []interface{}{exampleEventFin.DispatchInfo},
+		Topics: []Hash{{1, 2}},
+	}})
+	assert.NoError(t, err)
+
+	records, err = encoded.DecodeEventRecordsDynamic(&meta)
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+
+	reencoded, err := EncodeEventRecords(&meta, records)
+	assert.NoError(t, err)
+	assert.Equal(t, encoded, reencoded)
+}
+
+func TestEventRecordsRaw_DecodeEventRecordsDynamic_Errors(t *testing.T) {
+	var meta Metadata
+	err := DecodeFromHex(MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	_, err = EventRecordsRaw{0x04, 0x00}.DecodeEventRecordsDynamic(&meta)
+	assert.Error(t, err)
+
+	// unknown module index
+	_, err = EventRecordsRaw{0x04, 0x00, 0x00, 0x00, 0x00, 0x00, 0xff, 0x00}.DecodeEventRecordsDynamic(&meta)
+	assert.Error(t, err)
+
+	_, err = EventRecordsRaw{0x00}.DecodeEventRecordsDynamic(&Metadata{Version: 13})
+	assert.EqualError(t, err, "dynamic decoding of events is only supported from metadata v14, got v13")
+}
@@ -39,6 +39,20 @@ func TestDispatchInfo_EncodeDecode(t *testing.T) {
 	assertEncodeEmptyObj[DispatchInfo](t, 8)
 }
 
+var (
+	voteThresholdFuzzOpts = []fuzzOpt{
+		withFuzzFuncs(func(v *VoteThreshold, c fuzz.Continue) {
+			*v = VoteThreshold(c.Intn(3))
+		}),
+	}
+)
+
+func TestVoteThreshold_EncodeDecode(t *testing.T) {
+	assertRoundTripFuzz[VoteThreshold](t, 100, voteThresholdFuzzOpts...)
+	assertDecodeNilData[VoteThreshold](t)
+	assertEncodeEmptyObj[VoteThreshold](t, 1)
+}
+
 func TestVoteThreshold_Decoder(t *testing.T) {
 	// SuperMajorityAgainst
 	decoder := scale.NewDecoder(bytes.NewReader([]byte{1}))
@@ -106,6 +120,14 @@ func TestDispatchResult_Decode(t *testing.T) {
 	assertEncodeEmptyObj[DispatchResult](t, 1)
 }
 
+var (
+	proxyTypeFuzzOpts = []fuzzOpt{
+		withFuzzFuncs(func(p *ProxyType, c fuzz.Continue) {
+			*p = ProxyType(c.Intn(4))
+		}),
+	}
+)
+
 func TestProxyTypeEncodeDecode(t *testing.T) {
 	// encode
 	pt := Governance
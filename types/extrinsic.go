@@ -19,6 +19,7 @@ package types
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
@@ -31,23 +32,42 @@ import (
 const (
 	ExtrinsicBitSigned      = 0x80
 	ExtrinsicBitUnsigned    = 0
+	ExtrinsicBitGeneral     = 0x40 // general transactions, since v5
 	ExtrinsicUnmaskVersion  = 0x7f
+	ExtrinsicVersionMask    = 0x3f // masks the version if the general bit is set
 	ExtrinsicDefaultVersion = 1
 	ExtrinsicVersionUnknown = 0 // v0 is unknown
 	ExtrinsicVersion1       = 1
 	ExtrinsicVersion2       = 2
 	ExtrinsicVersion3       = 3
 	ExtrinsicVersion4       = 4
+	ExtrinsicVersion5       = 5
 )
 
+// maxExtrinsicLen limits the length of the bodies of extrinsics that are read at once while decoding
+const maxExtrinsicLen = 1 << 24
+
 // Extrinsic is a piece of Args bundled into a block that expresses something from the "external" (i.e. off-chain)
 // world. There are, broadly speaking, two types of extrinsic: transactions (which tend to be signed) and
 // inherents (which don't).
+//
+// Since extrinsic v5, an extrinsic is either bare (unsigned) or a general transaction. A general transaction carries
+// the extension version and the explicit data of the transaction extensions instead of a signature, signed v5
+// extrinsics do not exist. Both can be encoded and decoded, see DecodeExtrinsic. Building signed general transactions
+// is not supported, since metadata v14 does not declare the supported extension versions.
 type Extrinsic struct {
 	// Version is the encoded version flag (which encodes the raw transaction version and signing information in one byte)
 	Version byte
 	// Signature is the ExtrinsicSignatureV4, it's presence depends on the Version flag
 	Signature ExtrinsicSignatureV4
+	// ExtensionVersion is the version of the transaction extensions of a general transaction
+	ExtensionVersion byte
+	// Extensions is the encoded explicit data of the transaction extensions of a general transaction
+	Extensions []byte
+	// GeneralBody holds the encoded extensions and call of a general transaction that was decoded without metadata,
+	// since only the metadata defines where the extensions end. If set, it is encoded instead of Extensions and
+	// Method, DecodeExtrinsic splits it using the metadata.
+	GeneralBody []byte
 	// Method is the call this extrinsic wraps
 	Method Call
 }
@@ -114,13 +134,21 @@ func (e Extrinsic) IsSigned() bool {
 	return e.Version&ExtrinsicBitSigned == ExtrinsicBitSigned
 }
 
+// IsGeneral returns true if the extrinsic is a general transaction (extrinsic v5)
+func (e Extrinsic) IsGeneral() bool {
+	return e.Version&(ExtrinsicBitSigned|ExtrinsicBitGeneral) == ExtrinsicBitGeneral
+}
+
 // Type returns the raw transaction version (not flagged with signing information)
 func (e Extrinsic) Type() uint8 {
+	if e.IsGeneral() {
+		return e.Version & ExtrinsicVersionMask
+	}
 	return e.Version & ExtrinsicUnmaskVersion
 }
 
-// Sign adds a signature to the extrinsic
-func (e *Extrinsic) Sign(signer signature.KeyringPair, o SignatureOptions) error {
+// Sign adds a signature to the extrinsic, any signature.Signer can be used, e.g. a signature.KeyringPair
+func (e *Extrinsic) Sign(signer signature.Signer, o SignatureOptions) error {
 	if e.Type() != ExtrinsicVersion4 {
 		return fmt.Errorf("unsupported extrinsic version: %v (isSigned: %v, type: %v)", e.Version, e.IsSigned(), e.Type())
 	}
@@ -148,16 +176,21 @@ func (e *Extrinsic) Sign(signer signature.KeyringPair, o SignatureOptions) error
 		TransactionVersion: o.TransactionVersion,
 	}
 
-	signerPubKey := NewMultiAddressFromAccountID(signer.PublicKey)
+	signerPubKey := NewSignerAddress(signer.Scheme(), signer.Public())
+
+	b, err := Encode(payload)
+	if err != nil {
+		return err
+	}
 
-	sig, err := payload.Sign(signer)
+	sig, err := signMultiSignature(signer, b)
 	if err != nil {
 		return err
 	}
 
 	extSig := ExtrinsicSignatureV4{
 		Signer:    signerPubKey,
-		Signature: MultiSignature{IsSr25519: true, AsSr25519: sig},
+		Signature: sig,
 		Era:       era,
 		Nonce:     o.Nonce,
 		Tip:       o.Tip,
@@ -171,9 +204,108 @@ func (e *Extrinsic) Sign(signer signature.KeyringPair, o SignatureOptions) error
 	return nil
 }
 
+// SignWithMetadata adds a signature to the extrinsic. Unlike Sign, the extra and the additional signed data are not
+// hard-coded, they are encoded according to the signed extensions found in the metadata, see EncodeSignedExtensions.
+func (e *Extrinsic) SignWithMetadata(signer signature.Signer, meta *Metadata, o SignatureOptions) error {
+	payload, err := e.SigningPayload(meta, o)
+	if err != nil {
+		return err
+	}
+
+	sig, err := signMultiSignature(signer, payload)
+	if err != nil {
+		return err
+	}
+
+	return e.AttachSignature(meta, NewSignerAddress(signer.Scheme(), signer.Public()), sig, o)
+}
+
+// SigningPayload returns the payload that is signed by SignWithMetadata: the call followed by the extra and the
+// additional signed data of the signed extensions found in the metadata. Signers hash payloads longer than 256 bytes
+// before signing them, see signature.PreparePayload.
+func (e Extrinsic) SigningPayload(meta *Metadata, o SignatureOptions) ([]byte, error) {
+	if e.Type() != ExtrinsicVersion4 {
+		return nil, fmt.Errorf("unsupported extrinsic version: %v (isSigned: %v, type: %v)", e.Version, e.IsSigned(),
+			e.Type())
+	}
+
+	mb, err := Encode(e.Method)
+	if err != nil {
+		return nil, err
+	}
+
+	extra, additional, err := EncodeSignedExtensions(meta, o)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := make([]byte, 0, len(mb)+len(extra)+len(additional))
+	payload = append(payload, mb...)
+	payload = append(payload, extra...)
+	payload = append(payload, additional...)
+
+	return payload, nil
+}
+
+// AttachSignature adds a signature that was created over the SigningPayload of the extrinsic, e.g. by an offline
+// signer. The options and the metadata have to be the ones the payload was created with. Runtimes with 20 byte
+// account IDs require an AccountID20 signer and an Ethereum signature, see Metadata.UsesAccountID20.
+func (e *Extrinsic) AttachSignature(meta *Metadata, signer MultiAddress, sig MultiSignature, o SignatureOptions) error {
+	if e.Type() != ExtrinsicVersion4 {
+		return fmt.Errorf("unsupported extrinsic version: %v (isSigned: %v, type: %v)", e.Version, e.IsSigned(), e.Type())
+	}
+
+	if err := checkSignatureTypes(meta, signer, sig); err != nil {
+		return err
+	}
+
+	extra, _, err := EncodeSignedExtensions(meta, o)
+	if err != nil {
+		return err
+	}
+
+	e.Signature = ExtrinsicSignatureV4{
+		Signer:    signer,
+		Signature: sig,
+		Era:       o.era(),
+		Nonce:     o.Nonce,
+		Tip:       o.Tip,
+		Extra:     extra,
+	}
+
+	// mark the extrinsic as signed
+	e.Version |= ExtrinsicBitSigned
+
+	return nil
+}
+
+// checkSignatureTypes returns an error if the signer or the signature do not match the address and the signature type
+// of the runtime
+func checkSignatureTypes(meta *Metadata, signer MultiAddress, sig MultiSignature) error {
+	if meta.Version != 14 {
+		return nil
+	}
+
+	if meta.UsesAccountID20() != signer.IsAccountID20 {
+		if signer.IsAccountID20 {
+			return errors.New("the runtime does not use 20 byte account IDs, ethereum signers are not supported")
+		}
+		return errors.New("the runtime uses 20 byte account IDs, an ethereum signer is required")
+	}
+
+	if meta.AsMetadataV14.usesEthereumSignature() != sig.IsEthereum {
+		if sig.IsEthereum {
+			return errors.New("the runtime does not support ethereum signatures")
+		}
+		return errors.New("the runtime requires ethereum signatures")
+	}
+
+	return nil
+}
+
 func (e *Extrinsic) Decode(decoder scale.Decoder) error {
 	// compact length encoding (1, 2, or 4 bytes) (may not be there for Extrinsics older than Jan 11 2019)
-	_, err := decoder.DecodeUintCompact()
+	length, err := decoder.DecodeUintCompact()
 	if err != nil {
 		return err
 	}
@@ -197,6 +329,23 @@ func (e *Extrinsic) Decode(decoder scale.Decoder) error {
 		}
 	}
 
+	// extension version, extensions and call of a general transaction, they can only be split using the metadata
+	if e.IsGeneral() {
+		if e.Type() != ExtrinsicVersion5 {
+			return fmt.Errorf("unsupported extrinsic version: %v (isGeneral: %v, type: %v)", e.Version, e.IsGeneral(),
+				e.Type())
+		}
+
+		err = decoder.Decode(&e.ExtensionVersion)
+		if err != nil {
+			return err
+		}
+
+		// the body is bounded by the length, the extrinsic may be followed by other data
+		e.GeneralBody, err = readExtrinsicBody(decoder, length, 2)
+		return err
+	}
+
 	// call
 	err = decoder.Decode(&e.Method)
 	if err != nil {
@@ -207,7 +356,10 @@ func (e *Extrinsic) Decode(decoder scale.Decoder) error {
 }
 
 func (e Extrinsic) Encode(encoder scale.Encoder) error {
-	if e.Type() != ExtrinsicVersion4 {
+	switch {
+	case e.Type() == ExtrinsicVersion4 && !e.IsGeneral():
+	case e.Type() == ExtrinsicVersion5 && !e.IsSigned():
+	default:
 		return fmt.Errorf("unsupported extrinsic version: %v (isSigned: %v, type: %v)", e.Version, e.IsSigned(),
 			e.Type())
 	}
@@ -231,15 +383,60 @@ func (e Extrinsic) Encode(encoder scale.Encoder) error {
 		}
 	}
 
+	// encode the extension version and the extensions if general
+	if e.IsGeneral() {
+		err = tempEnc.Encode(e.ExtensionVersion)
+		if err != nil {
+			return err
+		}
+
+		if e.GeneralBody != nil {
+			err = tempEnc.Write(e.GeneralBody)
+			if err != nil {
+				return err
+			}
+
+			return writeExtrinsic(encoder, bb.Bytes())
+		}
+
+		err = tempEnc.Write(e.Extensions)
+		if err != nil {
+			return err
+		}
+	}
+
 	// encode the method
 	err = tempEnc.Encode(e.Method)
 	if err != nil {
 		return err
 	}
 
+	return writeExtrinsic(encoder, bb.Bytes())
+}
+
+// readExtrinsicBody reads the rest of an extrinsic of the given length, of which n bytes have been read already
+func readExtrinsicBody(decoder scale.Decoder, length *big.Int, n int) ([]byte, error) {
+	if !length.IsUint64() || length.Uint64() < uint64(n) || length.Uint64()-uint64(n) > maxExtrinsicLen {
+		return nil, fmt.Errorf("invalid extrinsic length %v", length)
+	}
+
+	body := make([]byte, length.Uint64()-uint64(n))
+	if len(body) == 0 {
+		return body, nil
+	}
+
+	err := decoder.Read(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return body, nil
+}
+
+// writeExtrinsic writes the encoded extrinsic with its compact length prefix
+func writeExtrinsic(encoder scale.Encoder, eb []byte) error {
 	// take the temporary buffer to determine length, write that as prefix
-	eb := bb.Bytes()
-	err = encoder.EncodeUintCompact(*big.NewInt(0).SetUint64(uint64(len(eb))))
+	err := encoder.EncodeUintCompact(*big.NewInt(0).SetUint64(uint64(len(eb))))
 	if err != nil {
 		return err
 	}
@@ -277,6 +474,17 @@ func NewCall(m *Metadata, call string, args ...interface{}) (Call, error) {
 	return Call{c, a}, nil
 }
 
+// Encode implements encoding for Call, which writes the call index followed by the encoded arguments. This allows
+// calls to be used as values of the runtime call type, see MetadataV14.EncodeValue.
+func (c Call) Encode(encoder scale.Encoder) error {
+	err := encoder.Encode(c.CallIndex)
+	if err != nil {
+		return err
+	}
+
+	return encoder.Encode(c.Args)
+}
+
 // Callindex is a 16 bit wrapper around the `[sectionIndex, methodIndex]` value that uniquely identifies a method
 type CallIndex struct {
 	SectionIndex uint8
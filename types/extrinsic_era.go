@@ -17,9 +17,20 @@
 package types
 
 import (
+	"fmt"
+	"math"
+	"math/bits"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
 )
 
+const (
+	// DefaultMortalPeriod is the default period of mortal eras in blocks, as used by Substrate based tooling
+	DefaultMortalPeriod uint64 = 64
+	// MaxMortalPeriod is the maximum period of mortal eras in blocks
+	MaxMortalPeriod uint64 = 1 << 16
+)
+
 // ExtrinsicEra indicates either a mortal or immortal extrinsic
 type ExtrinsicEra struct {
 	IsImmortalEra bool
@@ -69,8 +80,87 @@ func (e ExtrinsicEra) Encode(encoder scale.Encoder) error {
 	return nil
 }
 
+// NewMortalEra creates a mortal era that is valid for period blocks, starting at the current block. As in Substrate,
+// the period is rounded up to a power of two between 4 and 65536 and the phase is quantized.
+func NewMortalEra(period uint64, currentBlock uint64) ExtrinsicEra {
+	switch {
+	case period <= 4:
+		period = 4
+	case period >= MaxMortalPeriod:
+		period = MaxMortalPeriod
+	default:
+		period = 1 << bits.Len64(period-1)
+	}
+
+	phase := currentBlock % period
+	quantizeFactor := mortalEraQuantizeFactor(period)
+	quantizedPhase := phase / quantizeFactor * quantizeFactor
+
+	encoded := uint16(bits.TrailingZeros64(period)-1) | uint16(quantizedPhase/quantizeFactor)<<4
+
+	return ExtrinsicEra{
+		IsMortalEra: true,
+		AsMortalEra: MortalEra{First: byte(encoded), Second: byte(encoded >> 8)},
+	}
+}
+
+// Birth returns the first block at which an extrinsic with this era is valid, given a block within the era. It
+// returns 0 for immortal eras.
+func (e ExtrinsicEra) Birth(currentBlock uint64) uint64 {
+	if !e.IsMortalEra {
+		return 0
+	}
+
+	period, phase := e.AsMortalEra.periodAndPhase()
+
+	if currentBlock < phase {
+		currentBlock = phase
+	}
+
+	return (currentBlock-phase)/period*period + phase
+}
+
+// Death returns the first block at which an extrinsic with this era is no longer valid, given a block within the era.
+// It returns math.MaxUint64 for immortal eras.
+func (e ExtrinsicEra) Death(currentBlock uint64) uint64 {
+	if !e.IsMortalEra {
+		return math.MaxUint64
+	}
+
+	period, _ := e.AsMortalEra.periodAndPhase()
+
+	return e.Birth(currentBlock) + period
+}
+
 // MortalEra for an extrinsic, indicating period and phase
 type MortalEra struct {
 	First  byte
 	Second byte
 }
+
+// PeriodAndPhase decodes the period and the phase of the era, returning an error if they are invalid
+func (m MortalEra) PeriodAndPhase() (period uint64, phase uint64, err error) {
+	period, phase = m.periodAndPhase()
+
+	if period < 4 || phase >= period {
+		return 0, 0, fmt.Errorf("invalid mortal era with period %d and phase %d", period, phase)
+	}
+
+	return period, phase, nil
+}
+
+func (m MortalEra) periodAndPhase() (period uint64, phase uint64) {
+	encoded := uint64(m.First) | uint64(m.Second)<<8
+
+	period = 2 << (encoded % (1 << 4))
+	phase = (encoded >> 4) * mortalEraQuantizeFactor(period)
+
+	return period, phase
+}
+
+func mortalEraQuantizeFactor(period uint64) uint64 {
+	if f := period >> 12; f > 1 {
+		return f
+	}
+	return 1
+}
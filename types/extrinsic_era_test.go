@@ -17,6 +17,7 @@
 package types_test
 
 import (
+	"math"
 	"testing"
 
 	fuzz "github.com/google/gofuzz"
@@ -66,3 +67,68 @@ func TestExtrinsicEra_EncodeDecode(t *testing.T) {
 
 	assertRoundTripFuzz[ExtrinsicEra](t, 1000, extrinsicEraFuzzOpts...)
 }
+
+func TestNewMortalEra(t *testing.T) {
+	for _, test := range []struct {
+		period, current     uint64
+		expPeriod, expPhase uint64
+		expFirst, expSecond byte
+	}{
+		{64, 42, 64, 42, 0xa5, 0x02},
+		{32768, 20000, 32768, 20000, 0x4e, 0x9c},
+		{200, 513, 256, 1, 0x17, 0x00},
+		{2, 1, 4, 1, 0x11, 0x00},
+		{4, 5, 4, 1, 0x11, 0x00},
+		{0, 5, 4, 1, 0x11, 0x00},
+		{64000, 64000, 65536, 64000, 0x0f, 0xfa},
+		{32768, 20001, 32768, 20000, 0x4e, 0x9c},
+		{1 << 20, 1, 65536, 0, 0x0f, 0x00},
+	} {
+		e := NewMortalEra(test.period, test.current)
+		assert.True(t, e.IsMortalEra)
+		assert.False(t, e.IsImmortalEra)
+		assert.Equal(t, MortalEra{test.expFirst, test.expSecond}, e.AsMortalEra)
+
+		period, phase, err := e.AsMortalEra.PeriodAndPhase()
+		assert.NoError(t, err)
+		assert.Equal(t, test.expPeriod, period)
+		assert.Equal(t, test.expPhase, phase)
+
+		assertRoundtrip(t, e)
+	}
+}
+
+func TestMortalEra_PeriodAndPhase_Invalid(t *testing.T) {
+	_, _, err := MortalEra{1, 2}.PeriodAndPhase()
+	assert.EqualError(t, err, "invalid mortal era with period 4 and phase 32")
+
+	_, _, err = MortalEra{0, 0}.PeriodAndPhase()
+	assert.EqualError(t, err, "invalid mortal era with period 2 and phase 0")
+}
+
+func TestExtrinsicEra_BirthDeath(t *testing.T) {
+	e := NewMortalEra(64, 42)
+
+	assert.Equal(t, uint64(42), e.Birth(0))
+	assert.Equal(t, uint64(106), e.Death(0))
+	assert.Equal(t, uint64(42), e.Birth(42))
+	assert.Equal(t, uint64(42), e.Birth(105))
+	assert.Equal(t, uint64(106), e.Death(105))
+	assert.Equal(t, uint64(106), e.Birth(106))
+	assert.Equal(t, uint64(170), e.Death(106))
+
+	for current := uint64(1000); current < 1100; current++ {
+		e := NewMortalEra(64, current)
+		assert.Equal(t, current, e.Birth(current))
+		assert.Equal(t, current+64, e.Death(current))
+	}
+
+	// the phase of long periods is quantized, the birth is never after the current block
+	e = NewMortalEra(65536, 1000003)
+	assert.Equal(t, uint64(1000000), e.Birth(1000003))
+	assert.Equal(t, uint64(1065536), e.Death(1000003))
+
+	immortal := ExtrinsicEra{IsImmortalEra: true}
+	assert.Equal(t, uint64(0), immortal.Birth(1000))
+	assert.Equal(t, uint64(math.MaxUint64), immortal.Death(1000))
+}
@@ -38,15 +38,24 @@ type ExtrinsicPayloadV3 struct {
 	BlockHash   Hash         // additional via system::CheckEra
 }
 
-// Sign the extrinsic payload with the given derivation path
-func (e ExtrinsicPayloadV3) Sign(signer signature.KeyringPair) (Signature, error) {
+// Sign the extrinsic payload with the given signer
+func (e ExtrinsicPayloadV3) Sign(signer signature.Signer) (Signature, error) {
 	b, err := Encode(e)
 	if err != nil {
 		return Signature{}, err
 	}
 
-	sig, err := signature.Sign(b, signer.URI)
-	return NewSignature(sig), err
+	sig, err := signer.Sign(b)
+	if err != nil {
+		return Signature{}, err
+	}
+
+	if len(sig) != len(Signature{}) {
+		return Signature{}, fmt.Errorf("expected a signature of %d bytes, got %d, use Extrinsic.Sign for %v signers",
+			len(Signature{}), len(sig), signer.Scheme())
+	}
+
+	return NewSignature(sig), nil
 }
 
 // Encode implements encoding for ExtrinsicPayloadV3, which just unwraps the bytes of ExtrinsicPayloadV3 without
@@ -100,15 +109,24 @@ type ExtrinsicPayloadV4 struct {
 	TransactionVersion U32
 }
 
-// Sign the extrinsic payload with the given derivation path
-func (e ExtrinsicPayloadV4) Sign(signer signature.KeyringPair) (Signature, error) {
+// Sign the extrinsic payload with the given signer
+func (e ExtrinsicPayloadV4) Sign(signer signature.Signer) (Signature, error) {
 	b, err := Encode(e)
 	if err != nil {
 		return Signature{}, err
 	}
 
-	sig, err := signature.Sign(b, signer.URI)
-	return NewSignature(sig), err
+	sig, err := signer.Sign(b)
+	if err != nil {
+		return Signature{}, err
+	}
+
+	if len(sig) != len(Signature{}) {
+		return Signature{}, fmt.Errorf("expected a signature of %d bytes, got %d, use Extrinsic.Sign for %v signers",
+			len(Signature{}), len(sig), signer.Scheme())
+	}
+
+	return NewSignature(sig), nil
 }
 
 func (e ExtrinsicPayloadV4) Encode(encoder scale.Encoder) error {
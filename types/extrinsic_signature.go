@@ -16,6 +16,8 @@
 
 package types
 
+import "github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+
 type ExtrinsicSignatureV3 struct {
 	Signer    Address
 	Signature Signature
@@ -30,6 +32,61 @@ type ExtrinsicSignatureV4 struct {
 	Era       ExtrinsicEra // extra via system::CheckEra
 	Nonce     UCompact     // extra via system::CheckNonce (Compact<Index> where Index is u32))
 	Tip       UCompact     // extra via balances::TakeFees (Compact<Balance> where Balance is u128))
+	// Extra is the encoded extra data of all signed extensions, as defined by the metadata. If set, it is encoded
+	// instead of Era, Nonce and Tip. It is never set when decoding, since that requires metadata.
+	Extra []byte
+}
+
+func (s *ExtrinsicSignatureV4) Decode(decoder scale.Decoder) error {
+	err := decoder.Decode(&s.Signer)
+	if err != nil {
+		return err
+	}
+
+	err = decoder.Decode(&s.Signature)
+	if err != nil {
+		return err
+	}
+
+	err = decoder.Decode(&s.Era)
+	if err != nil {
+		return err
+	}
+
+	err = decoder.Decode(&s.Nonce)
+	if err != nil {
+		return err
+	}
+
+	return decoder.Decode(&s.Tip)
+}
+
+func (s ExtrinsicSignatureV4) Encode(encoder scale.Encoder) error {
+	err := encoder.Encode(s.Signer)
+	if err != nil {
+		return err
+	}
+
+	err = encoder.Encode(s.Signature)
+	if err != nil {
+		return err
+	}
+
+	if s.Extra != nil {
+		return encoder.Write(s.Extra)
+	}
+
+	err = encoder.Encode(s.Era)
+	if err != nil {
+		return err
+	}
+
+	err = encoder.Encode(s.Nonce)
+	if err != nil {
+		return err
+	}
+
+	return encoder.Encode(s.Tip)
 }
 
 type SignatureOptions struct {
@@ -40,4 +97,14 @@ type SignatureOptions struct {
 	GenesisHash        Hash         // additional via system::CheckGenesis
 	BlockHash          Hash         // additional via system::CheckEra
 	TransactionVersion U32          // additional via system::CheckTxVersion
+	AssetID            interface{}  // extra via ChargeAssetTxPayment, nil if the fee is paid in the native asset
+	MetadataHash       *Hash        // additional via frame_metadata_hash_extension::CheckMetadataHash, nil to disable
+}
+
+// era returns the era of the options, defaulting to an immortal era
+func (o SignatureOptions) era() ExtrinsicEra {
+	if !o.Era.IsMortalEra {
+		return ExtrinsicEra{IsImmortalEra: true}
+	}
+	return o.Era
 }
@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	fuzz "github.com/google/gofuzz"
 
 	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/stretchr/testify/assert"
@@ -55,6 +56,16 @@ var (
 		multiAddressFuzzOpts,
 		multiSignatureFuzzOpts,
 		extrinsicEraFuzzOpts,
+		[]fuzzOpt{
+			withFuzzFuncs(func(s *ExtrinsicSignatureV4, c fuzz.Continue) {
+				c.Fuzz(&s.Signer)
+				c.Fuzz(&s.Signature)
+				c.Fuzz(&s.Era)
+				c.Fuzz(&s.Nonce)
+				c.Fuzz(&s.Tip)
+				// Extra is not decoded, since that requires metadata
+			}),
+		},
 	)
 )
 
@@ -0,0 +1,240 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"net"
+	"net/rpc"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// SigningService holds the key of a remote signer, it is exposed via net/rpc.
+type SigningService struct {
+	signer signature.Signer
+}
+
+// PublicKeyArgs are the (empty) arguments of SigningService.PublicKey.
+type PublicKeyArgs struct{}
+
+func (s *SigningService) PublicKey(_ PublicKeyArgs, pub *[]byte) error {
+	*pub = s.signer.Public()
+	return nil
+}
+
+func (s *SigningService) Sign(payload []byte, sig *[]byte) error {
+	b, err := s.signer.Sign(payload)
+	if err != nil {
+		return err
+	}
+
+	*sig = b
+	return nil
+}
+
+// remoteSigner is a signature.Signer that does not hold any key material, it asks a SigningService over a socket.
+type remoteSigner struct {
+	client *rpc.Client
+	public []byte
+}
+
+func newRemoteSigner(addr string) (*remoteSigner, error) {
+	client, err := rpc.Dial("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	var pub []byte
+
+	if err := client.Call("SigningService.PublicKey", PublicKeyArgs{}, &pub); err != nil {
+		return nil, err
+	}
+
+	return &remoteSigner{client: client, public: pub}, nil
+}
+
+func (r *remoteSigner) Public() []byte {
+	return r.public
+}
+
+func (r *remoteSigner) Scheme() signature.Scheme {
+	return signature.SchemeSr25519
+}
+
+func (r *remoteSigner) Sign(payload []byte) ([]byte, error) {
+	var sig []byte
+
+	err := r.client.Call("SigningService.Sign", payload, &sig)
+
+	return sig, err
+}
+
+func startSigningService(t *testing.T, signer signature.Signer) string {
+	server := rpc.NewServer()
+
+	err := server.Register(&SigningService{signer: signer})
+	assert.NoError(t, err)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = l.Close()
+	})
+
+	go server.Accept(l)
+
+	return l.Addr().String()
+}
+
+func TestExtrinsic_Sign_RemoteSigner(t *testing.T) {
+	keyPairSigner, err := signature.NewKeyPairSigner(signature.SchemeSr25519, signature.TestKeyringPairAlice.URI)
+	assert.NoError(t, err)
+
+	signer, err := newRemoteSigner(startSigningService(t, keyPairSigner))
+	assert.NoError(t, err)
+
+	defer signer.client.Close()
+
+	c, err := NewCall(ExamplaryMetadataV4,
+		"balances.transfer", NewAddressFromAccountID(signature.TestKeyringPairBob.PublicKey),
+		NewUCompactFromUInt(6969))
+	assert.NoError(t, err)
+
+	ext := NewExtrinsic(c)
+
+	o := SignatureOptions{
+		BlockHash:          NewHash(MustHexDecodeString("0xec7afaf1cca720ce88c1d1b689d81f0583cc15a97d621cf046dd9abf605ef22f")),
+		GenesisHash:        NewHash(MustHexDecodeString("0xdcd1346701ca8396496e52aa2785b1748deb6db09551b72159dcb3e08991025b")),
+		Nonce:              NewUCompactFromUInt(1),
+		SpecVersion:        123,
+		Tip:                NewUCompactFromUInt(2),
+		TransactionVersion: 1,
+	}
+
+	err = ext.Sign(signer, o)
+	assert.NoError(t, err)
+	assert.True(t, ext.IsSigned())
+	assert.Equal(t, signature.TestKeyringPairAlice.PublicKey, ext.Signature.Signer.AsID[:])
+	assert.True(t, ext.Signature.Signature.IsSr25519)
+
+	mb, err := Encode(ext.Method)
+	assert.NoError(t, err)
+
+	b, err := Encode(ExtrinsicPayloadV4{
+		ExtrinsicPayloadV3: ExtrinsicPayloadV3{
+			Method:      mb,
+			Era:         ExtrinsicEra{IsImmortalEra: true},
+			Nonce:       o.Nonce,
+			Tip:         o.Tip,
+			SpecVersion: o.SpecVersion,
+			GenesisHash: o.GenesisHash,
+			BlockHash:   o.BlockHash,
+		},
+		TransactionVersion: o.TransactionVersion,
+	})
+	assert.NoError(t, err)
+
+	ok, err := signature.Verify(b, ext.Signature.Signature.AsSr25519[:], signature.TestKeyringPairAlice.URI)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	// the payload can be signed by the remote signer as well
+	sig, err := ExtrinsicPayloadV4{ExtrinsicPayloadV3: ExtrinsicPayloadV3{Method: mb}}.Sign(signer)
+	assert.NoError(t, err)
+	assert.NotEqual(t, Signature{}, sig)
+}
+
+func TestExtrinsic_Sign_SignerError(t *testing.T) {
+	signer, err := newRemoteSigner(startSigningService(t, signature.TestKeyringPairAlice))
+	assert.NoError(t, err)
+
+	err = signer.client.Close()
+	assert.NoError(t, err)
+
+	ext := NewExtrinsic(Call{})
+
+	err = ext.Sign(signer, SignatureOptions{})
+	assert.ErrorIs(t, err, rpc.ErrShutdown)
+	assert.False(t, ext.IsSigned())
+}
+
+func TestExtrinsic_Sign_Schemes(t *testing.T) {
+	for _, scheme := range []signature.Scheme{signature.SchemeSr25519, signature.SchemeEd25519, signature.SchemeEcdsa} {
+		t.Run(scheme.String(), func(t *testing.T) {
+			signer, err := signature.KeyringPairFromSecretWithScheme(scheme, "//Alice", 42)
+			assert.NoError(t, err)
+
+			c, err := NewCall(ExamplaryMetadataV4,
+				"balances.transfer", NewAddressFromAccountID(signature.TestKeyringPairBob.PublicKey),
+				NewUCompactFromUInt(6969))
+			assert.NoError(t, err)
+
+			ext := NewExtrinsic(c)
+
+			err = ext.Sign(signer, SignatureOptions{})
+			assert.NoError(t, err)
+
+			enc, err := Encode(ext)
+			assert.NoError(t, err)
+
+			var dec Extrinsic
+			err = Decode(enc, &dec)
+			assert.NoError(t, err)
+			assert.Equal(t, ext, dec)
+
+			assert.Equal(t, scheme.AccountID(signer.PublicKey), dec.Signature.Signer.AsID[:])
+
+			mb, err := Encode(dec.Method)
+			assert.NoError(t, err)
+
+			b, err := Encode(ExtrinsicPayloadV4{
+				ExtrinsicPayloadV3: ExtrinsicPayloadV3{Method: mb, Era: ExtrinsicEra{IsImmortalEra: true}},
+			})
+			assert.NoError(t, err)
+
+			var sig []byte
+
+			switch scheme {
+			case signature.SchemeSr25519:
+				assert.True(t, dec.Signature.Signature.IsSr25519)
+				sig = dec.Signature.Signature.AsSr25519[:]
+			case signature.SchemeEd25519:
+				assert.True(t, dec.Signature.Signature.IsEd25519)
+				sig = dec.Signature.Signature.AsEd25519[:]
+			case signature.SchemeEcdsa:
+				assert.True(t, dec.Signature.Signature.IsEcdsa)
+				sig = dec.Signature.Signature.AsEcdsa[:]
+			}
+
+			ok, err := signature.VerifyWithScheme(scheme, b, sig, signer.URI)
+			assert.NoError(t, err)
+			assert.True(t, ok)
+		})
+	}
+}
+
+func TestExtrinsicPayloadV4_Sign_Ecdsa(t *testing.T) {
+	signer, err := signature.KeyringPairFromSecretWithScheme(signature.SchemeEcdsa, "//Alice", 42)
+	assert.NoError(t, err)
+
+	_, err = ExtrinsicPayloadV4{}.Sign(signer)
+	assert.EqualError(t, err, "expected a signature of 64 bytes, got 65, use Extrinsic.Sign for ecdsa signers")
+}
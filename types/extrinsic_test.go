@@ -64,6 +64,80 @@ func TestExtrinsic_Signed_EncodeDecode(t *testing.T) {
 	assert.Equal(t, ExamplaryExtrinsic, extDec)
 }
 
+func TestExtrinsic_V5_Bare_EncodeDecode(t *testing.T) {
+	ext := Extrinsic{
+		Version: ExtrinsicVersion5,
+		Method:  Call{CallIndex: CallIndex{SectionIndex: 3, MethodIndex: 0}, Args: Args{0xe5, 0x6c}},
+	}
+
+	extEnc, err := EncodeToHex(ext)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "0x"+
+		"14"+ // length prefix, compact
+		"05"+ // version
+		"0300"+ // call index (section index and method index)
+		"e56c", // args
+		extEnc)
+
+	var extDec Extrinsic
+	err = DecodeFromHex(extEnc, &extDec)
+	assert.NoError(t, err)
+
+	assert.Equal(t, ext, extDec)
+	assert.False(t, extDec.IsSigned())
+	assert.False(t, extDec.IsGeneral())
+	assert.Equal(t, uint8(ExtrinsicVersion5), extDec.Type())
+}
+
+func TestExtrinsic_V5_General_EncodeDecode(t *testing.T) {
+	ext := Extrinsic{
+		Version:          ExtrinsicVersion5 | ExtrinsicBitGeneral,
+		ExtensionVersion: 0,
+		Extensions:       []byte{0x00, 0x04, 0x08},
+		Method:           Call{CallIndex: CallIndex{SectionIndex: 3, MethodIndex: 0}, Args: Args{0xe5, 0x6c}},
+	}
+
+	extEnc, err := EncodeToHex(ext)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "0x"+
+		"24"+ // length prefix, compact
+		"45"+ // version, general
+		"00"+ // extension version
+		"000408"+ // extensions
+		"0300"+ // call index (section index and method index)
+		"e56c", // args
+		extEnc)
+
+	var extDec Extrinsic
+	err = DecodeFromHex(extEnc, &extDec)
+	assert.NoError(t, err)
+
+	// the extensions can't be told apart from the call without metadata
+	assert.True(t, extDec.IsGeneral())
+	assert.False(t, extDec.IsSigned())
+	assert.Equal(t, uint8(ExtrinsicVersion5), extDec.Type())
+	assert.Equal(t, []byte{0x00, 0x04, 0x08, 0x03, 0x00, 0xe5, 0x6c}, extDec.GeneralBody)
+	assert.Equal(t, Call{}, extDec.Method)
+
+	reEnc, err := EncodeToHex(extDec)
+	assert.NoError(t, err)
+	assert.Equal(t, extEnc, reEnc)
+}
+
+func TestExtrinsic_V5_Signed_Unsupported(t *testing.T) {
+	var extDec Extrinsic
+	err := DecodeFromHex("0x1485"+"0300e56c", &extDec)
+	assert.EqualError(t, err, "unsupported extrinsic version: 133 (isSigned: true, type: 5)")
+
+	_, err = EncodeToHex(Extrinsic{Version: ExtrinsicVersion5 | ExtrinsicBitSigned})
+	assert.EqualError(t, err, "unsupported extrinsic version: 133 (isSigned: true, type: 5)")
+
+	_, err = EncodeToHex(Extrinsic{Version: ExtrinsicVersion4 | ExtrinsicBitGeneral})
+	assert.Error(t, err)
+}
+
 func TestExtrinsic_Sign(t *testing.T) {
 	c, err := NewCall(ExamplaryMetadataV4,
 		"balances.transfer", NewAddressFromAccountID(MustHexDecodeString(
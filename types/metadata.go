@@ -294,6 +294,34 @@ func (m *Metadata) FindEventNamesForEventID(eventID EventID) (Text, Text, error)
 	}
 }
 
+func (m *Metadata) FindEventIDForEventNames(module string, event string) (EventID, error) {
+	txtModule := Text(module)
+	txtEvent := Text(event)
+
+	switch m.Version {
+	case 4:
+		return m.AsMetadataV4.FindEventIDForEventNames(txtModule, txtEvent)
+	case 7:
+		return m.AsMetadataV7.FindEventIDForEventNames(txtModule, txtEvent)
+	case 8:
+		return m.AsMetadataV8.FindEventIDForEventNames(txtModule, txtEvent)
+	case 9:
+		return m.AsMetadataV9.FindEventIDForEventNames(txtModule, txtEvent)
+	case 10:
+		return m.AsMetadataV10.FindEventIDForEventNames(txtModule, txtEvent)
+	case 11:
+		return m.AsMetadataV11.FindEventIDForEventNames(txtModule, txtEvent)
+	case 12:
+		return m.AsMetadataV12.FindEventIDForEventNames(txtModule, txtEvent)
+	case 13:
+		return m.AsMetadataV13.FindEventIDForEventNames(txtModule, txtEvent)
+	case 14:
+		return m.AsMetadataV14.FindEventIDForEventNames(txtModule, txtEvent)
+	default:
+		return EventID{}, fmt.Errorf("unsupported metadata version")
+	}
+}
+
 func (m *Metadata) FindStorageEntryMetadata(module string, fn string) (StorageEntryMetadata, error) {
 	switch m.Version {
 	case 4:
@@ -319,6 +347,27 @@ func (m *Metadata) FindStorageEntryMetadata(module string, fn string) (StorageEn
 	}
 }
 
+// FindStorageEntryDefault returns the default value of a storage entry, which replaces absent values of entries with
+// the Default modifier (ValueQuery). Ok is false if the entry has no default, i.e. its modifier is Optional
+// (OptionQuery). Defaults are only supported from metadata v14.
+func (m *Metadata) FindStorageEntryDefault(module string, fn string) (value StorageDataRaw, ok bool, err error) {
+	if m.Version != 14 {
+		return nil, false, fmt.Errorf("storage defaults are only supported from metadata v14, got v%d", m.Version)
+	}
+
+	entryMeta, err := m.AsMetadataV14.FindStorageEntryMetadata(module, fn)
+	if err != nil {
+		return nil, false, err
+	}
+
+	entry := entryMeta.(StorageEntryMetadataV14)
+	if !entry.Modifier.IsDefault {
+		return nil, false, nil
+	}
+
+	return StorageDataRaw(entry.Fallback), true, nil
+}
+
 func (m *Metadata) ExistsModuleMetadata(module string) bool {
 	switch m.Version {
 	case 4:
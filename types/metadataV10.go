@@ -87,6 +87,26 @@ func (m *MetadataV10) FindEventNamesForEventID(eventID EventID) (Text, Text, err
 	return "", "", fmt.Errorf("module index %v out of range", eventID[0])
 }
 
+func (m *MetadataV10) FindEventIDForEventNames(module Text, event Text) (EventID, error) {
+	mi := uint8(0)
+	for _, mod := range m.Modules {
+		if !mod.HasEvents {
+			continue
+		}
+		if mod.Name != module {
+			mi++
+			continue
+		}
+		for ei, e := range mod.Events {
+			if e.Name == event {
+				return EventID{mi, uint8(ei)}, nil
+			}
+		}
+		return EventID{}, fmt.Errorf("event %v not found within module %v", event, module)
+	}
+	return EventID{}, fmt.Errorf("module %v not found in metadata", module)
+}
+
 func (m *MetadataV10) FindConstantValue(module Text, constant Text) ([]byte, error) {
 	for _, mod := range m.Modules {
 		if mod.Name == module {
@@ -411,6 +431,23 @@ func (s StorageHasherV10) Encode(encoder scale.Encoder) error {
 	return encoder.PushByte(t)
 }
 
+// hashLength returns the length of the hash of the hasher and whether the hasher is transparent, i.e. whether the
+// hashed data is appended to the hash
+func (s StorageHasherV10) hashLength() (int, bool) {
+	switch {
+	case s.IsBlake2_128, s.IsTwox128:
+		return 16, false
+	case s.IsBlake2_256, s.IsTwox256:
+		return 32, false
+	case s.IsBlake2_128Concat:
+		return 16, true
+	case s.IsTwox64Concat:
+		return 8, true
+	default:
+		return 0, true
+	}
+}
+
 func (s StorageHasherV10) HashFunc() (hash.Hash, error) {
 	// Blake2_128
 	if s.IsBlake2_128 {
@@ -126,6 +126,14 @@ func TestFindEventNamesForEventIDV10(t *testing.T) {
 	assert.Equal(t, exampleEventMetadataV4.Name, event)
 }
 
+func TestFindEventIDForEventNamesV10(t *testing.T) {
+	id, err := exampleMetadataV10.FindEventIDForEventNames(string(exampleModuleMetadataV102.Name),
+		string(exampleEventMetadataV4.Name))
+
+	assert.NoError(t, err)
+	assert.Equal(t, EventID([2]byte{1, 0}), id)
+}
+
 func TestFindStorageEntryMetadataV10(t *testing.T) {
 	_, err := exampleMetadataV10.FindStorageEntryMetadata("myStoragePrefix", "myStorageFunc2")
 	assert.NoError(t, err)
@@ -80,6 +80,24 @@ func (m *MetadataV12) FindEventNamesForEventID(eventID EventID) (Text, Text, err
 	return "", "", fmt.Errorf("module index %v out of range", eventID[0])
 }
 
+func (m *MetadataV12) FindEventIDForEventNames(module Text, event Text) (EventID, error) {
+	for _, mod := range m.Modules {
+		if !mod.HasEvents {
+			continue
+		}
+		if mod.Name != module {
+			continue
+		}
+		for ei, e := range mod.Events {
+			if e.Name == event {
+				return EventID{mod.Index, uint8(ei)}, nil
+			}
+		}
+		return EventID{}, fmt.Errorf("event %v not found within module %v", event, module)
+	}
+	return EventID{}, fmt.Errorf("module %v not found in metadata", module)
+}
+
 func (m *MetadataV12) FindStorageEntryMetadata(module string, fn string) (StorageEntryMetadata, error) {
 	for _, mod := range m.Modules {
 		if !mod.HasStorage {
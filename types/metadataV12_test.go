@@ -120,6 +120,17 @@ func TestMetadataV12_FindEventNamesForUnknownModule(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestMetadataV12_FindEventIDForEventNames(t *testing.T) {
+	id, err := exampleMetadataV12.FindEventIDForEventNames(string(exampleModuleMetadataV121.Name),
+		string(exampleEventMetadataV4.Name))
+
+	assert.NoError(t, err)
+	assert.Equal(t, EventID([2]byte{1, 0}), id)
+
+	_, err = exampleMetadataV12.FindEventIDForEventNames("unknown", string(exampleEventMetadataV4.Name))
+	assert.Error(t, err)
+}
+
 func TestMetadataV12_TestFindStorageEntryMetadata(t *testing.T) {
 	_, err := exampleMetadataV12.FindStorageEntryMetadata("myStoragePrefix", "myStorageFunc2")
 	assert.NoError(t, err)
@@ -126,6 +126,39 @@ func (m *MetadataV14) FindEventNamesForEventID(eventID EventID) (Text, Text, err
 	return "", "", fmt.Errorf("module index %v out of range", eventID[0])
 }
 
+func (m *MetadataV14) FindEventIDForEventNames(module Text, event Text) (EventID, error) {
+	for _, mod := range m.Pallets {
+		if !mod.HasEvents || mod.Name != module {
+			continue
+		}
+		variant, err := m.findEventVariant(module, event)
+		if err != nil {
+			return EventID{}, err
+		}
+		return EventID{uint8(mod.Index), uint8(variant.Index)}, nil
+	}
+	return EventID{}, fmt.Errorf("module %v not found in metadata", module)
+}
+
+func (m *MetadataV14) findEventVariant(module Text, event Text) (*Si1Variant, error) {
+	for _, mod := range m.Pallets {
+		if !mod.HasEvents || mod.Name != module {
+			continue
+		}
+		eventType := mod.Events.Type.Int64()
+
+		if typ, ok := m.EfficientLookup[eventType]; ok {
+			for i, vars := range typ.Def.Variant.Variants {
+				if vars.Name == event {
+					return &typ.Def.Variant.Variants[i], nil
+				}
+			}
+		}
+		return nil, fmt.Errorf("event %v not found within module %v", event, module)
+	}
+	return nil, fmt.Errorf("module %v not found in metadata", module)
+}
+
 func (m *MetadataV14) FindStorageEntryMetadata(module string, fn string) (StorageEntryMetadata, error) {
 	for _, mod := range m.Pallets {
 		if !mod.HasStorage {
@@ -111,6 +111,22 @@ func TestMetadataV14FindEventNamesInvalidEventID(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestMetadataV14FindEventIDForEventNames(t *testing.T) {
+	var meta Metadata
+	err := DecodeFromHex(MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	id, err := meta.FindEventIDForEventNames("Balances", "Transfer")
+	assert.NoError(t, err)
+	assert.Equal(t, EventID{6, 2}, id)
+
+	_, err = meta.FindEventIDForEventNames("Balances", "Unknown")
+	assert.Error(t, err)
+
+	_, err = meta.FindEventIDForEventNames("Unknown", "Transfer")
+	assert.Error(t, err)
+}
+
 func TestMetadataV14FindStorageEntryMetadata(t *testing.T) {
 	var meta Metadata
 	err := DecodeFromHex(MetadataV14Data, &meta)
@@ -142,6 +158,30 @@ func TestMetadataV14FindStorageEntryMetadataInvalidStorage(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestMetadataV14FindStorageEntryDefault(t *testing.T) {
+	var meta Metadata
+	err := DecodeFromHex(MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	// ValueQuery
+	value, ok, err := meta.FindStorageEntryDefault("System", "Account")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, make(StorageDataRaw, 80), value)
+
+	// OptionQuery
+	value, ok, err = meta.FindStorageEntryDefault("Staking", "Bonded")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.Nil(t, value)
+
+	_, _, err = meta.FindStorageEntryDefault("System", "Accountz")
+	assert.Error(t, err)
+
+	_, _, err = ExamplaryMetadataV13.FindStorageEntryDefault("System", "Account")
+	assert.EqualError(t, err, "storage defaults are only supported from metadata v14, got v13")
+}
+
 func TestMetadataV14ExistsModuleMetadata(t *testing.T) {
 	var meta Metadata
 	err := DecodeFromHex(MetadataV14Data, &meta)
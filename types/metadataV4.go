@@ -87,6 +87,26 @@ func (m *MetadataV4) FindEventNamesForEventID(eventID EventID) (Text, Text, erro
 	return "", "", fmt.Errorf("module index %v out of range", eventID[0])
 }
 
+func (m *MetadataV4) FindEventIDForEventNames(module Text, event Text) (EventID, error) {
+	mi := uint8(0)
+	for _, mod := range m.Modules {
+		if !mod.HasEvents {
+			continue
+		}
+		if mod.Prefix != module {
+			mi++
+			continue
+		}
+		for ei, e := range mod.Events {
+			if e.Name == event {
+				return EventID{mi, uint8(ei)}, nil
+			}
+		}
+		return EventID{}, fmt.Errorf("event %v not found within module %v", event, module)
+	}
+	return EventID{}, fmt.Errorf("module %v not found in metadata", module)
+}
+
 func (m *MetadataV4) FindConstantValue(_module Text, _constant Text) ([]byte, error) {
 	return nil, fmt.Errorf("constants are only supported from metadata v6 and up")
 }
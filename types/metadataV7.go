@@ -84,6 +84,26 @@ func (m *MetadataV7) FindEventNamesForEventID(eventID EventID) (Text, Text, erro
 	return "", "", fmt.Errorf("module index %v out of range", eventID[0])
 }
 
+func (m *MetadataV7) FindEventIDForEventNames(module Text, event Text) (EventID, error) {
+	mi := uint8(0)
+	for _, mod := range m.Modules {
+		if !mod.HasEvents {
+			continue
+		}
+		if mod.Name != module {
+			mi++
+			continue
+		}
+		for ei, e := range mod.Events {
+			if e.Name == event {
+				return EventID{mi, uint8(ei)}, nil
+			}
+		}
+		return EventID{}, fmt.Errorf("event %v not found within module %v", event, module)
+	}
+	return EventID{}, fmt.Errorf("module %v not found in metadata", module)
+}
+
 func (m *MetadataV7) FindConstantValue(module Text, constant Text) ([]byte, error) {
 	for _, mod := range m.Modules {
 		if mod.Name == module {
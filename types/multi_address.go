@@ -19,6 +19,7 @@ package types
 import (
 	"github.com/btcsuite/btcutil/base58"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
 )
 
 type MultiAddress struct {
@@ -32,6 +33,11 @@ type MultiAddress struct {
 	AsAddress32 [32]byte
 	IsAddress20 bool
 	AsAddress20 [20]byte
+	// IsAccountID20 is not a variant of MultiAddress, it is set for runtimes whose address type is AccountId20, see
+	// Metadata.UsesAccountID20. The account ID is encoded as is, without a variant index. Decoding only sets it when
+	// decoding with metadata, see DecodeExtrinsic.
+	IsAccountID20 bool
+	AsAccountID20 AccountID20
 }
 
 // NewMultiAddressFromAddress creates an MultiAddress from the given SS58 Address
@@ -51,6 +57,25 @@ func NewMultiAddressFromAccountID(b []byte) MultiAddress {
 	}
 }
 
+// NewMultiAddressFromAccountID20 creates a MultiAddress for runtimes whose address type is AccountId20
+func NewMultiAddressFromAccountID20(accountID AccountID20) MultiAddress {
+	return MultiAddress{
+		IsAccountID20: true,
+		AsAccountID20: accountID,
+	}
+}
+
+// NewSignerAddress creates the address of a signer from its public key, Ethereum signers have AccountId20 addresses
+func NewSignerAddress(scheme signature.Scheme, publicKey []byte) MultiAddress {
+	accountID := scheme.AccountID(publicKey)
+
+	if scheme == signature.SchemeEthereum {
+		return NewMultiAddressFromAccountID20(NewAccountID20(accountID))
+	}
+
+	return NewMultiAddressFromAccountID(accountID)
+}
+
 // NewMultiAddressFromHexAccountID creates an Address from the given hex string that contains an AccountID (public key)
 func NewMultiAddressFromHexAccountID(str string) (MultiAddress, error) {
 	b, err := HexDecodeString(str)
@@ -93,6 +118,8 @@ func (m MultiAddress) Encode(encoder scale.Encoder) error {
 		}
 
 		return encoder.Encode(m.AsAddress20)
+	case m.IsAccountID20:
+		return encoder.Encode(m.AsAccountID20)
 	}
 
 	return nil
@@ -81,3 +81,15 @@ func TestNewMultiAddressFromAccountID(t *testing.T) {
 		AsAddress20: [20]byte{},
 	})
 }
+
+func TestNewSignerAddress(t *testing.T) {
+	assert.Equal(t, NewMultiAddressFromAccountID(signature.TestKeyringPairAlice.PublicKey),
+		NewSignerAddress(signature.SchemeSr25519, signature.TestKeyringPairAlice.PublicKey))
+
+	alith := newAlith(t)
+	addr := NewSignerAddress(signature.SchemeEthereum, alith.Public())
+	assert.Equal(t, NewMultiAddressFromAccountID20(NewAccountID20(alith.Address())), addr)
+
+	// AccountID20 addresses are encoded without a variant index
+	assertEncode(t, []encodingAssert{{addr, alith.Address()}})
+}
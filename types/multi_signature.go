@@ -16,7 +16,12 @@
 
 package types
 
-import "github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+import (
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+)
 
 // MultiSignature
 type MultiSignature struct {
@@ -26,6 +31,11 @@ type MultiSignature struct {
 	AsSr25519 Signature      // Sr25519Signature
 	IsEcdsa   bool           // 2:: Ecdsa(EcdsaSignature)
 	AsEcdsa   EcdsaSignature // EcdsaSignature
+	// IsEthereum is not a variant of MultiSignature, it is set for runtimes whose signature type is the
+	// EthereumSignature of Frontier. The signature is encoded as is, without a variant index. Decoding only sets it
+	// when decoding with metadata, see DecodeExtrinsic.
+	IsEthereum bool
+	AsEthereum EcdsaSignature
 }
 
 func (m *MultiSignature) Decode(decoder scale.Decoder) error {
@@ -65,6 +75,8 @@ func (m MultiSignature) Encode(encoder scale.Encoder) error {
 	case m.IsEcdsa:
 		err1 = encoder.PushByte(2)
 		err2 = encoder.Encode(m.AsEcdsa)
+	case m.IsEthereum:
+		err2 = encoder.Encode(m.AsEthereum)
 	}
 
 	if err1 != nil {
@@ -76,3 +88,45 @@ func (m MultiSignature) Encode(encoder scale.Encoder) error {
 
 	return nil
 }
+
+// NewMultiSignature creates a MultiSignature from a signature of the given crypto scheme
+func NewMultiSignature(scheme signature.Scheme, sig []byte) (MultiSignature, error) {
+	switch scheme {
+	case signature.SchemeEd25519:
+		if len(sig) != len(Signature{}) {
+			return MultiSignature{}, fmt.Errorf("expected an ed25519 signature of %d bytes, got %d", len(Signature{}),
+				len(sig))
+		}
+		return MultiSignature{IsEd25519: true, AsEd25519: NewSignature(sig)}, nil
+	case signature.SchemeSr25519:
+		if len(sig) != len(Signature{}) {
+			return MultiSignature{}, fmt.Errorf("expected an sr25519 signature of %d bytes, got %d", len(Signature{}),
+				len(sig))
+		}
+		return MultiSignature{IsSr25519: true, AsSr25519: NewSignature(sig)}, nil
+	case signature.SchemeEcdsa:
+		if len(sig) != len(EcdsaSignature{}) {
+			return MultiSignature{}, fmt.Errorf("expected an ecdsa signature of %d bytes, got %d",
+				len(EcdsaSignature{}), len(sig))
+		}
+		return MultiSignature{IsEcdsa: true, AsEcdsa: NewEcdsaSignature(sig)}, nil
+	case signature.SchemeEthereum:
+		if len(sig) != len(EcdsaSignature{}) {
+			return MultiSignature{}, fmt.Errorf("expected an ethereum signature of %d bytes, got %d",
+				len(EcdsaSignature{}), len(sig))
+		}
+		return MultiSignature{IsEthereum: true, AsEthereum: NewEcdsaSignature(sig)}, nil
+	default:
+		return MultiSignature{}, fmt.Errorf("unsupported signature scheme %v", scheme)
+	}
+}
+
+// signMultiSignature signs the payload with the signer and wraps the signature into a MultiSignature
+func signMultiSignature(signer signature.Signer, payload []byte) (MultiSignature, error) {
+	sig, err := signer.Sign(payload)
+	if err != nil {
+		return MultiSignature{}, err
+	}
+
+	return NewMultiSignature(signer.Scheme(), sig)
+}
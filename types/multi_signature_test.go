@@ -21,7 +21,9 @@ import (
 
 	fuzz "github.com/google/gofuzz"
 
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
 	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
 )
 
 var testMultiSig1 = MultiSignature{IsEd25519: true, AsEd25519: NewSignature(hash64)}
@@ -63,6 +65,13 @@ func TestMultiSignature_Encode(t *testing.T) {
 	})
 }
 
+func TestMultiSignature_Encode_Ethereum(t *testing.T) {
+	// ethereum signatures are encoded without a variant index
+	assertEncode(t, []encodingAssert{
+		{MultiSignature{IsEthereum: true, AsEthereum: NewEcdsaSignature(hash65)}, hash65},
+	})
+}
+
 func TestMultiSignature_Decode(t *testing.T) {
 	assertDecode(t, []decodingAssert{
 		{MustHexDecodeString("0x0001020304050607080900010203040506070809000102030405060708090001020304050607080900010203040506070809000102030405060708090001020304"), testMultiSig1},   //nolint:lll
@@ -70,3 +79,33 @@ func TestMultiSignature_Decode(t *testing.T) {
 		{MustHexDecodeString("0x020102030405060708090001020304050607080900010203040506070809000102030405060708090001020304050607080900010203040506070809000102030405"), testMultiSig3}, //nolint:lll
 	})
 }
+
+func TestNewMultiSignature(t *testing.T) {
+	sig, err := NewMultiSignature(signature.SchemeEd25519, hash64)
+	assert.NoError(t, err)
+	assert.Equal(t, testMultiSig1, sig)
+
+	sig, err = NewMultiSignature(signature.SchemeSr25519, hash64)
+	assert.NoError(t, err)
+	assert.Equal(t, testMultiSig2, sig)
+
+	sig, err = NewMultiSignature(signature.SchemeEcdsa, hash65)
+	assert.NoError(t, err)
+	assert.Equal(t, testMultiSig3, sig)
+
+	_, err = NewMultiSignature(signature.SchemeSr25519, hash65)
+	assert.EqualError(t, err, "expected an sr25519 signature of 64 bytes, got 65")
+
+	_, err = NewMultiSignature(signature.SchemeEcdsa, hash64)
+	assert.EqualError(t, err, "expected an ecdsa signature of 65 bytes, got 64")
+
+	sig, err = NewMultiSignature(signature.SchemeEthereum, hash65)
+	assert.NoError(t, err)
+	assert.Equal(t, MultiSignature{IsEthereum: true, AsEthereum: NewEcdsaSignature(hash65)}, sig)
+
+	_, err = NewMultiSignature(signature.SchemeEthereum, hash64)
+	assert.EqualError(t, err, "expected an ethereum signature of 65 bytes, got 64")
+
+	_, err = NewMultiSignature(signature.Scheme(4), hash64)
+	assert.EqualError(t, err, "unsupported signature scheme unknown scheme 4")
+}
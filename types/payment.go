@@ -0,0 +1,274 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+)
+
+// WeightV2 is the two-dimensional weight of newer runtimes, consisting of the computational time and the size of the
+// proof that is needed to validate a block
+type WeightV2 struct {
+	RefTime   U64
+	ProofSize U64
+}
+
+// NewWeightV2 creates a new WeightV2 type
+func NewWeightV2(refTime, proofSize uint64) WeightV2 {
+	return WeightV2{RefTime: U64(refTime), ProofSize: U64(proofSize)}
+}
+
+func (w *WeightV2) Decode(decoder scale.Decoder) error {
+	refTime, err := decoder.DecodeUintCompact()
+	if err != nil {
+		return err
+	}
+
+	proofSize, err := decoder.DecodeUintCompact()
+	if err != nil {
+		return err
+	}
+
+	w.RefTime = U64(refTime.Uint64())
+	w.ProofSize = U64(proofSize.Uint64())
+
+	return nil
+}
+
+func (w WeightV2) Encode(encoder scale.Encoder) error {
+	if err := encoder.EncodeUintCompact(*new(big.Int).SetUint64(uint64(w.RefTime))); err != nil {
+		return err
+	}
+
+	return encoder.EncodeUintCompact(*new(big.Int).SetUint64(uint64(w.ProofSize)))
+}
+
+// UnmarshalJSON fills WeightV2 with the JSON encoded byte array given by bz. Weights of older runtimes are plain numbers,
+// they are read into the RefTime.
+func (w *WeightV2) UnmarshalJSON(bz []byte) error {
+	var v1 uint64
+	if err := json.Unmarshal(bz, &v1); err == nil {
+		*w = WeightV2{RefTime: U64(v1)}
+		return nil
+	}
+
+	var tmp struct {
+		RefTime        *uint64 `json:"ref_time"`
+		ProofSize      *uint64 `json:"proof_size"`
+		RefTimeCamel   *uint64 `json:"refTime"`
+		ProofSizeCamel *uint64 `json:"proofSize"`
+	}
+	if err := json.Unmarshal(bz, &tmp); err != nil {
+		return err
+	}
+
+	*w = WeightV2{}
+
+	for _, refTime := range []*uint64{tmp.RefTime, tmp.RefTimeCamel} {
+		if refTime != nil {
+			w.RefTime = U64(*refTime)
+		}
+	}
+
+	for _, proofSize := range []*uint64{tmp.ProofSize, tmp.ProofSizeCamel} {
+		if proofSize != nil {
+			w.ProofSize = U64(*proofSize)
+		}
+	}
+
+	return nil
+}
+
+// RuntimeDispatchInfo is the dispatch information of an extrinsic, as returned by payment_queryInfo and the
+// TransactionPaymentApi_query_info runtime API
+type RuntimeDispatchInfo struct {
+	// Weight of the extrinsic
+	Weight WeightV2
+	// Class of the extrinsic
+	Class DispatchClass
+	// PartialFee is the inclusion fee of the extrinsic, without the tip
+	PartialFee U128
+}
+
+// UnmarshalJSON fills RuntimeDispatchInfo with the JSON encoded byte array given by bz
+func (r *RuntimeDispatchInfo) UnmarshalJSON(bz []byte) error {
+	var tmp struct {
+		Weight     WeightV2        `json:"weight"`
+		Class      string          `json:"class"`
+		PartialFee json.RawMessage `json:"partialFee"`
+	}
+	if err := json.Unmarshal(bz, &tmp); err != nil {
+		return err
+	}
+
+	var class DispatchClass
+
+	switch strings.ToLower(tmp.Class) {
+	case "normal":
+		class.IsNormal = true
+	case "operational":
+		class.IsOperational = true
+	case "mandatory":
+		class.IsMandatory = true
+	default:
+		return fmt.Errorf("unknown dispatch class %v", tmp.Class)
+	}
+
+	partialFee, err := numberOrHexToU128(tmp.PartialFee)
+	if err != nil {
+		return fmt.Errorf("invalid partial fee: %w", err)
+	}
+
+	*r = RuntimeDispatchInfo{Weight: tmp.Weight, Class: class, PartialFee: partialFee}
+
+	return nil
+}
+
+// RuntimeDispatchInfoV1 is the dispatch information of an extrinsic of older runtimes, that only have a
+// one-dimensional Weight
+type RuntimeDispatchInfoV1 struct {
+	Weight     Weight
+	Class      DispatchClass
+	PartialFee U128
+}
+
+// FeeDetails is the breakdown of the fee of an extrinsic, as returned by payment_queryFeeDetails and the
+// TransactionPaymentApi_query_fee_details runtime API
+type FeeDetails struct {
+	// HasInclusionFee is false for unsigned extrinsics, that don't pay an inclusion fee
+	HasInclusionFee bool
+	InclusionFee    InclusionFee
+	// Tip is only set by the runtime API, the RPC does not return it
+	Tip U128
+}
+
+func (f *FeeDetails) Decode(decoder scale.Decoder) error {
+	if err := decoder.DecodeOption(&f.HasInclusionFee, &f.InclusionFee); err != nil {
+		return err
+	}
+
+	return decoder.Decode(&f.Tip)
+}
+
+func (f FeeDetails) Encode(encoder scale.Encoder) error {
+	if err := encoder.EncodeOption(f.HasInclusionFee, f.InclusionFee); err != nil {
+		return err
+	}
+
+	return encoder.Encode(f.Tip)
+}
+
+// UnmarshalJSON fills FeeDetails with the JSON encoded byte array given by bz
+func (f *FeeDetails) UnmarshalJSON(bz []byte) error {
+	var tmp struct {
+		InclusionFee *InclusionFee   `json:"inclusionFee"`
+		Tip          json.RawMessage `json:"tip"`
+	}
+	if err := json.Unmarshal(bz, &tmp); err != nil {
+		return err
+	}
+
+	*f = FeeDetails{Tip: NewU128(*big.NewInt(0))}
+
+	if tmp.InclusionFee != nil {
+		f.HasInclusionFee = true
+		f.InclusionFee = *tmp.InclusionFee
+	}
+
+	if len(tmp.Tip) > 0 {
+		tip, err := numberOrHexToU128(tmp.Tip)
+		if err != nil {
+			return fmt.Errorf("invalid tip: %w", err)
+		}
+		f.Tip = tip
+	}
+
+	return nil
+}
+
+// InclusionFee is the fee for including an extrinsic in a block, it is split into a base fee, a fee for the length of
+// the extrinsic and a fee for its weight
+type InclusionFee struct {
+	// BaseFee is the minimum fee of an extrinsic
+	BaseFee U128
+	// LenFee is the fee for the length of the extrinsic
+	LenFee U128
+	// AdjustedWeightFee is the fee for the weight of the extrinsic, adjusted by the fee multiplier
+	AdjustedWeightFee U128
+}
+
+// UnmarshalJSON fills InclusionFee with the JSON encoded byte array given by bz
+func (i *InclusionFee) UnmarshalJSON(bz []byte) error {
+	var tmp struct {
+		BaseFee           json.RawMessage `json:"baseFee"`
+		LenFee            json.RawMessage `json:"lenFee"`
+		AdjustedWeightFee json.RawMessage `json:"adjustedWeightFee"`
+	}
+	if err := json.Unmarshal(bz, &tmp); err != nil {
+		return err
+	}
+
+	var err error
+
+	i.BaseFee, err = numberOrHexToU128(tmp.BaseFee)
+	if err != nil {
+		return fmt.Errorf("invalid base fee: %w", err)
+	}
+
+	i.LenFee, err = numberOrHexToU128(tmp.LenFee)
+	if err != nil {
+		return fmt.Errorf("invalid len fee: %w", err)
+	}
+
+	i.AdjustedWeightFee, err = numberOrHexToU128(tmp.AdjustedWeightFee)
+	if err != nil {
+		return fmt.Errorf("invalid adjusted weight fee: %w", err)
+	}
+
+	return nil
+}
+
+// numberOrHexToU128 parses a balance as returned by the RPC, which is either a JSON number, a decimal string or a hex
+// string
+func numberOrHexToU128(bz json.RawMessage) (U128, error) {
+	s := strings.Trim(strings.TrimSpace(string(bz)), `"`)
+
+	i := new(big.Int)
+	ok := false
+
+	if strings.HasPrefix(s, "0x") {
+		i, ok = i.SetString(s[2:], 16)
+	} else {
+		i, ok = i.SetString(s, 10)
+	}
+
+	if !ok || i.Sign() < 0 {
+		return U128{}, fmt.Errorf("expected a number or a hex string, got %s", bz)
+	}
+
+	// normalize zero, so that parsed values are comparable to values created with big.NewInt
+	if i.Sign() == 0 {
+		i = big.NewInt(0)
+	}
+
+	return NewU128(*i), nil
+}
@@ -0,0 +1,127 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	exampleRuntimeDispatchInfo = RuntimeDispatchInfo{
+		Weight:     NewWeightV2(195000000, 3593),
+		Class:      DispatchClass{IsNormal: true},
+		PartialFee: NewU128(*big.NewInt(15600000001)),
+	}
+	exampleFeeDetails = FeeDetails{
+		HasInclusionFee: true,
+		InclusionFee: InclusionFee{
+			BaseFee:           NewU128(*big.NewInt(125000000)),
+			LenFee:            NewU128(*big.NewInt(1430000000)),
+			AdjustedWeightFee: NewU128(*big.NewInt(0)),
+		},
+		Tip: NewU128(*big.NewInt(10)),
+	}
+)
+
+func TestWeightV2_EncodeDecode(t *testing.T) {
+	assertRoundtrip(t, NewWeightV2(0, 0))
+	assertRoundtrip(t, NewWeightV2(195000000, 3593))
+
+	assertEncode(t, []encodingAssert{
+		{NewWeightV2(1, 64), []byte{0x04, 0x01, 0x01}},
+	})
+}
+
+func TestWeightV2_UnmarshalJSON(t *testing.T) {
+	for _, test := range []struct {
+		input    string
+		expected WeightV2
+	}{
+		{`195000000`, NewWeightV2(195000000, 0)},
+		{`{"ref_time":195000000,"proof_size":3593}`, NewWeightV2(195000000, 3593)},
+		{`{"refTime":195000000,"proofSize":3593}`, NewWeightV2(195000000, 3593)},
+	} {
+		var w WeightV2
+		err := json.Unmarshal([]byte(test.input), &w)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, w)
+	}
+
+	var w WeightV2
+	assert.Error(t, json.Unmarshal([]byte(`"abc"`), &w))
+}
+
+func TestRuntimeDispatchInfo_EncodeDecode(t *testing.T) {
+	assertRoundtrip(t, exampleRuntimeDispatchInfo)
+	assertRoundtrip(t, RuntimeDispatchInfoV1{
+		Weight:     195000000,
+		Class:      DispatchClass{IsOperational: true},
+		PartialFee: NewU128(*big.NewInt(1)),
+	})
+}
+
+func TestRuntimeDispatchInfo_UnmarshalJSON(t *testing.T) {
+	var info RuntimeDispatchInfo
+	err := json.Unmarshal([]byte(
+		`{"weight":{"ref_time":195000000,"proof_size":3593},"class":"normal","partialFee":"15600000001"}`), &info)
+	assert.NoError(t, err)
+	assert.Equal(t, exampleRuntimeDispatchInfo, info)
+
+	err = json.Unmarshal([]byte(`{"weight":195000000,"class":"operational","partialFee":"0x1"}`), &info)
+	assert.NoError(t, err)
+	assert.Equal(t, RuntimeDispatchInfo{
+		Weight:     NewWeightV2(195000000, 0),
+		Class:      DispatchClass{IsOperational: true},
+		PartialFee: NewU128(*big.NewInt(1)),
+	}, info)
+
+	err = json.Unmarshal([]byte(`{"weight":1,"class":"unknown","partialFee":"1"}`), &info)
+	assert.EqualError(t, err, "unknown dispatch class unknown")
+
+	err = json.Unmarshal([]byte(`{"weight":1,"class":"normal","partialFee":"-1"}`), &info)
+	assert.Error(t, err)
+}
+
+func TestFeeDetails_EncodeDecode(t *testing.T) {
+	assertRoundtrip(t, exampleFeeDetails)
+	assertRoundtrip(t, FeeDetails{Tip: NewU128(*big.NewInt(0))})
+}
+
+func TestFeeDetails_UnmarshalJSON(t *testing.T) {
+	var details FeeDetails
+	err := json.Unmarshal([]byte(`{"inclusionFee":{"baseFee":"0x773594000","lenFee":1430000000,`+
+		`"adjustedWeightFee":"0x0"}}`), &details)
+	assert.NoError(t, err)
+	assert.Equal(t, FeeDetails{
+		HasInclusionFee: true,
+		InclusionFee: InclusionFee{
+			BaseFee:           NewU128(*big.NewInt(32000000000)),
+			LenFee:            NewU128(*big.NewInt(1430000000)),
+			AdjustedWeightFee: NewU128(*big.NewInt(0)),
+		},
+		Tip: NewU128(*big.NewInt(0)),
+	}, details)
+
+	err = json.Unmarshal([]byte(`{"inclusionFee":null}`), &details)
+	assert.NoError(t, err)
+	assert.Equal(t, FeeDetails{Tip: NewU128(*big.NewInt(0))}, details)
+}
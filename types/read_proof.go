@@ -0,0 +1,66 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+)
+
+// ReadProof is a proof of storage values at a block, as returned by state_getReadProof and state_getChildReadProof.
+// The proof is the set of encoded trie nodes that are needed to read the values from the state root of the block, see
+// the trie package.
+type ReadProof struct {
+	At    Hash     `json:"at"`
+	Proof [][]byte `json:"proof"`
+}
+
+// UnmarshalJSON fills r with the JSON encoded read proof given by b
+func (r *ReadProof) UnmarshalJSON(b []byte) error {
+	var tmp struct {
+		At    Hash     `json:"at"`
+		Proof []string `json:"proof"`
+	}
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	r.At = tmp.At
+	r.Proof = make([][]byte, len(tmp.Proof))
+
+	for i, node := range tmp.Proof {
+		bz, err := HexDecodeString(node)
+		if err != nil {
+			return err
+		}
+		r.Proof[i] = bz
+	}
+
+	return nil
+}
+
+// MarshalJSON returns a JSON encoded read proof with hex encoded trie nodes
+func (r ReadProof) MarshalJSON() ([]byte, error) {
+	nodes := make([]string, len(r.Proof))
+	for i, node := range r.Proof {
+		nodes[i] = HexEncodeToString(node)
+	}
+
+	return json.Marshal(struct {
+		At    Hash     `json:"at"`
+		Proof []string `json:"proof"`
+	}{r.At, nodes})
+}
@@ -0,0 +1,46 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadProof_UnmarshalMarshalJSON(t *testing.T) {
+	s := []byte("{\"at\":\"0xa230d0b6dc75868237b08d71618f3d19526b8aa346d94c792a4fce0a945b1e3f\",\"proof\":[\"0x42aa04bb\",\"0x00\"]}") //nolint:lll
+
+	var p ReadProof
+
+	err := json.Unmarshal(s, &p)
+	assert.NoError(t, err)
+
+	assert.Equal(t, ReadProof{
+		At:    NewHash(MustHexDecodeString("0xa230d0b6dc75868237b08d71618f3d19526b8aa346d94c792a4fce0a945b1e3f")),
+		Proof: [][]byte{{0x42, 0xaa, 0x04, 0xbb}, {0x00}},
+	}, p)
+
+	b, err := json.Marshal(p)
+	assert.NoError(t, err)
+	assert.Equal(t, s, b)
+
+	err = json.Unmarshal([]byte("{\"proof\":[\"0xzz\"]}"), &p)
+	assert.Error(t, err)
+}
@@ -0,0 +1,218 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+)
+
+// SignedExtensionEncoder encodes data of a signed extension, either the extra data that is part of the extrinsic or
+// the additional signed data that is only part of the signing payload. typ is the type of the data as found in the
+// metadata.
+type SignedExtensionEncoder func(encoder scale.Encoder, meta *MetadataV14, typ Si1LookupTypeID,
+	o SignatureOptions) error
+
+// SignedExtension holds the encoders of a signed extension. A nil encoder is only valid if the corresponding type
+// found in the metadata is empty.
+type SignedExtension struct {
+	Extra            SignedExtensionEncoder
+	AdditionalSigned SignedExtensionEncoder
+}
+
+var (
+	signedExtensionsMu sync.RWMutex
+	signedExtensions   = map[string]SignedExtension{
+		"CheckNonZeroSender": {},
+		"CheckSpecVersion": {
+			AdditionalSigned: signedExtensionValue(func(o SignatureOptions) interface{} { return o.SpecVersion }),
+		},
+		"CheckTxVersion": {
+			AdditionalSigned: signedExtensionValue(func(o SignatureOptions) interface{} { return o.TransactionVersion }),
+		},
+		"CheckGenesis": {
+			AdditionalSigned: signedExtensionValue(func(o SignatureOptions) interface{} { return o.GenesisHash }),
+		},
+		"CheckMortality": checkMortality,
+		"CheckEra":       checkMortality,
+		"CheckNonce": {
+			Extra: signedExtensionValue(func(o SignatureOptions) interface{} { return o.Nonce }),
+		},
+		"CheckWeight": {},
+		"ChargeTransactionPayment": {
+			Extra: signedExtensionValue(func(o SignatureOptions) interface{} { return o.Tip }),
+		},
+		"ChargeAssetTxPayment": {
+			Extra: signedExtensionValue(func(o SignatureOptions) interface{} {
+				return DynamicComposite{{Name: "tip", Value: o.Tip}, {Name: "asset_id", Value: optionValue(o.AssetID)}}
+			}),
+		},
+		"CheckMetadataHash": {
+			Extra: signedExtensionValue(func(o SignatureOptions) interface{} {
+				if o.MetadataHash == nil {
+					return DynamicComposite{{Name: "mode", Value: "Disabled"}}
+				}
+				return DynamicComposite{{Name: "mode", Value: "Enabled"}}
+			}),
+			AdditionalSigned: signedExtensionValue(func(o SignatureOptions) interface{} {
+				if o.MetadataHash == nil {
+					return nil
+				}
+				return optionValue(*o.MetadataHash)
+			}),
+		},
+	}
+)
+
+var checkMortality = SignedExtension{
+	Extra:            signedExtensionValue(func(o SignatureOptions) interface{} { return o.era() }),
+	AdditionalSigned: signedExtensionValue(func(o SignatureOptions) interface{} { return o.BlockHash }),
+}
+
+// signedExtensionValue returns a SignedExtensionEncoder that encodes the value returned by fn using the type found in
+// the metadata, see MetadataV14.EncodeValue.
+func signedExtensionValue(fn func(o SignatureOptions) interface{}) SignedExtensionEncoder {
+	return func(encoder scale.Encoder, meta *MetadataV14, typ Si1LookupTypeID, o SignatureOptions) error {
+		return meta.EncodeValue(encoder, typ, fn(o))
+	}
+}
+
+// optionValue wraps a value into the Some variant of an Option, nil values are encoded as None.
+func optionValue(value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+
+	if _, ok := value.(DynamicVariant); ok {
+		return value
+	}
+
+	return DynamicVariant{Name: "Some", Fields: DynamicComposite{{Value: value}}}
+}
+
+// RegisterSignedExtension adds a signed extension to the registry of known signed extensions, an extension that is
+// already registered with the same identifier is replaced.
+func RegisterSignedExtension(identifier string, ext SignedExtension) {
+	signedExtensionsMu.Lock()
+	defer signedExtensionsMu.Unlock()
+
+	signedExtensions[identifier] = ext
+}
+
+// LookupSignedExtension returns the registered signed extension with the given identifier.
+func LookupSignedExtension(identifier string) (SignedExtension, bool) {
+	signedExtensionsMu.RLock()
+	defer signedExtensionsMu.RUnlock()
+
+	ext, ok := signedExtensions[identifier]
+
+	return ext, ok
+}
+
+// EncodeSignedExtensions encodes the extra and the additional signed data of all signed extensions that are found in
+// the metadata, in the order defined by the metadata. Signed extensions that are not registered are only accepted if
+// both of their types are empty.
+func EncodeSignedExtensions(meta *Metadata, o SignatureOptions) (extra []byte, additional []byte, err error) {
+	if meta.Version != 14 {
+		return nil, nil, fmt.Errorf("signed extensions are only supported from metadata v14, got v%d", meta.Version)
+	}
+
+	m := &meta.AsMetadataV14
+
+	var extraBuf, additionalBuf bytes.Buffer
+
+	extraEncoder := scale.NewEncoder(&extraBuf)
+	additionalEncoder := scale.NewEncoder(&additionalBuf)
+
+	for _, ext := range m.Extrinsic.SignedExtensions {
+		identifier := string(ext.Identifier)
+
+		registered, ok := LookupSignedExtension(identifier)
+		if !ok {
+			if m.isEmptyType(ext.Type.Int64(), 0) && m.isEmptyType(ext.AdditionalSigned.Int64(), 0) {
+				continue
+			}
+
+			return nil, nil, fmt.Errorf("signed extension %v is unknown and has non-empty types, it has to be "+
+				"registered with RegisterSignedExtension", identifier)
+		}
+
+		err = m.encodeSignedExtension(*extraEncoder, registered.Extra, ext.Type, o)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to encode extra of signed extension %v: %w", identifier, err)
+		}
+
+		err = m.encodeSignedExtension(*additionalEncoder, registered.AdditionalSigned, ext.AdditionalSigned, o)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to encode additional signed of signed extension %v: %w", identifier,
+				err)
+		}
+	}
+
+	return extraBuf.Bytes(), additionalBuf.Bytes(), nil
+}
+
+func (m *MetadataV14) encodeSignedExtension(encoder scale.Encoder, fn SignedExtensionEncoder, typ Si1LookupTypeID,
+	o SignatureOptions) error {
+	if fn != nil {
+		return fn(encoder, m, typ, o)
+	}
+
+	if !m.isEmptyType(typ.Int64(), 0) {
+		return fmt.Errorf("no encoder for non-empty type %d", typ.Int64())
+	}
+
+	return nil
+}
+
+// isEmptyType returns true if the type with the given ID has no encoded representation, such as `()`.
+func (m *MetadataV14) isEmptyType(typeID int64, depth int) bool {
+	if depth > maxDynamicValueDepth {
+		return false
+	}
+
+	typ, ok := m.EfficientLookup[typeID]
+	if !ok {
+		return false
+	}
+
+	switch {
+	case typ.Def.IsComposite:
+		for _, field := range typ.Def.Composite.Fields {
+			if !m.isEmptyType(field.Type.Int64(), depth+1) {
+				return false
+			}
+		}
+
+		return true
+	case typ.Def.IsTuple:
+		for _, elem := range typ.Def.Tuple {
+			if !m.isEmptyType(elem.Int64(), depth+1) {
+				return false
+			}
+		}
+
+		return true
+	case typ.Def.IsArray:
+		return typ.Def.Array.Len == 0 || m.isEmptyType(typ.Def.Array.Type.Int64(), depth+1)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,168 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var exampleSignatureOptions = SignatureOptions{
+	BlockHash:          NewHash(MustHexDecodeString("0xec7afaf1cca720ce88c1d1b689d81f0583cc15a97d621cf046dd9abf605ef22f")),
+	GenesisHash:        NewHash(MustHexDecodeString("0xdcd1346701ca8396496e52aa2785b1748deb6db09551b72159dcb3e08991025b")),
+	Nonce:              NewUCompactFromUInt(1),
+	SpecVersion:        268,
+	Tip:                NewUCompactFromUInt(2),
+	TransactionVersion: 2,
+}
+
+func decodeMetadataV14(t *testing.T) *Metadata {
+	var meta Metadata
+	err := DecodeFromHex(MetadataV14Data, &meta)
+	assert.NoError(t, err)
+	return &meta
+}
+
+func renameSignedExtension(meta *Metadata, from, to string) {
+	for i, ext := range meta.AsMetadataV14.Extrinsic.SignedExtensions {
+		if string(ext.Identifier) == from {
+			meta.AsMetadataV14.Extrinsic.SignedExtensions[i].Identifier = Text(to)
+		}
+	}
+}
+
+func TestEncodeSignedExtensions(t *testing.T) {
+	meta := decodeMetadataV14(t)
+
+	extra, additional, err := EncodeSignedExtensions(meta, exampleSignatureOptions)
+	assert.NoError(t, err)
+	assert.Equal(t, MustHexDecodeString(
+		"0x00"+ // CheckMortality, immortal era
+			"04"+ // CheckNonce
+			"08"+ // ChargeAssetTxPayment, tip
+			"00", // ChargeAssetTxPayment, no asset id
+	), extra)
+	assert.Equal(t, MustHexDecodeString(
+		"0x0c010000"+ // CheckSpecVersion
+			"02000000"+ // CheckTxVersion
+			"dcd1346701ca8396496e52aa2785b1748deb6db09551b72159dcb3e08991025b"+ // CheckGenesis
+			"ec7afaf1cca720ce88c1d1b689d81f0583cc15a97d621cf046dd9abf605ef22f", // CheckMortality
+	), additional)
+}
+
+func TestEncodeSignedExtensions_MortalEraAndAssetID(t *testing.T) {
+	meta := decodeMetadataV14(t)
+
+	o := exampleSignatureOptions
+	o.Era = ExtrinsicEra{IsMortalEra: true, AsMortalEra: MortalEra{First: 0x15, Second: 0x02}}
+	o.AssetID = U32(7)
+
+	extra, _, err := EncodeSignedExtensions(meta, o)
+	assert.NoError(t, err)
+	assert.Equal(t, MustHexDecodeString("0x1502"+"04"+"08"+"0107000000"), extra)
+}
+
+func TestEncodeSignedExtensions_UnknownExtension(t *testing.T) {
+	meta := decodeMetadataV14(t)
+
+	// CheckWeight has empty types, so an unknown extension with the same types is skipped
+	renameSignedExtension(meta, "CheckWeight", "CheckUnknownEmpty")
+
+	_, _, err := EncodeSignedExtensions(meta, exampleSignatureOptions)
+	assert.NoError(t, err)
+
+	renameSignedExtension(meta, "CheckNonce", "CheckUnknown")
+
+	_, _, err = EncodeSignedExtensions(meta, exampleSignatureOptions)
+	assert.EqualError(t, err, "signed extension CheckUnknown is unknown and has non-empty types, it has to be "+
+		"registered with RegisterSignedExtension")
+}
+
+func TestEncodeSignedExtensions_RegisteredExtension(t *testing.T) {
+	meta := decodeMetadataV14(t)
+
+	renameSignedExtension(meta, "CheckNonce", "CheckCustomNonce")
+
+	RegisterSignedExtension("CheckCustomNonce", SignedExtension{
+		Extra: func(encoder scale.Encoder, meta *MetadataV14, typ Si1LookupTypeID, o SignatureOptions) error {
+			return meta.EncodeValue(encoder, typ, 42)
+		},
+	})
+
+	ext, ok := LookupSignedExtension("CheckCustomNonce")
+	assert.True(t, ok)
+	assert.NotNil(t, ext.Extra)
+
+	extra, _, err := EncodeSignedExtensions(meta, exampleSignatureOptions)
+	assert.NoError(t, err)
+	assert.Equal(t, MustHexDecodeString("0x00"+"a8"+"08"+"00"), extra)
+}
+
+func TestEncodeSignedExtensions_MissingEncoder(t *testing.T) {
+	meta := decodeMetadataV14(t)
+
+	// CheckWeight has no encoders, it cannot be used for the non-empty types of CheckNonce
+	renameSignedExtension(meta, "CheckNonce", "CheckWeight")
+
+	_, _, err := EncodeSignedExtensions(meta, exampleSignatureOptions)
+	assert.Error(t, err)
+}
+
+func TestEncodeSignedExtensions_UnsupportedMetadata(t *testing.T) {
+	_, _, err := EncodeSignedExtensions(ExamplaryMetadataV13, exampleSignatureOptions)
+	assert.EqualError(t, err, "signed extensions are only supported from metadata v14, got v13")
+}
+
+func TestExtrinsic_SignWithMetadata(t *testing.T) {
+	meta := decodeMetadataV14(t)
+
+	c, err := NewCall(meta, "Balances.transfer", NewMultiAddressFromAccountID(signature.TestKeyringPairBob.PublicKey),
+		NewUCompactFromUInt(6969))
+	assert.NoError(t, err)
+
+	ext := NewExtrinsic(c)
+
+	err = ext.SignWithMetadata(signature.TestKeyringPairAlice, meta, exampleSignatureOptions)
+	assert.NoError(t, err)
+	assert.True(t, ext.IsSigned())
+
+	extra, additional, err := EncodeSignedExtensions(meta, exampleSignatureOptions)
+	assert.NoError(t, err)
+	assert.Equal(t, extra, ext.Signature.Extra)
+
+	mb, err := Encode(ext.Method)
+	assert.NoError(t, err)
+
+	payload := append(append(mb, extra...), additional...)
+
+	ok, err := signature.Verify(payload, ext.Signature.Signature.AsSr25519[:], signature.TestKeyringPairAlice.URI)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	// the extra data is written right after the signature
+	enc, err := Encode(ext)
+	assert.NoError(t, err)
+
+	sig, err := Encode(ext.Signature)
+	assert.NoError(t, err)
+	assert.Equal(t, extra, sig[len(sig)-len(extra):])
+	assert.Contains(t, string(enc), string(append(sig, mb...)))
+}
@@ -17,6 +17,7 @@
 package types
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 
@@ -102,6 +103,121 @@ func CreateStorageKey(meta *Metadata, prefix, method string, args ...[]byte) (St
 	return createKey(meta, method, prefix, stringKey, nil, entryMeta)
 }
 
+// CreateStorageKeyPrefix creates the prefix of the keys of a map whose leading arguments are the given ones, e.g. the
+// prefix of all keys of a double map for a given first argument. Without arguments, the prefix of all keys of the map
+// is returned. The prefix can be used to list the keys of a map, e.g. with state.GetKeysPaged.
+func CreateStorageKeyPrefix(meta *Metadata, prefix, method string, args ...[]byte) (StorageKey, error) {
+	entryMeta, err := meta.FindStorageEntryMetadata(prefix, method)
+	if err != nil {
+		return nil, err
+	}
+
+	if !entryMeta.IsMap() {
+		return nil, fmt.Errorf("%s:%s is not a map", prefix, method)
+	}
+
+	hashers, err := entryMeta.Hashers()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get hashers for %s map", method)
+	}
+
+	if len(args) > len(hashers) {
+		return nil, fmt.Errorf("%s:%s has %d keys, received %d arguments", prefix, method, len(hashers), len(args))
+	}
+
+	return createKeyMap(method, prefix, args, entryMeta)
+}
+
+// DecodeStorageKeyArgs decodes the arguments of a key of the map prefix.method, e.g. a key that was returned by
+// state.GetKeysPaged, into the given targets, which are pointers like the targets of Decode. Arguments without a
+// target, e.g. because fewer targets than map keys are given or a target is nil, are skipped.
+//
+// Arguments can only be decoded if they are hashed with a transparent hasher, which appends the argument to its hash:
+// Blake2_128Concat, Twox64Concat or Identity. The other hashers are opaque, their arguments can only be skipped.
+// Decoding keys requires metadata v14.
+func DecodeStorageKeyArgs(meta *Metadata, prefix, method string, key StorageKey, targets ...interface{}) error {
+	if meta.Version != 14 {
+		return fmt.Errorf("decoding storage keys is only supported from metadata v14, got v%d", meta.Version)
+	}
+
+	entryMeta, err := meta.FindStorageEntryMetadata(prefix, method)
+	if err != nil {
+		return err
+	}
+
+	entry, ok := entryMeta.(StorageEntryMetadataV14)
+	if !ok || !entry.IsMap() {
+		return fmt.Errorf("%s:%s is not a map", prefix, method)
+	}
+
+	keyTypes, err := meta.AsMetadataV14.storageKeyTypes(entry)
+	if err != nil {
+		return err
+	}
+
+	hashers := entry.Type.AsMap.Hashers
+	if len(targets) > len(hashers) {
+		return fmt.Errorf("%s:%s has %d keys, received %d targets", prefix, method, len(hashers), len(targets))
+	}
+
+	keyPrefix := createPrefixedKey(method, prefix)
+	if !bytes.HasPrefix(key, keyPrefix) {
+		return fmt.Errorf("key %#x is not a key of %s:%s", []byte(key), prefix, method)
+	}
+
+	r := bytes.NewReader(key[len(keyPrefix):])
+	decoder := scale.NewDecoder(r)
+
+	for i := range targets {
+		hashLen, transparent := hashers[i].hashLength()
+		if r.Len() < hashLen {
+			return fmt.Errorf("key %#x of %s:%s is too short", []byte(key), prefix, method)
+		}
+
+		_, err = r.Seek(int64(hashLen), io.SeekCurrent)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case !transparent && targets[i] != nil:
+			return fmt.Errorf("argument %d of %s:%s can not be decoded, its hasher is opaque", i, prefix, method)
+		case !transparent:
+			continue
+		case targets[i] == nil:
+			_, err = meta.AsMetadataV14.DecodeValue(*decoder, keyTypes[i])
+		default:
+			err = decoder.Decode(targets[i])
+		}
+
+		if err != nil {
+			return fmt.Errorf("unable to decode argument %d of %s:%s: %w", i, prefix, method, err)
+		}
+	}
+
+	if len(targets) == len(hashers) && r.Len() != 0 {
+		return fmt.Errorf("%d bytes of the key of %s:%s were not decoded", r.Len(), prefix, method)
+	}
+
+	return nil
+}
+
+// storageKeyTypes returns the types of the keys of a map, maps with more than one key have a tuple as key type
+func (m *MetadataV14) storageKeyTypes(entry StorageEntryMetadataV14) ([]Si1LookupTypeID, error) {
+	mapType := entry.Type.AsMap
+	if len(mapType.Hashers) == 1 {
+		return []Si1LookupTypeID{mapType.Key}, nil
+	}
+
+	typ, ok := m.EfficientLookup[mapType.Key.Int64()]
+	if !ok || !typ.Def.IsTuple || len(typ.Def.Tuple) != len(mapType.Hashers) {
+		return nil, fmt.Errorf("expected the key type of %s to be a tuple of %d types", entry.Name,
+			len(mapType.Hashers))
+	}
+
+	return typ.Def.Tuple, nil
+}
+
 // Encode implements encoding for StorageKey, which just unwraps the bytes of StorageKey
 func (s StorageKey) Encode(encoder scale.Encoder) error {
 	return encoder.Write(s)
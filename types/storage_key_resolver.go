@@ -0,0 +1,178 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+)
+
+// StorageKeyResolver resolves raw storage keys, e.g. the keys of a StorageChangeSet, to the pallet and the storage
+// entry they belong to and decodes the arguments of map keys. It requires metadata v14.
+type StorageKeyResolver struct {
+	meta    *MetadataV14
+	entries map[string]storageKeyResolverEntry
+}
+
+type storageKeyResolverEntry struct {
+	pallet string
+	entry  StorageEntryMetadataV14
+}
+
+// DecodedStorageKey is a storage key that was resolved by a StorageKeyResolver
+type DecodedStorageKey struct {
+	// Pallet is the storage prefix of the pallet, usually the name of the pallet
+	Pallet string
+	// Entry is the name of the storage entry
+	Entry string
+	// Args are the arguments of a map key, one per hasher of the map, plain keys have no arguments
+	Args []DecodedStorageKeyArg
+}
+
+// DecodedStorageKeyArg is an argument of a map key
+type DecodedStorageKeyArg struct {
+	// Hasher is the hasher the argument was hashed with
+	Hasher StorageHasherV10
+	// Hash is the hash of the argument, it is empty for the Identity hasher
+	Hash []byte
+	// IsOpaque is true if the argument was hashed with an opaque hasher, i.e. Blake2_128, Blake2_256, Twox128 or
+	// Twox256, which does not append the argument to its hash. The argument can not be recovered from the key.
+	IsOpaque bool
+	// Encoded is the encoded argument, it is nil for opaque arguments
+	Encoded []byte
+	// Value is the argument decoded according to the key type, see MetadataV14.DecodeValue. It is nil for opaque
+	// arguments.
+	Value interface{}
+}
+
+// Decode decodes the encoded argument into the target, e.g. a *AccountID
+func (a DecodedStorageKeyArg) Decode(target interface{}) error {
+	if a.IsOpaque {
+		return fmt.Errorf("the argument can not be decoded, its hasher is opaque")
+	}
+
+	return Decode(a.Encoded, target)
+}
+
+// NewStorageKeyResolver creates a resolver for the storage entries of all pallets found in the metadata. The lookup
+// is keyed by the twox128 hashes of the pallet prefix and the entry name that every storage key starts with.
+func NewStorageKeyResolver(meta *Metadata) (*StorageKeyResolver, error) {
+	if meta.Version != 14 {
+		return nil, fmt.Errorf("resolving storage keys is only supported from metadata v14, got v%d", meta.Version)
+	}
+
+	r := &StorageKeyResolver{
+		meta:    &meta.AsMetadataV14,
+		entries: map[string]storageKeyResolverEntry{},
+	}
+
+	for _, pallet := range r.meta.Pallets {
+		if !pallet.HasStorage {
+			continue
+		}
+
+		prefix := string(pallet.Storage.Prefix)
+
+		for _, entry := range pallet.Storage.Items {
+			key := createPrefixedKey(string(entry.Name), prefix)
+			r.entries[string(key)] = storageKeyResolverEntry{pallet: prefix, entry: entry}
+		}
+	}
+
+	return r, nil
+}
+
+// Resolve returns the pallet and the storage entry of a key and decodes the arguments of map keys. Arguments that were
+// hashed with an opaque hasher can not be recovered, they are returned with IsOpaque set.
+func (r *StorageKeyResolver) Resolve(key StorageKey) (*DecodedStorageKey, error) {
+	const prefixLen = 32
+
+	if len(key) < prefixLen {
+		return nil, fmt.Errorf("storage key %#x is too short", []byte(key))
+	}
+
+	found, ok := r.entries[string(key[:prefixLen])]
+	if !ok {
+		return nil, fmt.Errorf("storage key %#x does not belong to a storage entry of the metadata", []byte(key))
+	}
+
+	decoded := &DecodedStorageKey{Pallet: found.pallet, Entry: string(found.entry.Name)}
+
+	rd := bytes.NewReader(key[prefixLen:])
+
+	if found.entry.IsMap() {
+		args, err := r.decodeArgs(rd, found.entry)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode the key of %s:%s: %w", decoded.Pallet, decoded.Entry, err)
+		}
+
+		decoded.Args = args
+	}
+
+	if rd.Len() != 0 {
+		return nil, fmt.Errorf("%d bytes of the key of %s:%s were not decoded", rd.Len(), decoded.Pallet,
+			decoded.Entry)
+	}
+
+	return decoded, nil
+}
+
+func (r *StorageKeyResolver) decodeArgs(rd *bytes.Reader, entry StorageEntryMetadataV14) ([]DecodedStorageKeyArg,
+	error) {
+	keyTypes, err := r.meta.storageKeyTypes(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := scale.NewDecoder(rd)
+	hashers := entry.Type.AsMap.Hashers
+	args := make([]DecodedStorageKeyArg, len(hashers))
+
+	for i, hasher := range hashers {
+		hashLen, transparent := hasher.hashLength()
+
+		arg := DecodedStorageKeyArg{Hasher: hasher, IsOpaque: !transparent, Hash: make([]byte, hashLen)}
+
+		_, err = io.ReadFull(rd, arg.Hash)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the hash of argument %d: %w", i, err)
+		}
+
+		if transparent {
+			start := rd.Size() - int64(rd.Len())
+
+			arg.Value, err = r.meta.DecodeValue(*decoder, keyTypes[i])
+			if err != nil {
+				return nil, fmt.Errorf("unable to decode argument %d: %w", i, err)
+			}
+
+			// keep the encoded argument, so that it can be decoded into a typed value as well
+			arg.Encoded = make([]byte, rd.Size()-int64(rd.Len())-start)
+			_, err = rd.ReadAt(arg.Encoded, start)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		args[i] = arg
+	}
+
+	return args, nil
+}
@@ -0,0 +1,152 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorageKeyResolver_Resolve(t *testing.T) {
+	m := DecodedMetadataV14Example()
+	alice := NewAccountID(MustHexDecodeString(AlicePubKey))
+
+	r, err := NewStorageKeyResolver(m)
+	assert.NoError(t, err)
+
+	// plain
+	key, err := CreateStorageKey(m, "Timestamp", "Now")
+	assert.NoError(t, err)
+
+	decoded, err := r.Resolve(key)
+	assert.NoError(t, err)
+	assert.Equal(t, &DecodedStorageKey{Pallet: "Timestamp", Entry: "Now"}, decoded)
+
+	// Blake2_128Concat
+	key, err = CreateStorageKey(m, "System", "Account", alice[:])
+	assert.NoError(t, err)
+
+	decoded, err = r.Resolve(key)
+	assert.NoError(t, err)
+	assert.Equal(t, "System", decoded.Pallet)
+	assert.Equal(t, "Account", decoded.Entry)
+	assert.Len(t, decoded.Args, 1)
+	assert.True(t, decoded.Args[0].Hasher.IsBlake2_128Concat)
+	assert.Len(t, decoded.Args[0].Hash, 16)
+	assert.False(t, decoded.Args[0].IsOpaque)
+	assert.Equal(t, alice[:], decoded.Args[0].Encoded)
+	assert.Equal(t, DynamicComposite{{Value: alice[:]}}, decoded.Args[0].Value)
+
+	var who AccountID
+	assert.NoError(t, decoded.Args[0].Decode(&who))
+	assert.Equal(t, alice, who)
+
+	// Twox64Concat and Twox64Concat
+	key, err = CreateStorageKey(m, "Staking", "ErasStakers", []byte{7, 0, 0, 0}, alice[:])
+	assert.NoError(t, err)
+
+	decoded, err = r.Resolve(key)
+	assert.NoError(t, err)
+	assert.Equal(t, "ErasStakers", decoded.Entry)
+	assert.Len(t, decoded.Args, 2)
+	assert.Equal(t, uint32(7), decoded.Args[0].Value)
+	assert.Equal(t, alice[:], decoded.Args[1].Encoded)
+
+	// Identity
+	h := NewHash(MustHexDecodeString("0x0102030405060708091011121314151617181920212223242526272829303132"))
+	key, err = CreateStorageKey(m, "Democracy", "Preimages", h[:])
+	assert.NoError(t, err)
+
+	decoded, err = r.Resolve(key)
+	assert.NoError(t, err)
+	assert.True(t, decoded.Args[0].Hasher.IsIdentity)
+	assert.Empty(t, decoded.Args[0].Hash)
+	assert.Equal(t, h[:], decoded.Args[0].Encoded)
+}
+
+func TestStorageKeyResolver_Resolve_Opaque(t *testing.T) {
+	m := DecodedMetadataV14Example()
+	alice := NewAccountID(MustHexDecodeString(AlicePubKey))
+
+	for _, pallet := range m.AsMetadataV14.Pallets {
+		if pallet.Name != "Multisig" {
+			continue
+		}
+		for _, item := range pallet.Storage.Items {
+			if item.Name == "Multisigs" {
+				item.Type.AsMap.Hashers[0] = StorageHasherV10{IsBlake2_256: true}
+			}
+		}
+	}
+
+	callHash := NewHash(MustHexDecodeString("0x0102030405060708091011121314151617181920212223242526272829303132"))
+	key, err := CreateStorageKey(m, "Multisig", "Multisigs", alice[:], callHash[:])
+	assert.NoError(t, err)
+
+	r, err := NewStorageKeyResolver(m)
+	assert.NoError(t, err)
+
+	decoded, err := r.Resolve(key)
+	assert.NoError(t, err)
+	assert.Len(t, decoded.Args, 2)
+
+	assert.True(t, decoded.Args[0].IsOpaque)
+	assert.Len(t, decoded.Args[0].Hash, 32)
+	assert.Nil(t, decoded.Args[0].Encoded)
+	assert.Nil(t, decoded.Args[0].Value)
+	assert.EqualError(t, decoded.Args[0].Decode(&AccountID{}), "the argument can not be decoded, its hasher is opaque")
+
+	assert.False(t, decoded.Args[1].IsOpaque)
+	assert.Equal(t, callHash[:], decoded.Args[1].Encoded)
+}
+
+func TestStorageKeyResolver_Resolve_Errors(t *testing.T) {
+	m := DecodedMetadataV14Example()
+
+	_, err := NewStorageKeyResolver(ExamplaryMetadataV13)
+	assert.EqualError(t, err, "resolving storage keys is only supported from metadata v14, got v13")
+
+	r, err := NewStorageKeyResolver(m)
+	assert.NoError(t, err)
+
+	_, err = r.Resolve(StorageKey{1, 2, 3})
+	assert.EqualError(t, err, "storage key 0x010203 is too short")
+
+	unknown := make(StorageKey, 32)
+	_, err = r.Resolve(unknown)
+	assert.EqualError(t, err, fmt.Sprintf("storage key %#x does not belong to a storage entry of the metadata",
+		[]byte(unknown)))
+
+	key, err := CreateStorageKey(m, "Timestamp", "Now")
+	assert.NoError(t, err)
+
+	_, err = r.Resolve(append(key, 1))
+	assert.EqualError(t, err, "1 bytes of the key of Timestamp:Now were not decoded")
+
+	key, err = CreateStorageKey(m, "System", "Account", MustHexDecodeString(AlicePubKey))
+	assert.NoError(t, err)
+
+	_, err = r.Resolve(key[:40])
+	assert.EqualError(t, err, "unable to decode the key of System:Account: unable to read the hash of argument 0: "+
+		"unexpected EOF")
+
+	_, err = r.Resolve(key[:50])
+	assert.Error(t, err)
+}
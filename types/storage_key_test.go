@@ -366,6 +366,116 @@ func TestCreateStorageKeyDoubleMapV9(t *testing.T) {
 		hex) //nolint:lll
 }
 
+func TestCreateStorageKeyPrefix(t *testing.T) {
+	m := DecodedMetadataV14Example()
+	alice := MustHexDecodeString(AlicePubKey)
+	era := []byte{7, 0, 0, 0} // U32
+
+	key, err := CreateStorageKey(m, "Staking", "ErasStakers", era, alice)
+	assert.NoError(t, err)
+
+	for _, args := range [][][]byte{nil, {era}, {era, alice}} {
+		prefix, err := CreateStorageKeyPrefix(m, "Staking", "ErasStakers", args...)
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(key.Hex(), prefix.Hex()), "%d args", len(args))
+	}
+
+	prefix, err := CreateStorageKeyPrefix(m, "Staking", "ErasStakers")
+	assert.NoError(t, err)
+	assert.Len(t, prefix, 32)
+
+	_, err = CreateStorageKeyPrefix(m, "Staking", "ErasStakers", era, alice, alice)
+	assert.EqualError(t, err, "Staking:ErasStakers has 2 keys, received 3 arguments")
+
+	_, err = CreateStorageKeyPrefix(m, "Timestamp", "Now")
+	assert.EqualError(t, err, "Timestamp:Now is not a map")
+}
+
+func TestDecodeStorageKeyArgs(t *testing.T) {
+	m := DecodedMetadataV14Example()
+	alice := NewAccountID(MustHexDecodeString(AlicePubKey))
+
+	// Blake2_128Concat
+	key, err := CreateStorageKey(m, "System", "Account", alice[:])
+	assert.NoError(t, err)
+
+	var who AccountID
+	assert.NoError(t, DecodeStorageKeyArgs(m, "System", "Account", key, &who))
+	assert.Equal(t, alice, who)
+
+	// Twox64Concat and Twox64Concat
+	key, err = CreateStorageKey(m, "Staking", "ErasStakers", []byte{7, 0, 0, 0}, alice[:])
+	assert.NoError(t, err)
+
+	var era U32
+	who = AccountID{}
+	assert.NoError(t, DecodeStorageKeyArgs(m, "Staking", "ErasStakers", key, &era, &who))
+	assert.Equal(t, U32(7), era)
+	assert.Equal(t, alice, who)
+
+	era = 0
+	assert.NoError(t, DecodeStorageKeyArgs(m, "Staking", "ErasStakers", key, &era))
+	assert.Equal(t, U32(7), era)
+
+	who = AccountID{}
+	assert.NoError(t, DecodeStorageKeyArgs(m, "Staking", "ErasStakers", key, nil, &who))
+	assert.Equal(t, alice, who)
+
+	// Identity
+	h := NewHash(MustHexDecodeString("0x0102030405060708091011121314151617181920212223242526272829303132"))
+	key, err = CreateStorageKey(m, "Democracy", "Preimages", h[:])
+	assert.NoError(t, err)
+
+	var preimage Hash
+	assert.NoError(t, DecodeStorageKeyArgs(m, "Democracy", "Preimages", key, &preimage))
+	assert.Equal(t, h, preimage)
+}
+
+func TestDecodeStorageKeyArgs_Errors(t *testing.T) {
+	m := DecodedMetadataV14Example()
+	alice := MustHexDecodeString(AlicePubKey)
+
+	key, err := CreateStorageKey(m, "System", "Account", alice)
+	assert.NoError(t, err)
+
+	var who, other AccountID
+	err = DecodeStorageKeyArgs(m, "System", "Account", key, &who, &other)
+	assert.EqualError(t, err, "System:Account has 1 keys, received 2 targets")
+
+	err = DecodeStorageKeyArgs(m, "Balances", "Account", key, &who)
+	assert.EqualError(t, err, fmt.Sprintf("key %#x is not a key of Balances:Account", []byte(key)))
+
+	err = DecodeStorageKeyArgs(m, "System", "Account", append(key, 1), &who)
+	assert.EqualError(t, err, "1 bytes of the key of System:Account were not decoded")
+
+	err = DecodeStorageKeyArgs(m, "System", "Account", key[:40], &who)
+	assert.EqualError(t, err, fmt.Sprintf("key %#x of System:Account is too short", []byte(key[:40])))
+
+	err = DecodeStorageKeyArgs(m, "Timestamp", "Now", key)
+	assert.EqualError(t, err, "Timestamp:Now is not a map")
+
+	err = DecodeStorageKeyArgs(ExamplaryMetadataV13, "System", "Account", key, &who)
+	assert.EqualError(t, err, "decoding storage keys is only supported from metadata v14, got v13")
+
+	// opaque hashers can only be skipped
+	multisigs, err := CreateStorageKey(m, "Multisig", "Multisigs", alice, alice)
+	assert.NoError(t, err)
+
+	for _, pallet := range m.AsMetadataV14.Pallets {
+		if pallet.Name != "Multisig" {
+			continue
+		}
+		for _, item := range pallet.Storage.Items {
+			if item.Name == "Multisigs" {
+				item.Type.AsMap.Hashers[0] = StorageHasherV10{IsTwox128: true}
+			}
+		}
+	}
+
+	err = DecodeStorageKeyArgs(m, "Multisig", "Multisigs", multisigs, &who, &other)
+	assert.EqualError(t, err, "argument 0 of Multisig:Multisigs can not be decoded, its hasher is opaque")
+}
+
 func TestStorageKey_EncodedLength(t *testing.T) {
 	assertEncodedLength(t, []encodedLengthAssert{
 		{NewStorageKey(MustHexDecodeString("0x00")), 1},
@@ -0,0 +1,122 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	FixtureConfigFileName = "fixture.json"
+)
+
+// FixtureConfig holds the configuration used when generating the storage data of a fixture.
+type FixtureConfig struct {
+	// Seed is used for generating deterministic storage data.
+	Seed int64 `json:"seed"`
+
+	// Skip maps the EventRecords fields that should not be generated for the fixture to the reason why they are
+	// skipped, e.g. because their types do not match the ones found in the metadata of the fixture.
+	Skip map[string]string `json:"skip"`
+}
+
+// Skips returns true if the EventRecords field should not be generated.
+func (c *FixtureConfig) Skips(fieldName string) bool {
+	_, ok := c.Skip[fieldName]
+	return ok
+}
+
+// Fixture holds the test data for one spec version of a chain. The test data is found in the
+// `<test data dir>/<chain>/<spec version>` directory.
+type Fixture struct {
+	Dir    string
+	Config *FixtureConfig
+
+	metaFileName    string
+	storageFileName string
+}
+
+// FindFixtures returns the fixtures found in the test data dir. A fixture is a directory that contains a
+// fixture config file.
+func FindFixtures(dirName, metaFileName, storageFileName string) ([]*Fixture, error) {
+	configPaths, err := filepath.Glob(filepath.Join(dirName, "*", "*", FixtureConfigFileName))
+
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(configPaths)
+
+	var fixtures []*Fixture
+
+	for _, configPath := range configPaths {
+		b, err := os.ReadFile(configPath)
+
+		if err != nil {
+			return nil, fmt.Errorf("couldn't read fixture config: %w", err)
+		}
+
+		var config FixtureConfig
+
+		if err := json.Unmarshal(b, &config); err != nil {
+			return nil, fmt.Errorf("couldn't unmarshal fixture config %s: %w", configPath, err)
+		}
+
+		for fieldName, reason := range config.Skip {
+			if reason == "" {
+				return nil, fmt.Errorf("fixture config %s skips %s without a reason", configPath, fieldName)
+			}
+		}
+
+		fixtures = append(fixtures, &Fixture{
+			Dir:             filepath.Dir(configPath),
+			Config:          &config,
+			metaFileName:    metaFileName,
+			storageFileName: storageFileName,
+		})
+	}
+
+	return fixtures, nil
+}
+
+// ReadMetadata reads and decodes the metadata of the fixture.
+func (f *Fixture) ReadMetadata() (*types.Metadata, error) {
+	b, err := os.ReadFile(filepath.Join(f.Dir, f.metaFileName))
+
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read metadata: %w", err)
+	}
+
+	var meta types.Metadata
+
+	if err := types.Decode(b, &meta); err != nil {
+		return nil, fmt.Errorf("couldn't decode metadata: %w", err)
+	}
+
+	return &meta, nil
+}
+
+// WriteStorageData writes the raw storage data of the fixture.
+func (f *Fixture) WriteStorageData(data []byte) error {
+	return os.WriteFile(filepath.Join(f.Dir, f.storageFileName), data, 0644) //nolint:gosec
+}
@@ -0,0 +1,385 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	defaultMaxSequenceLen = 3
+	defaultMaxTopics      = 2
+	defaultMaxDepth       = 16
+)
+
+var (
+	errUnsupportedMetadataVersion = errors.New("synthetic events can only be generated from metadata v14")
+	errTypeNotFound               = errors.New("type not found in metadata")
+	errMaxDepthReached            = errors.New("max type depth reached")
+)
+
+// EventFilter decides whether an event of a pallet should be part of the generated event records.
+type EventFilter func(pallet, event string) bool
+
+// GeneratedEvent holds information about an event that was generated by the Generator.
+type GeneratedEvent struct {
+	Pallet  string
+	Event   string
+	EventID types.EventID
+}
+
+// Generator builds synthetic event records, as they would be found under the `System.Events` storage key,
+// by walking the type registry found in the metadata.
+type Generator struct {
+	meta *types.MetadataV14
+	rand *rand.Rand
+
+	maxSequenceLen int
+	maxTopics      int
+	maxDepth       int
+}
+
+// NewGenerator creates a new Generator for the provided metadata. The seed is used to make the generated data
+// deterministic.
+func NewGenerator(meta *types.Metadata, seed int64) (*Generator, error) {
+	if meta.Version != 14 {
+		return nil, errUnsupportedMetadataVersion
+	}
+
+	return &Generator{
+		meta:           &meta.AsMetadataV14,
+		rand:           rand.New(rand.NewSource(seed)), //nolint:gosec
+		maxSequenceLen: defaultMaxSequenceLen,
+		maxTopics:      defaultMaxTopics,
+		maxDepth:       defaultMaxDepth,
+	}, nil
+}
+
+// GenerateEventRecords generates one event record for every event found in the metadata that is accepted by the
+// filter.
+//
+// The phase of the records is increasing - the first record is emitted during initialization, the last during
+// finalization and all the others while applying extrinsics with consecutive indexes. This allows consumers to
+// restore the original order of the records after decoding them into types.EventRecords.
+func (g *Generator) GenerateEventRecords(filter EventFilter) (types.EventRecordsRaw, []GeneratedEvent, error) {
+	var (
+		generated []GeneratedEvent
+		bodies    [][]byte
+	)
+
+	for _, pallet := range g.meta.Pallets {
+		if !pallet.HasEvents {
+			continue
+		}
+
+		eventType, ok := g.meta.EfficientLookup[pallet.Events.Type.Int64()]
+
+		if !ok {
+			return nil, nil, fmt.Errorf("event type for pallet %s: %w", pallet.Name, errTypeNotFound)
+		}
+
+		if !eventType.Def.IsVariant {
+			return nil, nil, fmt.Errorf("event type for pallet %s is not a variant", pallet.Name)
+		}
+
+		for _, variant := range eventType.Def.Variant.Variants {
+			if filter != nil && !filter(string(pallet.Name), string(variant.Name)) {
+				continue
+			}
+
+			body, err := g.generateEventBody(variant.Fields)
+
+			if err != nil {
+				return nil, nil, fmt.Errorf("couldn't generate event %s.%s: %w", pallet.Name, variant.Name, err)
+			}
+
+			bodies = append(bodies, body)
+
+			generated = append(generated, GeneratedEvent{
+				Pallet:  string(pallet.Name),
+				Event:   string(variant.Name),
+				EventID: types.EventID{byte(pallet.Index), byte(variant.Index)},
+			})
+		}
+	}
+
+	var buf bytes.Buffer
+
+	encoder := scale.NewEncoder(&buf)
+
+	if err := encoder.EncodeUintCompact(*big.NewInt(int64(len(generated)))); err != nil {
+		return nil, nil, err
+	}
+
+	for i, event := range generated {
+		if err := encoder.Encode(phaseForRecord(i, len(generated))); err != nil {
+			return nil, nil, err
+		}
+
+		if err := encoder.Encode(event.EventID); err != nil {
+			return nil, nil, err
+		}
+
+		if err := encoder.Write(bodies[i]); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return buf.Bytes(), generated, nil
+}
+
+// phaseForRecord returns the phase used for the record at index i out of n records.
+func phaseForRecord(i, n int) types.Phase {
+	switch {
+	case i == 0:
+		return types.Phase{IsInitialization: true}
+	case i == n-1:
+		return types.Phase{IsFinalization: true}
+	default:
+		return types.Phase{IsApplyExtrinsic: true, AsApplyExtrinsic: uint32(i)}
+	}
+}
+
+// generateEventBody generates the fields and the topics of an event record.
+func (g *Generator) generateEventBody(fields []types.Si1Field) ([]byte, error) {
+	var buf bytes.Buffer
+
+	encoder := scale.NewEncoder(&buf)
+
+	for _, field := range fields {
+		if err := g.encodeType(encoder, field.Type.Int64(), 0); err != nil {
+			return nil, err
+		}
+	}
+
+	topics := make([]types.Hash, g.rand.Intn(g.maxTopics+1))
+
+	for i := range topics {
+		g.rand.Read(topics[i][:])
+	}
+
+	if err := encoder.Encode(topics); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (g *Generator) encodeType(encoder *scale.Encoder, typeID int64, depth int) error { //nolint:funlen
+	if depth > g.maxDepth {
+		return errMaxDepthReached
+	}
+
+	typ, ok := g.meta.EfficientLookup[typeID]
+
+	if !ok {
+		return fmt.Errorf("type %d: %w", typeID, errTypeNotFound)
+	}
+
+	def := typ.Def
+
+	switch {
+	case def.IsComposite:
+		for _, field := range def.Composite.Fields {
+			if err := g.encodeType(encoder, field.Type.Int64(), depth+1); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case def.IsVariant:
+		if len(def.Variant.Variants) == 0 {
+			return fmt.Errorf("type %d has no variants", typeID)
+		}
+
+		variant := def.Variant.Variants[g.rand.Intn(len(def.Variant.Variants))]
+
+		if err := encoder.PushByte(byte(variant.Index)); err != nil {
+			return err
+		}
+
+		for _, field := range variant.Fields {
+			if err := g.encodeType(encoder, field.Type.Int64(), depth+1); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case def.IsSequence:
+		n := g.sequenceLen(depth)
+
+		if err := encoder.EncodeUintCompact(*big.NewInt(int64(n))); err != nil {
+			return err
+		}
+
+		for i := 0; i < n; i++ {
+			if err := g.encodeType(encoder, def.Sequence.Type.Int64(), depth+1); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case def.IsArray:
+		for i := 0; i < int(def.Array.Len); i++ {
+			if err := g.encodeType(encoder, def.Array.Type.Int64(), depth+1); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case def.IsTuple:
+		for _, elem := range def.Tuple {
+			if err := g.encodeType(encoder, elem.Int64(), depth+1); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case def.IsPrimitive:
+		return g.encodePrimitive(encoder, def.Primitive.Si0TypeDefPrimitive)
+	case def.IsCompact:
+		bitLen, err := g.compactBitLen(def.Compact.Type.Int64(), depth+1)
+
+		if err != nil {
+			return err
+		}
+
+		return encoder.EncodeUintCompact(*g.randomUint(bitLen))
+	case def.IsBitSequence:
+		storeBitLen, err := g.compactBitLen(def.BitSequence.BitStoreType.Int64(), depth+1)
+
+		if err != nil {
+			return err
+		}
+
+		bits := g.rand.Intn(g.maxSequenceLen*8 + 1)
+
+		if err := encoder.EncodeUintCompact(*big.NewInt(int64(bits))); err != nil {
+			return err
+		}
+
+		storeLen := storeBitLen / 8
+		stores := (bits + storeBitLen - 1) / storeBitLen
+
+		return encoder.Write(g.randomBytes(stores * storeLen))
+	default:
+		return fmt.Errorf("type %d has an unsupported type definition", typeID)
+	}
+}
+
+func (g *Generator) sequenceLen(depth int) int {
+	if depth >= g.maxDepth/2 {
+		return 0
+	}
+
+	return g.rand.Intn(g.maxSequenceLen + 1)
+}
+
+// compactBitLen returns the bit length of the primitive that is wrapped by a compact or used as bit store.
+func (g *Generator) compactBitLen(typeID int64, depth int) (int, error) {
+	if depth > g.maxDepth {
+		return 0, errMaxDepthReached
+	}
+
+	typ, ok := g.meta.EfficientLookup[typeID]
+
+	if !ok {
+		return 0, fmt.Errorf("type %d: %w", typeID, errTypeNotFound)
+	}
+
+	switch {
+	case typ.Def.IsPrimitive:
+		switch typ.Def.Primitive.Si0TypeDefPrimitive {
+		case types.IsU8:
+			return 8, nil
+		case types.IsU16:
+			return 16, nil
+		case types.IsU32:
+			return 32, nil
+		case types.IsU64:
+			return 64, nil
+		case types.IsU128:
+			return 128, nil
+		case types.IsU256:
+			return 256, nil
+		}
+	case typ.Def.IsComposite && len(typ.Def.Composite.Fields) == 1:
+		return g.compactBitLen(typ.Def.Composite.Fields[0].Type.Int64(), depth+1)
+	case typ.Def.IsComposite && len(typ.Def.Composite.Fields) == 0,
+		typ.Def.IsTuple && len(typ.Def.Tuple) == 0:
+		return 0, nil
+	}
+
+	return 0, fmt.Errorf("type %d cannot be compact encoded", typeID)
+}
+
+func (g *Generator) encodePrimitive(encoder *scale.Encoder, primitive types.Si0TypeDefPrimitive) error {
+	switch primitive {
+	case types.IsBool:
+		return encoder.Encode(g.rand.Intn(2) == 1)
+	case types.IsChar:
+		return encoder.Encode(uint32('a' + g.rand.Intn(26)))
+	case types.IsStr:
+		b := make([]byte, g.rand.Intn(g.maxSequenceLen*4+1))
+
+		for i := range b {
+			b[i] = byte('a' + g.rand.Intn(26))
+		}
+
+		return encoder.Encode(string(b))
+	case types.IsU8, types.IsI8:
+		return encoder.Write(g.randomBytes(1))
+	case types.IsU16, types.IsI16:
+		return encoder.Write(g.randomBytes(2))
+	case types.IsU32, types.IsI32:
+		return encoder.Write(g.randomBytes(4))
+	case types.IsU64, types.IsI64:
+		return encoder.Write(g.randomBytes(8))
+	case types.IsU128, types.IsI128:
+		return encoder.Write(g.randomBytes(16))
+	case types.IsU256, types.IsI256:
+		return encoder.Write(g.randomBytes(32))
+	default:
+		return fmt.Errorf("unsupported primitive %d", primitive)
+	}
+}
+
+func (g *Generator) randomBytes(n int) []byte {
+	b := make([]byte, n)
+
+	g.rand.Read(b)
+
+	return b
+}
+
+func (g *Generator) randomUint(bitLen int) *big.Int {
+	if bitLen == 0 {
+		return big.NewInt(0)
+	}
+
+	// Favour small values so that all compact encoding modes are covered.
+	bitLen = 1 + g.rand.Intn(bitLen)
+
+	return new(big.Int).Rand(g.rand, new(big.Int).Lsh(big.NewInt(1), uint(bitLen)))
+}
@@ -0,0 +1,119 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestMetadata(t *testing.T) *types.Metadata {
+	var meta types.Metadata
+
+	err := types.DecodeFromHex(types.MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	return &meta
+}
+
+func TestNewGenerator_UnsupportedMetadata(t *testing.T) {
+	g, err := NewGenerator(types.ExamplaryMetadataV13, 1)
+	assert.ErrorIs(t, err, errUnsupportedMetadataVersion)
+	assert.Nil(t, g)
+}
+
+func TestGenerator_GenerateEventRecords(t *testing.T) {
+	meta := newTestMetadata(t)
+
+	g, err := NewGenerator(meta, 1)
+	assert.NoError(t, err)
+
+	raw, events, err := g.GenerateEventRecords(func(pallet, event string) bool {
+		return pallet == "Balances" || (pallet == "System" && event == "ExtrinsicSuccess")
+	})
+	assert.NoError(t, err)
+	assert.Len(t, events, 11)
+
+	for _, event := range events {
+		if event.Pallet != "Balances" {
+			assert.Equal(t, "System", event.Pallet)
+			assert.Equal(t, "ExtrinsicSuccess", event.Event)
+		}
+	}
+
+	var records types.EventRecords
+
+	err = raw.DecodeEventRecords(meta, &records)
+	assert.NoError(t, err)
+
+	assert.Len(t, records.System_ExtrinsicSuccess, 1)
+	assert.True(t, records.System_ExtrinsicSuccess[0].Phase.IsInitialization)
+	assert.Len(t, records.Balances_Transfer, 1)
+	assert.Equal(t, types.Phase{IsApplyExtrinsic: true, AsApplyExtrinsic: 3}, records.Balances_Transfer[0].Phase)
+	assert.Len(t, records.Balances_Slashed, 1)
+	assert.True(t, records.Balances_Slashed[0].Phase.IsFinalization)
+}
+
+func TestGenerator_GenerateEventRecords_Deterministic(t *testing.T) {
+	meta := newTestMetadata(t)
+
+	g1, err := NewGenerator(meta, 42)
+	assert.NoError(t, err)
+
+	g2, err := NewGenerator(meta, 42)
+	assert.NoError(t, err)
+
+	g3, err := NewGenerator(meta, 43)
+	assert.NoError(t, err)
+
+	raw1, events1, err := g1.GenerateEventRecords(nil)
+	assert.NoError(t, err)
+
+	raw2, events2, err := g2.GenerateEventRecords(nil)
+	assert.NoError(t, err)
+
+	raw3, events3, err := g3.GenerateEventRecords(nil)
+	assert.NoError(t, err)
+
+	assert.Equal(t, raw1, raw2)
+	assert.Equal(t, events1, events2)
+
+	assert.NotEqual(t, raw1, raw3)
+	assert.Equal(t, events1, events3)
+}
+
+func TestGenerator_GenerateEventRecords_NoEvents(t *testing.T) {
+	meta := newTestMetadata(t)
+
+	g, err := NewGenerator(meta, 1)
+	assert.NoError(t, err)
+
+	raw, events, err := g.GenerateEventRecords(func(string, string) bool {
+		return false
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+	assert.Equal(t, types.EventRecordsRaw{0}, raw)
+}
+
+func TestPhaseForRecord(t *testing.T) {
+	assert.Equal(t, types.Phase{IsInitialization: true}, phaseForRecord(0, 3))
+	assert.Equal(t, types.Phase{IsApplyExtrinsic: true, AsApplyExtrinsic: 1}, phaseForRecord(1, 3))
+	assert.Equal(t, types.Phase{IsFinalization: true}, phaseForRecord(2, 3))
+}
@@ -17,7 +17,6 @@
 package main
 
 import (
-	"context"
 	"fmt"
 	"os"
 	"reflect"
@@ -25,8 +24,7 @@ import (
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 )
 
-func main() { //nolint: funlen
-	ctx := context.Background()
+func main() {
 	args := os.Args
 
 	if len(args) != 4 {
@@ -42,124 +40,59 @@ func main() { //nolint: funlen
 	fmt.Printf("Test data meta file name - %s\n", metaFileName)
 	fmt.Printf("Test data storage file name - %s\n", storageFileName)
 
-	if err := os.RemoveAll(dirName); err != nil {
-		fmt.Println("Couldn't remove test dir -", err)
+	fixtures, err := FindFixtures(dirName, metaFileName, storageFileName)
 
+	if err != nil {
+		fmt.Println("Couldn't find fixtures -", err)
 		os.Exit(1)
 	}
 
-	parser := NewParser()
-
-	t := reflect.TypeOf(types.EventRecords{})
-
-	requestMap := make(map[ClientOpts][]*ReqData)
-
-	for i := 0; i < t.NumField(); i++ {
-		field := t.Field(i)
-
-		if parser.CanSkip(field) {
-			fmt.Println("Skipping field -", field.Name)
-			continue
-		}
-
-		fieldInfo, err := parser.ParseField(field)
+	for _, fixture := range fixtures {
+		count, err := generateFixture(fixture)
 
 		if err != nil {
-			fmt.Println("Couldn't parse field -", err)
+			fmt.Printf("Couldn't generate fixture %s - %s\n", fixture.Dir, err)
 			os.Exit(1)
 		}
 
-		if reqData, ok := requestMap[*fieldInfo.ClientOpts]; ok {
-			reqData = append(reqData, fieldInfo.ReqData)
-			requestMap[*fieldInfo.ClientOpts] = reqData
-		} else {
-			requestMap[*fieldInfo.ClientOpts] = []*ReqData{fieldInfo.ReqData}
-		}
-	}
-
-	count := 0
-
-	for clientOpts, reqData := range requestMap {
-		c, err := NewClient(clientOpts)
-
-		if err != nil {
-			fmt.Println("Couldn't create new client -", err)
-			os.Exit(1)
-		}
-
-		for _, reqDatum := range reqData {
-			testData, err := c.GetTestData(ctx, reqDatum)
-
-			if err != nil {
-				fmt.Printf("Couldn't get raw data for %s %s - %s\n", reqDatum.Module, reqDatum.Call, err)
-
-				continue
-			}
-
-			if err := writeTestData(testData, dirName, metaFileName, storageFileName, count); err != nil {
-				fmt.Println("Couldn't create test files -", err)
-				os.Exit(1)
-			}
-
-			count++
-		}
+		fmt.Printf("Generated %d events for %s\n", count, fixture.Dir)
 	}
 }
 
-func writeTestData(
-	testData *TestData,
-	dirName string,
-	metaFileName string,
-	storageFileName string,
-	count int,
-) error {
-	if err := os.MkdirAll(fmt.Sprintf("%s/%d", dirName, count), os.ModePerm); err != nil {
-		return err
-	}
-
-	metaFile, err := os.Create(fmt.Sprintf("%s/%d/%s", dirName, count, metaFileName))
+func generateFixture(fixture *Fixture) (int, error) {
+	meta, err := fixture.ReadMetadata()
 
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	defer metaFile.Close()
+	generator, err := NewGenerator(meta, fixture.Config.Seed)
 
-	if _, err := metaFile.Write(testData.Meta); err != nil {
-		return err
+	if err != nil {
+		return 0, err
 	}
 
-	storageFile, err := os.Create(fmt.Sprintf("%s/%d/%s", dirName, count, storageFileName))
+	eventRecordsType := reflect.TypeOf(types.EventRecords{})
 
-	if err != nil {
-		return err
-	}
+	filter := func(pallet, event string) bool {
+		fieldName := fmt.Sprintf("%s_%s", pallet, event)
 
-	defer storageFile.Close()
+		if _, ok := eventRecordsType.FieldByName(fieldName); !ok {
+			return false
+		}
 
-	if _, err := storageFile.Write(testData.StorageData); err != nil {
-		return err
+		return !fixture.Config.Skips(fieldName)
 	}
 
-	infoFile, err := os.Create(fmt.Sprintf("%s/%d/info.txt", dirName, count))
+	raw, events, err := generator.GenerateEventRecords(filter)
 
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	defer infoFile.Close()
-
-	infoStr := fmt.Sprintf(
-		"Blockchain - %s\nBlock number - %d\nApi URL - %s\nWs URL - %s\n",
-		testData.Blockchain,
-		testData.BlockNumber,
-		testData.APIURL,
-		testData.WsURL,
-	)
-
-	if _, err := infoFile.WriteString(infoStr); err != nil {
-		return err
+	if err := fixture.WriteStorageData(raw); err != nil {
+		return 0, err
 	}
 
-	return nil
+	return len(events), nil
 }
@@ -1,5 +1,3 @@
-//go:build types_test
-
 // Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
 //
 // Copyright 2019 Centrifuge GmbH
@@ -20,65 +18,49 @@ package test
 
 import (
 	"os"
-	"path"
+	"path/filepath"
 	"testing"
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
 )
 
 //go:generate go run ./test-gen test_data meta_bytes storage_bytes
 
-func TestTypesDecode(t *testing.T) {
-	dirEntries, err := os.ReadDir("test_data")
-
-	if err != nil {
-		t.Errorf("Couldn't read test data dir - %s", err)
-	}
-
-	for _, dirEntry := range dirEntries {
-		if !dirEntry.IsDir() {
-			continue
-		}
-
-		subdirPath := path.Join("test_data", dirEntry.Name())
-
-		subdirEntries, err := os.ReadDir(subdirPath)
+const (
+	testDataDir     = "test_data"
+	metaFileName    = "meta_bytes"
+	storageFileName = "storage_bytes"
+)
 
-		if err != nil {
-			t.Errorf("Couldn't read sub dir - %s", err)
-		}
+func TestTypesDecode(t *testing.T) {
+	fixtureDirs, err := filepath.Glob(filepath.Join(testDataDir, "*", "*"))
+	assert.NoError(t, err)
+	assert.NotEmpty(t, fixtureDirs)
 
-		var metadata types.Metadata
-		var storageData []byte
+	for _, fixtureDir := range fixtureDirs {
+		fixtureDir := fixtureDir
 
-		for _, subdirEntry := range subdirEntries {
-			if path.Ext(subdirEntry.Name()) == ".txt" {
-				// Skip info file
-				continue
-			}
+		t.Run(fixtureDir, func(t *testing.T) {
+			metaBytes, err := os.ReadFile(filepath.Join(fixtureDir, metaFileName))
+			assert.NoError(t, err)
 
-			testDataPath := path.Join(subdirPath, subdirEntry.Name())
+			storageData, err := os.ReadFile(filepath.Join(fixtureDir, storageFileName))
+			assert.NoError(t, err)
 
-			b, err := os.ReadFile(testDataPath)
+			var metadata types.Metadata
 
-			if err != nil {
-				t.Errorf("Couldn't read meta file - %s", err)
-			}
+			err = types.Decode(metaBytes, &metadata)
+			assert.NoError(t, err)
 
-			switch subdirEntry.Name() {
-			case "meta_bytes":
-				if err := types.Decode(b, &metadata); err != nil {
-					t.Errorf("Couldn't decode Metadata - %s", err)
-				}
-			case "storage_bytes":
-				storageData = b
-			}
-		}
+			events := types.EventRecords{}
 
-		events := types.EventRecords{}
+			err = types.EventRecordsRaw(storageData).DecodeEventRecords(&metadata, &events)
+			assert.NoError(t, err)
 
-		if err := types.EventRecordsRaw(storageData).DecodeEventRecords(&metadata, &events); err != nil {
-			t.Errorf("Couldn't decode events for %s - %s", subdirPath, err)
-		}
+			encoded, err := types.EncodeEventRecords(&metadata, &events)
+			assert.NoError(t, err)
+			assert.Equal(t, types.EventRecordsRaw(storageData), encoded, "event records round trip")
+		})
 	}
 }
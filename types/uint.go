@@ -241,7 +241,7 @@ func BigIntToUintBytes(i *big.Int, bytelen int) ([]byte, error) {
 	}
 
 	max := big.NewInt(0).Exp(big.NewInt(2), big.NewInt(int64(bytelen*8)), nil)
-	if i.CmpAbs(max) > 0 {
+	if i.CmpAbs(max) >= 0 {
 		return nil, fmt.Errorf("cannot encode big.Int to []byte: given big.Int exceeds highest number "+
 			"%v for an uint with %v bits", max, bytelen*8)
 	}
@@ -457,6 +457,24 @@ func TestBigIntToUintBytes(t *testing.T) {
 		"uint with 8 bits")
 }
 
+func TestBigIntToUintBytes_Boundary(t *testing.T) {
+	res, err := BigIntToUintBytes(big.NewInt(255), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0xff}, res)
+
+	_, err = BigIntToUintBytes(big.NewInt(256), 1)
+	assert.EqualError(t, err, "cannot encode big.Int to []byte: given big.Int exceeds highest number 256 for an "+
+		"uint with 8 bits")
+
+	max := big.NewInt(0).Lsh(big.NewInt(1), 128)
+	_, err = BigIntToUintBytes(max, 16)
+	assert.Error(t, err)
+
+	res, err = BigIntToUintBytes(big.NewInt(0).Sub(max, big.NewInt(1)), 16)
+	assert.NoError(t, err)
+	assert.Equal(t, MustHexDecodeString("0xffffffffffffffffffffffffffffffff"), res)
+}
+
 func TestUintBytesToBigInt(t *testing.T) {
 	res, err := UintBytesToBigInt(MustHexDecodeString("0x0004"))
 	assert.NoError(t, err)